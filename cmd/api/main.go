@@ -1,44 +1,212 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/gin-gonic/gin"
+	"go-backend/internal/infrastructure/buildinfo"
+	"go-backend/internal/infrastructure/config"
 	"go-backend/internal/infrastructure/database"
+	"go-backend/internal/infrastructure/jwtkeys"
+	"go-backend/internal/infrastructure/logging"
+	"go-backend/internal/infrastructure/metrics"
+	"go-backend/internal/infrastructure/tracing"
 	"go-backend/internal/interfaces/http/router"
+	postEntity "go-backend/internal/modules/post/domain/entity"
+	projectEntity "go-backend/internal/modules/project/domain/entity"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/seo"
+	"gorm.io/gorm"
 )
 
 func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Error loading .env file")
+	// Load configuration
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	printVersion := flag.Bool("version", false, "print the build version and exit")
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatal("Failed to load configuration: ", err)
+	}
+
+	info := buildinfo.Get()
+	if *printVersion {
+		fmt.Printf("%s (commit %s, built %s, %s)\n", info.Version, info.Commit, info.BuildDate, info.GoVersion)
+		return
 	}
 
+	if *printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal("Failed to print configuration: ", err)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	if *printConfig {
+		return
+	}
+
+	// Log through slog from here on, including the standard log package
+	logger, err := logging.New(cfg.Log, os.Stderr)
+	if err != nil {
+		log.Fatal("Failed to create logger: ", err)
+	}
+	slog.SetDefault(logger)
+
+	// Set up trace propagation and export. The stdout exporter writes to
+	// standard output, apart from the logs on standard error.
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, os.Stdout)
+	if err != nil {
+		log.Fatal("Failed to set up tracing: ", err)
+	}
+
+	if cfg.IsProduction() {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// Load JWT signing keys
+	keys, err := jwtkeys.FromConfig(cfg.JWT)
+	if err != nil {
+		log.Fatal("Failed to load JWT keys: ", err)
+	}
+	jwtkeys.SetDefault(keys)
+
+	// Canonical URLs of public pages are made from the site URL
+	seo.SetSiteURL(cfg.SEO.SiteURL)
+
 	// Initialize database
-	db, err := database.Connect()
+	db, err := database.Connect(cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	if cfg.Metrics.Enabled {
+		if err := metrics.InstrumentDB(db, cfg.Database.Name); err != nil {
+			log.Fatal("Failed to instrument database: ", err)
+		}
+	}
+
+	if cfg.Tracing.Exporter != "none" {
+		if err := db.Use(tracing.GormPlugin{}); err != nil {
+			log.Fatal("Failed to trace database: ", err)
+		}
+	}
+
 	// Run migrations
 	if err := database.Migrate(db); err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
 	// Setup router
-	r := router.NewRouter(db)
+	r := router.NewRouter(db, cfg)
 	r.SetupRoutes()
 
 	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.HTTP.Port),
+		Handler:           r,
+		ReadTimeout:       time.Duration(cfg.HTTP.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(cfg.HTTP.ReadHeaderTimeout),
+		WriteTimeout:      time.Duration(cfg.HTTP.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.HTTP.IdleTimeout),
+		MaxHeaderBytes:    cfg.HTTP.MaxHeaderBytes,
+	}
+
+	serverErr := make(chan error, 2)
+	go func() {
+		slog.Info("Server starting", "version", info.Version, "commit", info.Commit, "port", cfg.HTTP.Port)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	// Serve metrics on the admin port, if any
+	var adminSrv *http.Server
+	if cfg.Metrics.Enabled && cfg.Metrics.AdminPort != 0 {
+		mux := http.NewServeMux()
+		mux.Handle(cfg.Metrics.Path, metrics.Handler())
+		adminSrv = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Metrics.AdminPort),
+			Handler:           mux,
+			ReadHeaderTimeout: time.Duration(cfg.HTTP.ReadHeaderTimeout),
+		}
+		go func() {
+			slog.Info("Metrics server starting", "port", cfg.Metrics.AdminPort)
+			serverErr <- adminSrv.ListenAndServe()
+		}()
 	}
 
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Publish scheduled posts and projects when they are due
+	stopPublisher := func() {}
+	if cfg.Publishing.Interval > 0 {
+		publisher := publishing.NewPublisher(db, &postEntity.Post{}, &projectEntity.Project{})
+		publisherCtx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			publisher.Run(publisherCtx, time.Duration(cfg.Publishing.Interval))
+		}()
+		stopPublisher = func() {
+			cancel()
+			<-done
+		}
 	}
+
+	// Wait for SIGINT or SIGTERM. After stop, a second signal kills the
+	// process without waiting for the drain.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-serverErr:
+		log.Fatal("Failed to start server: ", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	shutdown(srv, adminSrv, r, db, cfg.HTTP, stopPublisher, shutdownTracing)
+}
+
+// shutdown stops the server gracefully. The health check reports "draining"
+// for the drain delay while requests are still served, then in-flight
+// requests get up to the shutdown timeout to finish. The admin server, if
+// any, is stopped after them so that the drain shows up in the metrics.
+// Then the publisher is stopped, the database pool is closed and pending
+// spans are flushed.
+func shutdown(srv, adminSrv *http.Server, r *router.Router, db *gorm.DB, cfg config.HTTPConfig, stopPublisher func(), shutdownTracing func(context.Context) error) {
+	slog.Info("Shutting down", "drain_delay", cfg.DrainDelay.String())
+	r.Drain()
+	time.Sleep(time.Duration(cfg.DrainDelay))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout))
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Error("Failed to finish in-flight requests", "error", err)
+	}
+	if adminSrv != nil {
+		if err := adminSrv.Shutdown(ctx); err != nil {
+			slog.Error("Failed to stop metrics server", "error", err)
+		}
+	}
+
+	stopPublisher()
+
+	sqlDB, err := db.DB()
+	if err == nil {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		slog.Error("Failed to close database", "error", err)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+
+	slog.Info("Server stopped")
 }
@@ -279,17 +279,21 @@ type {{.ModuleTitle}} struct {
 var repositoryTemplate = `package repository
 
 import (
+	"context"
+
 	"go-backend/internal/modules/{{.ModuleLower}}/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 type {{.ModuleTitle}}Repository interface {
-	Create({{.ModuleLower}} *entity.{{.ModuleTitle}}) error
-	GetByID(id uint) (*entity.{{.ModuleTitle}}, error)
-	GetAll() ([]entity.{{.ModuleTitle}}, error)
-	Update({{.ModuleLower}} *entity.{{.ModuleTitle}}) error
-	Delete(id uint) error
-	GetByUserID(userID uint) ([]entity.{{.ModuleTitle}}, error)
+	Create(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error
+	GetByID(ctx context.Context, id uint) (*entity.{{.ModuleTitle}}, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error)
+	Update(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error
+	Delete(ctx context.Context, id uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error)
 }
 
 type {{.ModuleLower}}Repository struct {
@@ -300,51 +304,52 @@ func New{{.ModuleTitle}}Repository(db *gorm.DB) {{.ModuleTitle}}Repository {
 	return &{{.ModuleLower}}Repository{db: db}
 }
 
-func (r *{{.ModuleLower}}Repository) Create({{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
-	return r.db.Create({{.ModuleLower}}).Error
+func (r *{{.ModuleLower}}Repository) Create(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
+	return r.db.WithContext(ctx).Create({{.ModuleLower}}).Error
 }
 
-func (r *{{.ModuleLower}}Repository) GetByID(id uint) (*entity.{{.ModuleTitle}}, error) {
+func (r *{{.ModuleLower}}Repository) GetByID(ctx context.Context, id uint) (*entity.{{.ModuleTitle}}, error) {
 	var {{.ModuleLower}} entity.{{.ModuleTitle}}
-	err := r.db.First(&{{.ModuleLower}}, id).Error
+	err := r.db.WithContext(ctx).First(&{{.ModuleLower}}, id).Error
 	return &{{.ModuleLower}}, err
 }
 
-func (r *{{.ModuleLower}}Repository) GetAll() ([]entity.{{.ModuleTitle}}, error) {
-	var {{.ModulePlural}} []entity.{{.ModuleTitle}}
-	err := r.db.Find(&{{.ModulePlural}}).Error
-	return {{.ModulePlural}}, err
+func (r *{{.ModuleLower}}Repository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	return pagination.Find[entity.{{.ModuleTitle}}](r.db.WithContext(ctx).Scopes(f.Scope), params)
 }
 
-func (r *{{.ModuleLower}}Repository) Update({{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
-	return r.db.Save({{.ModuleLower}}).Error
+func (r *{{.ModuleLower}}Repository) Update(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
+	return r.db.WithContext(ctx).Save({{.ModuleLower}}).Error
 }
 
-func (r *{{.ModuleLower}}Repository) Delete(id uint) error {
-	return r.db.Delete(&entity.{{.ModuleTitle}}{}, id).Error
+func (r *{{.ModuleLower}}Repository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.{{.ModuleTitle}}{}, id).Error
 }
 
-func (r *{{.ModuleLower}}Repository) GetByUserID(userID uint) ([]entity.{{.ModuleTitle}}, error) {
-	var {{.ModulePlural}} []entity.{{.ModuleTitle}}
-	err := r.db.Where("user_id = ?", userID).Find(&{{.ModulePlural}}).Error
-	return {{.ModulePlural}}, err
+func (r *{{.ModuleLower}}Repository) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	return pagination.Find[entity.{{.ModuleTitle}}](r.db.WithContext(ctx).Where("user_id = ?", userID), params)
 }`
 
 var serviceTemplate = `package service
 
 import (
-	"errors"
+	"context"
+	"go-backend/internal/infrastructure/tracing"
 	"go-backend/internal/modules/{{.ModuleLower}}/domain/entity"
 	"go-backend/internal/modules/{{.ModuleLower}}/domain/repository"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type {{.ModuleTitle}}Service interface {
-	Create({{.ModuleLower}} *entity.{{.ModuleTitle}}) error
-	GetByID(id uint) (*entity.{{.ModuleTitle}}, error)
-	GetAll() ([]entity.{{.ModuleTitle}}, error)
-	Update({{.ModuleLower}} *entity.{{.ModuleTitle}}, userID uint) error
-	Delete(id, userID uint) error
-	GetByUserID(userID uint) ([]entity.{{.ModuleTitle}}, error)
+	Create(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error
+	GetByID(ctx context.Context, id uint) (*entity.{{.ModuleTitle}}, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error)
+	Update(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}, userID uint) error
+	Delete(ctx context.Context, id, userID uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error)
 }
 
 type {{.ModuleLower}}Service struct {
@@ -355,46 +360,68 @@ func New{{.ModuleTitle}}Service(repo repository.{{.ModuleTitle}}Repository) {{.M
 	return &{{.ModuleLower}}Service{repo: repo}
 }
 
-func (s *{{.ModuleLower}}Service) Create({{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
-	return s.repo.Create({{.ModuleLower}})
+func (s *{{.ModuleLower}}Service) Create(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
+	ctx, span := tracing.Start(ctx, "{{.ModuleTitle}}Service.Create")
+	defer span.End()
+
+	return s.repo.Create(ctx, {{.ModuleLower}})
 }
 
-func (s *{{.ModuleLower}}Service) GetByID(id uint) (*entity.{{.ModuleTitle}}, error) {
-	return s.repo.GetByID(id)
+func (s *{{.ModuleLower}}Service) GetByID(ctx context.Context, id uint) (*entity.{{.ModuleTitle}}, error) {
+	ctx, span := tracing.Start(ctx, "{{.ModuleTitle}}Service.GetByID")
+	defer span.End()
+
+	{{.ModuleLower}}, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.NotFoundOr(err, "{{.ModuleLower}} not found")
+	}
+	return {{.ModuleLower}}, nil
 }
 
-func (s *{{.ModuleLower}}Service) GetAll() ([]entity.{{.ModuleTitle}}, error) {
-	return s.repo.GetAll()
+func (s *{{.ModuleLower}}Service) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "{{.ModuleTitle}}Service.GetAll")
+	defer span.End()
+
+	return s.repo.GetAll(ctx, f, params)
 }
 
-func (s *{{.ModuleLower}}Service) Update({{.ModuleLower}} *entity.{{.ModuleTitle}}, userID uint) error {
-	existing, err := s.repo.GetByID({{.ModuleLower}}.ID)
+func (s *{{.ModuleLower}}Service) Update(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}, userID uint) error {
+	ctx, span := tracing.Start(ctx, "{{.ModuleTitle}}Service.Update")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, {{.ModuleLower}}.ID)
 	if err != nil {
-		return err
+		return apperror.NotFoundOr(err, "{{.ModuleLower}} not found")
 	}
 
-	if existing.UserID != userID {
-		return errors.New("unauthorized: you can only update your own {{.ModulePlural}}")
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "update", "{{.ModulePlural}}"); err != nil {
+		return err
 	}
 
-	return s.repo.Update({{.ModuleLower}})
+	return s.repo.Update(ctx, {{.ModuleLower}})
 }
 
-func (s *{{.ModuleLower}}Service) Delete(id, userID uint) error {
-	existing, err := s.repo.GetByID(id)
+func (s *{{.ModuleLower}}Service) Delete(ctx context.Context, id, userID uint) error {
+	ctx, span := tracing.Start(ctx, "{{.ModuleTitle}}Service.Delete")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return apperror.NotFoundOr(err, "{{.ModuleLower}} not found")
 	}
 
-	if existing.UserID != userID {
-		return errors.New("unauthorized: you can only delete your own {{.ModulePlural}}")
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "delete", "{{.ModulePlural}}"); err != nil {
+		return err
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(ctx, id)
 }
 
-func (s *{{.ModuleLower}}Service) GetByUserID(userID uint) ([]entity.{{.ModuleTitle}}, error) {
-	return s.repo.GetByUserID(userID)
+func (s *{{.ModuleLower}}Service) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "{{.ModuleTitle}}Service.GetByUserID")
+	defer span.End()
+
+	return s.repo.GetByUserID(ctx, userID, params)
 }`
 
 var handlerTemplate = `package handlers
@@ -407,15 +434,12 @@ import (
 	"go-backend/internal/modules/{{.ModuleLower}}/domain/entity"
 	"go-backend/internal/modules/{{.ModuleLower}}/domain/service"
 	"go-backend/internal/modules/{{.ModuleLower}}/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 )
 
-type Response struct {
-	Status  int         ` + "`json:\"status\"`" + `
-	Message string      ` + "`json:\"message\"`" + `
-	Data    interface{} ` + "`json:\"data,omitempty\"`" + `
-	Error   string      ` + "`json:\"error,omitempty\"`" + `
-}
-
 type {{.ModuleTitle}}Handler struct {
 	service service.{{.ModuleTitle}}Service
 }
@@ -424,25 +448,16 @@ func New{{.ModuleTitle}}Handler(service service.{{.ModuleTitle}}Service) *{{.Mod
 	return &{{.ModuleTitle}}Handler{service: service}
 }
 
-func formatResponse(status int, message string, data interface{}, err string) Response {
-	return Response{
-		Status:  status,
-		Message: message,
-		Data:    data,
-		Error:   err,
-	}
-}
-
 func (h *{{.ModuleTitle}}Handler) Create(c *gin.Context) {
 	var req dto.Create{{.ModuleTitle}}Request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -452,54 +467,65 @@ func (h *{{.ModuleTitle}}Handler) Create(c *gin.Context) {
 		UserID:      userID.(uint),
 	}
 
-	if err := h.service.Create({{.ModuleLower}}); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to create {{.ModuleLower}}", nil, err.Error()))
+	if err := h.service.Create(c.Request.Context(), {{.ModuleLower}}); err != nil {
+		c.Error(err)
 		return
 	}
 
-	response := dto.{{.ModuleTitle}}Response{
+	resp := dto.{{.ModuleTitle}}Response{
 		ID:          {{.ModuleLower}}.ID,
 		Name:        {{.ModuleLower}}.Name,
 		Description: {{.ModuleLower}}.Description,
 		UserID:      {{.ModuleLower}}.UserID,
 	}
 
-	c.JSON(http.StatusCreated, formatResponse(http.StatusCreated, "{{.ModuleTitle}} created successfully", response, ""))
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "{{.ModuleTitle}} created successfully", resp))
 }
 
 func (h *{{.ModuleTitle}}Handler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	{{.ModuleLower}}, err := h.service.GetByID(uint(id))
+	{{.ModuleLower}}, err := h.service.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "{{.ModuleTitle}} not found", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	response := dto.{{.ModuleTitle}}Response{
+	resp := dto.{{.ModuleTitle}}Response{
 		ID:          {{.ModuleLower}}.ID,
 		Name:        {{.ModuleLower}}.Name,
 		Description: {{.ModuleLower}}.Description,
 		UserID:      {{.ModuleLower}}.UserID,
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "{{.ModuleTitle}} retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "{{.ModuleTitle}} retrieved successfully", resp))
 }
 
 func (h *{{.ModuleTitle}}Handler) GetAll(c *gin.Context) {
-	{{.ModulePlural}}, err := h.service.GetAll()
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	{{.ModulePlural}}, meta, err := h.service.GetAll(c.Request.Context(), f, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve {{.ModulePlural}}", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	response := make([]dto.{{.ModuleTitle}}Response, len({{.ModulePlural}}))
+	resp := make([]dto.{{.ModuleTitle}}Response, len({{.ModulePlural}}))
 	for i, {{.ModuleLower}} := range {{.ModulePlural}} {
-		response[i] = dto.{{.ModuleTitle}}Response{
+		resp[i] = dto.{{.ModuleTitle}}Response{
 			ID:          {{.ModuleLower}}.ID,
 			Name:        {{.ModuleLower}}.Name,
 			Description: {{.ModuleLower}}.Description,
@@ -507,25 +533,25 @@ func (h *{{.ModuleTitle}}Handler) GetAll(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "{{.ModulePlural}} retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "{{.ModulePlural}} retrieved successfully", resp, meta))
 }
 
 func (h *{{.ModuleTitle}}Handler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var req dto.Update{{.ModuleTitle}}Request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -535,58 +561,64 @@ func (h *{{.ModuleTitle}}Handler) Update(c *gin.Context) {
 		Description: req.Description,
 	}
 
-	if err := h.service.Update({{.ModuleLower}}, userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to update {{.ModuleLower}}", nil, err.Error()))
+	if err := h.service.Update(c.Request.Context(), {{.ModuleLower}}, userID.(uint)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	response := dto.{{.ModuleTitle}}Response{
+	resp := dto.{{.ModuleTitle}}Response{
 		ID:          {{.ModuleLower}}.ID,
 		Name:        {{.ModuleLower}}.Name,
 		Description: {{.ModuleLower}}.Description,
 		UserID:      userID.(uint),
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "{{.ModuleTitle}} updated successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "{{.ModuleTitle}} updated successfully", resp))
 }
 
 func (h *{{.ModuleTitle}}Handler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	if err := h.service.Delete(uint(id), userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to delete {{.ModuleLower}}", nil, err.Error()))
+	if err := h.service.Delete(c.Request.Context(), uint(id), userID.(uint)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "{{.ModuleTitle}} deleted successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "{{.ModuleTitle}} deleted successfully", nil))
 }
 
 func (h *{{.ModuleTitle}}Handler) GetByUserID(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	{{.ModulePlural}}, err := h.service.GetByUserID(userID.(uint))
+	{{.ModulePlural}}, meta, err := h.service.GetByUserID(c.Request.Context(), userID.(uint), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve user's {{.ModulePlural}}", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	response := make([]dto.{{.ModuleTitle}}Response, len({{.ModulePlural}}))
+	resp := make([]dto.{{.ModuleTitle}}Response, len({{.ModulePlural}}))
 	for i, {{.ModuleLower}} := range {{.ModulePlural}} {
-		response[i] = dto.{{.ModuleTitle}}Response{
+		resp[i] = dto.{{.ModuleTitle}}Response{
 			ID:          {{.ModuleLower}}.ID,
 			Name:        {{.ModuleLower}}.Name,
 			Description: {{.ModuleLower}}.Description,
@@ -594,11 +626,31 @@ func (h *{{.ModuleTitle}}Handler) GetByUserID(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User's {{.ModulePlural}} retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "User's {{.ModulePlural}} retrieved successfully", resp, meta))
 }`
 
 var dtoTemplate = `package dto
 
+import (
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+)
+
+// ListOptions are the sorts of {{.ModuleLower}} lists, newest first by default.
+var ListOptions = pagination.Options{
+	Sortable:    []string{"id", "name", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// FilterFields are the fields that {{.ModuleLower}} lists can be filtered by.
+var FilterFields = filter.Fields{
+	"id":         {Column: "id", Type: filter.Integer},
+	"name":       {Column: "name", Type: filter.String},
+	"user_id":    {Column: "user_id", Type: filter.Integer},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+}
+
 type Create{{.ModuleTitle}}Request struct {
 	Name        string ` + "`json:\"name\" binding:\"required\"`" + `
 	Description string ` + "`json:\"description\"`" + `
@@ -705,7 +757,7 @@ func TestCreate{{.ModuleTitle}}(t *testing.T) {
 				Description: "Test Description",
 			},
 			setupMock: func() {
-				mockService.On("Create", mock.AnythingOfType("*entity.{{.ModuleTitle}}")).
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("*entity.{{.ModuleTitle}}")).
 					Return(nil)
 			},
 			expectedCode: http.StatusCreated,
@@ -733,6 +785,7 @@ func TestCreate{{.ModuleTitle}}(t *testing.T) {
 var serviceTestTemplate = `package tests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -760,7 +813,7 @@ func TestCreate{{.ModuleTitle}}Service(t *testing.T) {
 				Description: "Test Description",
 			},
 			setupMock: func() {
-				mockRepo.On("Create", mock.AnythingOfType("*entity.{{.ModuleTitle}}")).
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.{{.ModuleTitle}}")).
 					Return(nil)
 			},
 			expectedError: nil,
@@ -772,7 +825,7 @@ func TestCreate{{.ModuleTitle}}Service(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			err := svc.Create(tt.input)
+			err := svc.Create(context.Background(), tt.input)
 			assert.Equal(t, tt.expectedError, err)
 			mockRepo.AssertExpectations(t)
 		})
@@ -782,89 +835,97 @@ func TestCreate{{.ModuleTitle}}Service(t *testing.T) {
 var repositoryMockTemplate = `package mocks
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 	"go-backend/internal/modules/{{.ModuleLower}}/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type Mock{{.ModuleTitle}}Repository struct {
 	mock.Mock
 }
 
-func (m *Mock{{.ModuleTitle}}Repository) Create({{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
-	args := m.Called({{.ModuleLower}})
+func (m *Mock{{.ModuleTitle}}Repository) Create(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
+	args := m.Called(ctx, {{.ModuleLower}})
 	return args.Error(0)
 }
 
-func (m *Mock{{.ModuleTitle}}Repository) GetByID(id uint) (*entity.{{.ModuleTitle}}, error) {
-	args := m.Called(id)
+func (m *Mock{{.ModuleTitle}}Repository) GetByID(ctx context.Context, id uint) (*entity.{{.ModuleTitle}}, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.{{.ModuleTitle}}), args.Error(1)
 }
 
-func (m *Mock{{.ModuleTitle}}Repository) GetAll() ([]entity.{{.ModuleTitle}}, error) {
-	args := m.Called()
-	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Error(1)
+func (m *Mock{{.ModuleTitle}}Repository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	args := m.Called(ctx, f, params)
+	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Get(1).(pagination.Meta), args.Error(2)
 }
 
-func (m *Mock{{.ModuleTitle}}Repository) Update({{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
-	args := m.Called({{.ModuleLower}})
+func (m *Mock{{.ModuleTitle}}Repository) Update(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
+	args := m.Called(ctx, {{.ModuleLower}})
 	return args.Error(0)
 }
 
-func (m *Mock{{.ModuleTitle}}Repository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *Mock{{.ModuleTitle}}Repository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *Mock{{.ModuleTitle}}Repository) GetByUserID(userID uint) ([]entity.{{.ModuleTitle}}, error) {
-	args := m.Called(userID)
-	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Error(1)
+func (m *Mock{{.ModuleTitle}}Repository) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	args := m.Called(ctx, userID, params)
+	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Get(1).(pagination.Meta), args.Error(2)
 }`
 
 var serviceMockTemplate = `package mocks
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 	"go-backend/internal/modules/{{.ModuleLower}}/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type Mock{{.ModuleTitle}}Service struct {
 	mock.Mock
 }
 
-func (m *Mock{{.ModuleTitle}}Service) Create({{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
-	args := m.Called({{.ModuleLower}})
+func (m *Mock{{.ModuleTitle}}Service) Create(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}) error {
+	args := m.Called(ctx, {{.ModuleLower}})
 	return args.Error(0)
 }
 
-func (m *Mock{{.ModuleTitle}}Service) GetByID(id uint) (*entity.{{.ModuleTitle}}, error) {
-	args := m.Called(id)
+func (m *Mock{{.ModuleTitle}}Service) GetByID(ctx context.Context, id uint) (*entity.{{.ModuleTitle}}, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.{{.ModuleTitle}}), args.Error(1)
 }
 
-func (m *Mock{{.ModuleTitle}}Service) GetAll() ([]entity.{{.ModuleTitle}}, error) {
-	args := m.Called()
-	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Error(1)
+func (m *Mock{{.ModuleTitle}}Service) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	args := m.Called(ctx, f, params)
+	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Get(1).(pagination.Meta), args.Error(2)
 }
 
-func (m *Mock{{.ModuleTitle}}Service) Update({{.ModuleLower}} *entity.{{.ModuleTitle}}, userID uint) error {
-	args := m.Called({{.ModuleLower}}, userID)
+func (m *Mock{{.ModuleTitle}}Service) Update(ctx context.Context, {{.ModuleLower}} *entity.{{.ModuleTitle}}, userID uint) error {
+	args := m.Called(ctx, {{.ModuleLower}}, userID)
 	return args.Error(0)
 }
 
-func (m *Mock{{.ModuleTitle}}Service) Delete(id, userID uint) error {
-	args := m.Called(id, userID)
+func (m *Mock{{.ModuleTitle}}Service) Delete(ctx context.Context, id, userID uint) error {
+	args := m.Called(ctx, id, userID)
 	return args.Error(0)
 }
 
-func (m *Mock{{.ModuleTitle}}Service) GetByUserID(userID uint) ([]entity.{{.ModuleTitle}}, error) {
-	args := m.Called(userID)
-	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Error(1)
+func (m *Mock{{.ModuleTitle}}Service) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.{{.ModuleTitle}}, pagination.Meta, error) {
+	args := m.Called(ctx, userID, params)
+	return args.Get(0).([]entity.{{.ModuleTitle}}), args.Get(1).(pagination.Meta), args.Error(2)
 }`
 
 var middlewareTemplate = `package middleware
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go-backend/internal/infrastructure/config"
+	"go-backend/internal/infrastructure/jwtkeys"
+)
+
+const usage = `Manage the JWT signing keys in JWT_KEYS_DIR.
+
+Usage:
+  keys [-dir DIR] generate [-alg RS256|EdDSA]   add a key, activating it if there is no active key
+  keys [-dir DIR] rotate [-alg RS256|EdDSA]     add a key and make it the signing key
+  keys [-dir DIR] activate KID                  make an existing key the signing key
+  keys [-dir DIR] retire KID                    delete a key that is no longer in use
+  keys [-dir DIR] list                          list the keys
+
+Newly generated keys are only trusted by instances that have loaded them, so
+with several instances deploy the output of "generate" everywhere before
+running "activate".
+`
+
+func main() {
+	dir := flag.String("dir", "", "key directory (default JWT_KEYS_DIR, or \"keys\")")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if *dir == "" {
+		*dir = cfg.JWT.KeysDir
+	}
+	if *dir == "" {
+		*dir = "keys"
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	switch cmd {
+	case "generate":
+		err = generate(*dir, args, false)
+	case "rotate":
+		err = generate(*dir, args, true)
+	case "activate":
+		err = withKeyID(args, func(id string) error {
+			if err := jwtkeys.Activate(*dir, id); err != nil {
+				return err
+			}
+			fmt.Printf("Activated key %s\n", id)
+			return nil
+		})
+	case "retire":
+		err = withKeyID(args, func(id string) error {
+			if err := jwtkeys.Retire(*dir, id); err != nil {
+				return err
+			}
+			fmt.Printf("Retired key %s\n", id)
+			return nil
+		})
+	case "list":
+		err = list(*dir)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("%s: %v", cmd, err)
+	}
+}
+
+func generate(dir string, args []string, activate bool) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	alg := fs.String("alg", jwtkeys.AlgEdDSA, "signing algorithm (RS256 or EdDSA)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	id, err := jwtkeys.GenerateKey(dir, *alg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Generated %s key %s in %s\n", *alg, id, dir)
+
+	// The first key in a directory is always activated
+	if !activate {
+		_, err := jwtkeys.ActiveKeyID(dir)
+		if err == nil {
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := jwtkeys.Activate(dir, id); err != nil {
+		return err
+	}
+	fmt.Printf("Activated key %s\n", id)
+	return nil
+}
+
+func withKeyID(args []string, fn func(id string) error) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a key ID")
+	}
+	return fn(args[0])
+}
+
+func list(dir string) error {
+	keys, err := jwtkeys.ListDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		fmt.Printf("No keys in %s\n", dir)
+		return nil
+	}
+
+	for _, key := range keys {
+		active := ""
+		if key.Active {
+			active = "active"
+		}
+		fmt.Printf("%-18s %-6s %s %s\n", key.ID, key.Algorithm, key.CreatedAt.Format("2006-01-02"), active)
+	}
+	return nil
+}
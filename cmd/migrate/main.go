@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"go-backend/internal/infrastructure/config"
+	"go-backend/internal/infrastructure/database"
+	"go-backend/internal/infrastructure/migrate"
+)
+
+const usage = `Manage the database schema.
+
+Usage:
+  migrate up                       apply every pending migration
+  migrate down [N]                 roll back the last N migrations (default 1)
+  migrate status                   list migrations and when they were applied
+  migrate create -module M NAME    add empty up and down files to internal/modules/M/migrations
+
+Migrations are compiled into the binary, so rebuild after adding one.
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	if cmd == "create" {
+		if err := create(args); err != nil {
+			log.Fatalf("create: %v", err)
+		}
+		return
+	}
+
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch cmd {
+	case "up":
+		err = up(ctx, migrator)
+	case "down":
+		err = down(ctx, migrator, args)
+	case "status":
+		err = status(ctx, migrator)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("%s: %v", cmd, err)
+	}
+}
+
+func up(ctx context.Context, migrator *migrate.Migrator) error {
+	applied, err := migrator.Up(ctx)
+	for _, m := range applied {
+		fmt.Printf("Applied %s\n", m)
+	}
+	if err == nil && len(applied) == 0 {
+		fmt.Println("No pending migrations")
+	}
+	return err
+}
+
+func down(ctx context.Context, migrator *migrate.Migrator, args []string) error {
+	n := 1
+	if len(args) > 0 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+			return fmt.Errorf("invalid number of migrations %q", args[0])
+		}
+	}
+
+	rolledBack, err := migrator.Down(ctx, n)
+	for _, m := range rolledBack {
+		fmt.Printf("Rolled back %s\n", m)
+	}
+	return err
+}
+
+func status(ctx context.Context, migrator *migrate.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Printf("%d  %-12s %-40s %s\n", s.Version, s.Module, s.Name, appliedAt)
+	}
+	return nil
+}
+
+func create(args []string) error {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	module := fs.String("module", "", "module that owns the migration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *module == "" || fs.NArg() != 1 {
+		return fmt.Errorf("usage: migrate create -module M NAME")
+	}
+
+	moduleDir := filepath.Join("internal", "modules", *module)
+	if _, err := os.Stat(moduleDir); err != nil {
+		return fmt.Errorf("module %q not found: %w", *module, err)
+	}
+
+	up, down, err := migrate.Create(filepath.Join(moduleDir, "migrations"), fs.Arg(0), time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created %s\nCreated %s\n", up, down)
+	return nil
+}
@@ -0,0 +1,51 @@
+// Package buildinfo reports the version of the running binary.
+//
+// Version, Commit and BuildDate are set at build time with
+//
+//	go build -ldflags "-X go-backend/internal/infrastructure/buildinfo.Version=v1.2.3 \
+//	  -X go-backend/internal/infrastructure/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X go-backend/internal/infrastructure/buildinfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// as the Makefile and Dockerfile do. Without them, the commit and date
+// recorded by the Go toolchain are used when available.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"build_date,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	return info
+}
@@ -0,0 +1,389 @@
+// Package config holds the application configuration.
+//
+// Settings are read from, in increasing order of precedence: the defaults
+// below, an optional YAML or TOML file, environment variables (including a
+// .env file) and command line flags. Every setting has an environment
+// variable, given by the env tags along its path, and a flag named after it:
+// DB_HOST can also be set with -db-host.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
+// minProductionSecretLength is the shortest JWT_SECRET accepted in production.
+const minProductionSecretLength = 32
+
+type Config struct {
+	Env      string         `yaml:"env" toml:"env" env:"APP_ENV"`
+	Log      LogConfig      `yaml:"log" toml:"log"`
+	HTTP     HTTPConfig     `yaml:"http" toml:"http"`
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	JWT      JWTConfig      `yaml:"jwt" toml:"jwt"`
+	Mail     MailConfig     `yaml:"mail" toml:"mail"`
+	Auth     AuthConfig     `yaml:"auth" toml:"auth"`
+	OAuth    OAuthConfig    `yaml:"oauth" toml:"oauth"`
+	Health   HealthConfig   `yaml:"health" toml:"health"`
+	Metrics  MetricsConfig  `yaml:"metrics" toml:"metrics"`
+	Tracing  TracingConfig  `yaml:"tracing" toml:"tracing"`
+
+	Publishing PublishingConfig `yaml:"publishing" toml:"publishing"`
+	SEO        SEOConfig        `yaml:"seo" toml:"seo"`
+}
+
+type LogConfig struct {
+	// Format is json or text.
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+	// Level is debug, info, warn or error.
+	Level string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+}
+
+type HTTPConfig struct {
+	Port              int      `yaml:"port" toml:"port" env:"PORT"`
+	ReadTimeout       Duration `yaml:"read_timeout" toml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	ReadHeaderTimeout Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	WriteTimeout      Duration `yaml:"write_timeout" toml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	MaxHeaderBytes    int      `yaml:"max_header_bytes" toml:"max_header_bytes" env:"HTTP_MAX_HEADER_BYTES"`
+	// DrainDelay is how long the health check reports "draining" before the
+	// server stops accepting connections, so load balancers stop routing to it.
+	DrainDelay Duration `yaml:"drain_delay" toml:"drain_delay" env:"HTTP_DRAIN_DELAY"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish.
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" toml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" toml:"user" env:"DB_USER"`
+	Password string `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" toml:"name" env:"DB_NAME"`
+	SSLMode  string `yaml:"sslmode" toml:"sslmode" env:"DB_SSLMODE"`
+	// SlowQueryThreshold logs queries that take longer as warnings. 0
+	// disables the warning.
+	SlowQueryThreshold Duration `yaml:"slow_query_threshold" toml:"slow_query_threshold" env:"DB_SLOW_QUERY_THRESHOLD"`
+}
+
+// DSN returns the Postgres connection string.
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.Name, c.SSLMode)
+}
+
+type JWTConfig struct {
+	// Secret signs HS256 tokens. It is ignored for signing when KeysDir is
+	// set, but still verifies tokens issued before the switch.
+	Secret string `yaml:"secret" toml:"secret" env:"JWT_SECRET" secret:"true"`
+	// KeysDir holds the RS256/EdDSA keys managed with cmd/keys.
+	KeysDir string `yaml:"keys_dir" toml:"keys_dir" env:"JWT_KEYS_DIR"`
+}
+
+type MailConfig struct {
+	// Driver is "log", "file" or "smtp".
+	Driver string     `yaml:"driver" toml:"driver" env:"MAIL_DRIVER"`
+	From   string     `yaml:"from" toml:"from" env:"MAIL_FROM"`
+	Dir    string     `yaml:"dir" toml:"dir" env:"MAIL_DIR"`
+	SMTP   SMTPConfig `yaml:"smtp" toml:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host" toml:"host" env:"SMTP_HOST"`
+	Port     int    `yaml:"port" toml:"port" env:"SMTP_PORT"`
+	Username string `yaml:"username" toml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" toml:"password" env:"SMTP_PASSWORD" secret:"true"`
+}
+
+type AuthConfig struct {
+	RegistrationEnabled  bool   `yaml:"registration_enabled" toml:"registration_enabled" env:"REGISTRATION_ENABLED"`
+	EmailVerificationURL string `yaml:"email_verification_url" toml:"email_verification_url" env:"EMAIL_VERIFICATION_URL"`
+	PasswordResetURL     string `yaml:"password_reset_url" toml:"password_reset_url" env:"PASSWORD_RESET_URL"`
+	MFAIssuer            string `yaml:"mfa_issuer" toml:"mfa_issuer" env:"MFA_ISSUER"`
+	MFARequiredForAdmins bool   `yaml:"mfa_required_for_admins" toml:"mfa_required_for_admins" env:"MFA_REQUIRED_FOR_ADMINS"`
+}
+
+type OAuthConfig struct {
+	// CallbackURL is the base of the redirect URLs, which end in
+	// "/<provider>/callback".
+	CallbackURL string            `yaml:"callback_url" toml:"callback_url" env:"OAUTH_CALLBACK_URL"`
+	GitHub      OAuthClientConfig `yaml:"github" toml:"github" env:"GITHUB_"`
+	Google      OAuthClientConfig `yaml:"google" toml:"google" env:"GOOGLE_"`
+	OIDC        OIDCConfig        `yaml:"oidc" toml:"oidc" env:"OIDC_"`
+}
+
+type OAuthClientConfig struct {
+	ClientID     string `yaml:"client_id" toml:"client_id" env:"CLIENT_ID"`
+	ClientSecret string `yaml:"client_secret" toml:"client_secret" env:"CLIENT_SECRET" secret:"true"`
+}
+
+type OIDCConfig struct {
+	// ProviderName is the name used in the login URL.
+	ProviderName string `yaml:"provider_name" toml:"provider_name" env:"PROVIDER_NAME"`
+	IssuerURL    string `yaml:"issuer_url" toml:"issuer_url" env:"ISSUER_URL"`
+	ClientID     string `yaml:"client_id" toml:"client_id" env:"CLIENT_ID"`
+	ClientSecret string `yaml:"client_secret" toml:"client_secret" env:"CLIENT_SECRET" secret:"true"`
+}
+
+type HealthConfig struct {
+	// CheckTimeout bounds each readiness check.
+	CheckTimeout Duration `yaml:"check_timeout" toml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+	// DiskPath is the directory whose free space is checked, typically the
+	// uploads directory. The check is disabled when it is empty.
+	DiskPath      string `yaml:"disk_path" toml:"disk_path" env:"HEALTH_DISK_PATH"`
+	DiskMinFreeMB int    `yaml:"disk_min_free_mb" toml:"disk_min_free_mb" env:"HEALTH_DISK_MIN_FREE_MB"`
+}
+
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"METRICS_ENABLED"`
+	Path    string `yaml:"path" toml:"path" env:"METRICS_PATH"`
+	// AdminPort serves the metrics on a separate port, which can be kept
+	// private. When 0 they are served on the main port.
+	AdminPort int `yaml:"admin_port" toml:"admin_port" env:"METRICS_ADMIN_PORT"`
+}
+
+type TracingConfig struct {
+	// Exporter is "none", "stdout" or "otlp". Incoming trace context is
+	// propagated even when no spans are exported.
+	Exporter string `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	// OTLPEndpoint is the URL of the OTLP/HTTP collector, such as
+	// http://localhost:4318. When empty the standard OTEL_EXPORTER_OTLP_*
+	// variables apply.
+	OTLPEndpoint string `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	ServiceName  string `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME"`
+	// SampleRatio is the fraction of new traces that are recorded, between 0
+	// and 1. Requests that arrive with a sampled parent are always recorded.
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+type PublishingConfig struct {
+	// Interval is how often scheduled posts and projects that are due are
+	// published. The scheduler is disabled when it is 0.
+	Interval Duration `yaml:"interval" toml:"interval" env:"PUBLISH_INTERVAL"`
+}
+
+type SEOConfig struct {
+	// SiteURL is the URL of the site that shows the public posts, projects
+	// and profiles, such as https://example.com. Canonical URLs are made from
+	// it, and left empty without it.
+	SiteURL string `yaml:"site_url" toml:"site_url" env:"SITE_URL"`
+}
+
+// Default returns the configuration used for settings that are not set
+// anywhere else.
+func Default() Config {
+	return Config{
+		Env: EnvDevelopment,
+		Log: LogConfig{
+			Format: "text",
+			Level:  "info",
+		},
+		HTTP: HTTPConfig{
+			Port:              8080,
+			ReadTimeout:       Duration(15 * time.Second),
+			ReadHeaderTimeout: Duration(5 * time.Second),
+			WriteTimeout:      Duration(30 * time.Second),
+			IdleTimeout:       Duration(60 * time.Second),
+			MaxHeaderBytes:    1 << 20,
+			DrainDelay:        Duration(5 * time.Second),
+			ShutdownTimeout:   Duration(30 * time.Second),
+		},
+		Database: DatabaseConfig{
+			Host:               "localhost",
+			Port:               5432,
+			User:               "postgres",
+			Password:           "postgres",
+			Name:               "go_backend",
+			SSLMode:            "disable",
+			SlowQueryThreshold: Duration(200 * time.Millisecond),
+		},
+		Mail: MailConfig{
+			Driver: "log",
+			From:   "no-reply@localhost",
+			Dir:    "tmp/mail",
+			SMTP:   SMTPConfig{Port: 587},
+		},
+		OAuth: OAuthConfig{
+			OIDC: OIDCConfig{ProviderName: "oidc"},
+		},
+		Health: HealthConfig{
+			CheckTimeout:  Duration(2 * time.Second),
+			DiskMinFreeMB: 512,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "go-backend",
+			SampleRatio: 1,
+		},
+		Publishing: PublishingConfig{
+			Interval: Duration(time.Minute),
+		},
+	}
+}
+
+func (c *Config) IsProduction() bool {
+	return c.Env == EnvProduction
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch c.Env {
+	case EnvDevelopment, EnvTest, EnvProduction:
+	default:
+		add("APP_ENV must be %s, %s or %s, got %q", EnvDevelopment, EnvTest, EnvProduction, c.Env)
+	}
+
+	switch c.Log.Format {
+	case "json", "text":
+	default:
+		add("LOG_FORMAT must be json or text, got %q", c.Log.Format)
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		add("LOG_LEVEL must be debug, info, warn or error, got %q", c.Log.Level)
+	}
+
+	if c.HTTP.Port < 1 || c.HTTP.Port > 65535 {
+		add("PORT must be between 1 and 65535, got %d", c.HTTP.Port)
+	}
+	for _, setting := range []struct {
+		name  string
+		value Duration
+	}{
+		{"HTTP_READ_TIMEOUT", c.HTTP.ReadTimeout},
+		{"HTTP_READ_HEADER_TIMEOUT", c.HTTP.ReadHeaderTimeout},
+		{"HTTP_WRITE_TIMEOUT", c.HTTP.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", c.HTTP.IdleTimeout},
+		{"HTTP_DRAIN_DELAY", c.HTTP.DrainDelay},
+	} {
+		if setting.value < 0 {
+			add("%s must not be negative, got %s", setting.name, setting.value)
+		}
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		add("HTTP_SHUTDOWN_TIMEOUT must be positive, got %s", c.HTTP.ShutdownTimeout)
+	}
+	if c.HTTP.MaxHeaderBytes < 1 {
+		add("HTTP_MAX_HEADER_BYTES must be positive, got %d", c.HTTP.MaxHeaderBytes)
+	}
+
+	if c.Database.Host == "" {
+		add("DB_HOST is required")
+	}
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		add("DB_PORT must be between 1 and 65535, got %d", c.Database.Port)
+	}
+	if c.Database.User == "" {
+		add("DB_USER is required")
+	}
+	if c.Database.Name == "" {
+		add("DB_NAME is required")
+	}
+	if c.Database.SlowQueryThreshold < 0 {
+		add("DB_SLOW_QUERY_THRESHOLD must not be negative, got %s", c.Database.SlowQueryThreshold)
+	}
+
+	switch {
+	case c.JWT.Secret == "" && c.JWT.KeysDir == "":
+		add("JWT_SECRET or JWT_KEYS_DIR is required")
+	case c.IsProduction() && c.JWT.KeysDir == "" && len(c.JWT.Secret) < minProductionSecretLength:
+		add("JWT_SECRET must be at least %d characters in production", minProductionSecretLength)
+	}
+
+	switch c.Mail.Driver {
+	case "log", "file":
+		// Both keep the links of the emails, which carry live tokens
+		if c.IsProduction() {
+			add("MAIL_DRIVER must be smtp in production, got %q", c.Mail.Driver)
+		}
+	case "smtp":
+		if c.Mail.SMTP.Host == "" {
+			add("SMTP_HOST is required when MAIL_DRIVER is smtp")
+		}
+	default:
+		add("MAIL_DRIVER must be log, file or smtp, got %q", c.Mail.Driver)
+	}
+
+	for _, setting := range []struct{ name, value string }{
+		{"EMAIL_VERIFICATION_URL", c.Auth.EmailVerificationURL},
+		{"PASSWORD_RESET_URL", c.Auth.PasswordResetURL},
+		{"OAUTH_CALLBACK_URL", c.OAuth.CallbackURL},
+		{"OIDC_ISSUER_URL", c.OAuth.OIDC.IssuerURL},
+		{"SITE_URL", c.SEO.SiteURL},
+	} {
+		if setting.value == "" {
+			continue
+		}
+		if u, err := url.Parse(setting.value); err != nil || u.Scheme == "" || u.Host == "" {
+			add("%s must be an absolute URL, got %q", setting.name, setting.value)
+		}
+	}
+
+	oauthEnabled := c.OAuth.GitHub.ClientID != "" || c.OAuth.Google.ClientID != "" || c.OAuth.OIDC.IssuerURL != ""
+	if oauthEnabled && c.OAuth.CallbackURL == "" {
+		add("OAUTH_CALLBACK_URL is required when an OAuth provider is configured")
+	}
+	if c.OAuth.OIDC.IssuerURL != "" && c.OAuth.OIDC.ProviderName == "" {
+		add("OIDC_PROVIDER_NAME is required when OIDC_ISSUER_URL is set")
+	}
+
+	if c.Health.CheckTimeout <= 0 {
+		add("HEALTH_CHECK_TIMEOUT must be positive, got %s", c.Health.CheckTimeout)
+	}
+	if c.Health.DiskMinFreeMB < 0 {
+		add("HEALTH_DISK_MIN_FREE_MB must not be negative, got %d", c.Health.DiskMinFreeMB)
+	}
+
+	if c.Metrics.Enabled {
+		if !strings.HasPrefix(c.Metrics.Path, "/") {
+			add("METRICS_PATH must start with /, got %q", c.Metrics.Path)
+		}
+		switch {
+		case c.Metrics.AdminPort < 0 || c.Metrics.AdminPort > 65535:
+			add("METRICS_ADMIN_PORT must be between 0 and 65535, got %d", c.Metrics.AdminPort)
+		case c.Metrics.AdminPort == c.HTTP.Port:
+			add("METRICS_ADMIN_PORT must differ from PORT")
+		}
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Tracing.OTLPEndpoint != "" {
+			if u, err := url.Parse(c.Tracing.OTLPEndpoint); err != nil || u.Scheme == "" || u.Host == "" {
+				add("TRACING_OTLP_ENDPOINT must be an absolute URL, got %q", c.Tracing.OTLPEndpoint)
+			}
+		}
+	default:
+		add("TRACING_EXPORTER must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.ServiceName == "" {
+		add("TRACING_SERVICE_NAME is required")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		add("TRACING_SAMPLE_RATIO must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	}
+	if c.Publishing.Interval < 0 {
+		add("PUBLISH_INTERVAL must not be negative, got %s", c.Publishing.Interval)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func load(t *testing.T, args ...string) (*Config, error) {
+	t.Helper()
+	return Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+}
+
+// clearEnv unsets every configuration variable for the duration of the test.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	for _, s := range settings(&Config{}) {
+		t.Setenv(s.env, "")
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func validConfig() Config {
+	cfg := Default()
+	cfg.JWT.Secret = "0123456789abcdef0123456789abcdef"
+	return cfg
+}
+
+func TestLoad(t *testing.T) {
+	clearEnv(t)
+
+	t.Run("uses defaults", func(t *testing.T) {
+		cfg, err := load(t)
+		require.NoError(t, err)
+		assert.Equal(t, Default(), *cfg)
+	})
+
+	t.Run("reads environment variables", func(t *testing.T) {
+		t.Setenv("DB_HOST", "db.internal")
+		t.Setenv("DB_PORT", "6543")
+		t.Setenv("REGISTRATION_ENABLED", "true")
+		t.Setenv("GITHUB_CLIENT_ID", "github-id")
+		t.Setenv("OIDC_CLIENT_SECRET", "oidc-secret")
+		t.Setenv("SMTP_HOST", "")
+		t.Setenv("TRACING_SAMPLE_RATIO", "0.25")
+
+		cfg, err := load(t)
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal", cfg.Database.Host)
+		assert.Equal(t, 6543, cfg.Database.Port)
+		assert.True(t, cfg.Auth.RegistrationEnabled)
+		assert.Equal(t, "github-id", cfg.OAuth.GitHub.ClientID)
+		assert.Equal(t, "oidc-secret", cfg.OAuth.OIDC.ClientSecret)
+		assert.Equal(t, "", cfg.Mail.SMTP.Host)
+		assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+		assert.Equal(t, "postgres", cfg.Database.User, "unset variables keep the default")
+	})
+
+	t.Run("flags override environment variables", func(t *testing.T) {
+		t.Setenv("DB_HOST", "db.internal")
+
+		cfg, err := load(t, "-db-host", "db.flag", "-port", "9000", "-http-read-timeout", "2s")
+		require.NoError(t, err)
+		assert.Equal(t, "db.flag", cfg.Database.Host)
+		assert.Equal(t, 9000, cfg.HTTP.Port)
+		assert.Equal(t, Duration(2*time.Second), cfg.HTTP.ReadTimeout)
+	})
+
+	t.Run("reads a YAML file", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "env: production\nhttp:\n  shutdown_timeout: 1m\ndatabase:\n  host: db.yaml\noauth:\n  github:\n    client_id: github-id\n")
+
+		cfg, err := load(t, "-config", path)
+		require.NoError(t, err)
+		assert.Equal(t, EnvProduction, cfg.Env)
+		assert.Equal(t, "db.yaml", cfg.Database.Host)
+		assert.Equal(t, 5432, cfg.Database.Port, "settings missing from the file keep the default")
+		assert.Equal(t, "github-id", cfg.OAuth.GitHub.ClientID)
+		assert.Equal(t, Duration(time.Minute), cfg.HTTP.ShutdownTimeout)
+	})
+
+	t.Run("reads a TOML file named by CONFIG_FILE", func(t *testing.T) {
+		path := writeFile(t, "config.toml", "[http]\ndrain_delay = \"0s\"\n\n[database]\nhost = \"db.toml\"\n\n[mail.smtp]\nport = 2525\n")
+		t.Setenv("CONFIG_FILE", path)
+		t.Setenv("DB_PORT", "6543")
+
+		cfg, err := load(t)
+		require.NoError(t, err)
+		assert.Equal(t, "db.toml", cfg.Database.Host)
+		assert.Equal(t, 2525, cfg.Mail.SMTP.Port)
+		assert.Equal(t, Duration(0), cfg.HTTP.DrainDelay)
+		assert.Equal(t, 6543, cfg.Database.Port, "environment variables override the file")
+	})
+
+	t.Run("rejects unknown keys in the file", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "database:\n  hots: db.yaml\n")
+
+		_, err := load(t, "-config", path)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects malformed values", func(t *testing.T) {
+		t.Setenv("DB_PORT", "five")
+
+		_, err := load(t)
+		assert.ErrorContains(t, err, "DB_PORT")
+	})
+
+	t.Run("rejects malformed durations", func(t *testing.T) {
+		t.Setenv("HTTP_IDLE_TIMEOUT", "60")
+
+		_, err := load(t)
+		assert.ErrorContains(t, err, "HTTP_IDLE_TIMEOUT")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errors []string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "JWT secret is required",
+			modify: func(cfg *Config) { cfg.JWT.Secret = "" },
+			errors: []string{"JWT_SECRET or JWT_KEYS_DIR is required"},
+		},
+		{
+			name: "a short JWT secret is refused in production",
+			modify: func(cfg *Config) {
+				cfg.Env = EnvProduction
+				cfg.JWT.Secret = "secret"
+			},
+			errors: []string{"JWT_SECRET must be at least 32 characters in production"},
+		},
+		{
+			name: "a key directory replaces the secret",
+			modify: func(cfg *Config) {
+				cfg.Env = EnvProduction
+				cfg.JWT.Secret = ""
+				cfg.JWT.KeysDir = "keys"
+				cfg.Mail.Driver = "smtp"
+				cfg.Mail.SMTP.Host = "smtp.example.com"
+			},
+		},
+		{
+			name: "mail must be sent in production",
+			modify: func(cfg *Config) {
+				cfg.Env = EnvProduction
+				cfg.JWT.Secret = strings.Repeat("s", minProductionSecretLength)
+			},
+			errors: []string{`MAIL_DRIVER must be smtp in production, got "log"`},
+		},
+		{
+			name: "log format and level are checked",
+			modify: func(cfg *Config) {
+				cfg.Log.Format = "xml"
+				cfg.Log.Level = "trace"
+				cfg.Database.SlowQueryThreshold = -1
+			},
+			errors: []string{
+				`LOG_FORMAT must be json or text, got "xml"`,
+				`LOG_LEVEL must be debug, info, warn or error, got "trace"`,
+				"DB_SLOW_QUERY_THRESHOLD must not be negative",
+			},
+		},
+		{
+			name: "metrics admin port must differ from the main port",
+			modify: func(cfg *Config) {
+				cfg.Metrics.AdminPort = cfg.HTTP.Port
+				cfg.Metrics.Path = "metrics"
+			},
+			errors: []string{
+				"METRICS_ADMIN_PORT must differ from PORT",
+				`METRICS_PATH must start with /, got "metrics"`,
+			},
+		},
+		{
+			name: "metrics settings are ignored when disabled",
+			modify: func(cfg *Config) {
+				cfg.Metrics.Enabled = false
+				cfg.Metrics.AdminPort = cfg.HTTP.Port
+			},
+		},
+		{
+			name: "tracing settings are checked",
+			modify: func(cfg *Config) {
+				cfg.Tracing.Exporter = "otlp"
+				cfg.Tracing.OTLPEndpoint = "localhost:4318"
+				cfg.Tracing.SampleRatio = 1.5
+			},
+			errors: []string{
+				`TRACING_OTLP_ENDPOINT must be an absolute URL, got "localhost:4318"`,
+				"TRACING_SAMPLE_RATIO must be between 0 and 1, got 1.5",
+			},
+		},
+		{
+			name: "publish interval must not be negative",
+			modify: func(cfg *Config) {
+				cfg.Publishing.Interval = Duration(-time.Minute)
+			},
+			errors: []string{"PUBLISH_INTERVAL must not be negative, got -1m0s"},
+		},
+		{
+			name: "site URL must be absolute",
+			modify: func(cfg *Config) {
+				cfg.SEO.SiteURL = "example.com"
+			},
+			errors: []string{`SITE_URL must be an absolute URL, got "example.com"`},
+		},
+		{
+			name: "reports every error",
+			modify: func(cfg *Config) {
+				cfg.Env = "staging"
+				cfg.HTTP.Port = 0
+				cfg.HTTP.ShutdownTimeout = 0
+				cfg.Mail.Driver = "smtp"
+				cfg.OAuth.GitHub.ClientID = "github-id"
+				cfg.Auth.PasswordResetURL = "/reset"
+			},
+			errors: []string{
+				`APP_ENV must be development, test or production, got "staging"`,
+				"PORT must be between 1 and 65535, got 0",
+				"HTTP_SHUTDOWN_TIMEOUT must be positive, got 0s",
+				"SMTP_HOST is required when MAIL_DRIVER is smtp",
+				`PASSWORD_RESET_URL must be an absolute URL, got "/reset"`,
+				"OAUTH_CALLBACK_URL is required when an OAuth provider is configured",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+			if len(tt.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, msg := range tt.errors {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	cfg := validConfig()
+	cfg.OAuth.GitHub.ClientID = "github-id"
+	cfg.OAuth.GitHub.ClientSecret = "github-secret"
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.Print(&out))
+
+	assert.Contains(t, out.String(), "client_id: github-id")
+	assert.Contains(t, out.String(), "client_secret: REDACTED")
+	assert.NotContains(t, out.String(), "github-secret")
+	assert.NotContains(t, out.String(), cfg.JWT.Secret)
+	assert.Contains(t, out.String(), "client_secret: \"\"", "empty secrets are shown as empty")
+	assert.Equal(t, "github-secret", cfg.OAuth.GitHub.ClientSecret, "the original is not modified")
+}
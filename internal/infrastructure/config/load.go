@@ -0,0 +1,170 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads the configuration. It registers a flag for every setting, and
+// -config for the configuration file, on fs and parses args with it. The
+// file may also be given with CONFIG_FILE. A missing .env file is not an
+// error.
+//
+// Load does not validate the result, call Validate for that.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+
+	configFile := fs.String("config", "", "YAML or TOML configuration file (or CONFIG_FILE)")
+	flags := make(map[string]string)
+	for _, s := range settings(&cfg) {
+		name := flagName(s.env)
+		fs.Func(name, "overrides "+s.env, func(value string) error {
+			flags[name] = value
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("loading .env: %w", err)
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(&cfg, path); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings(&cfg) {
+		value, ok := flags[flagName(s.env)]
+		if !ok {
+			// Empty variables, as produced by "${VAR:-}" in docker-compose,
+			// count as unset.
+			value = os.Getenv(s.env)
+			ok = value != ""
+		}
+		if !ok {
+			continue
+		}
+		if err := s.set(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return &cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	default:
+		return fmt.Errorf("%s: configuration files must end in .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// setting is a leaf field of Config and the environment variable that sets it.
+type setting struct {
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+func (s setting) set(raw string) error {
+	if u, ok := s.value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(raw)); err != nil {
+			return fmt.Errorf("%s: invalid value %q: %w", s.env, raw, err)
+		}
+		return nil
+	}
+
+	switch s.value.Kind() {
+	case reflect.String:
+		s.value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false, got %q", s.env, raw)
+		}
+		s.value.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be a number, got %q", s.env, raw)
+		}
+		s.value.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a number, got %q", s.env, raw)
+		}
+		s.value.SetFloat(f)
+	default:
+		return fmt.Errorf("%s: unsupported type %s", s.env, s.value.Type())
+	}
+	return nil
+}
+
+// settings lists the leaf fields of cfg. The environment variable of a field
+// is the concatenation of the env tags on its path, so that OAuth.GitHub
+// (env "GITHUB_") and its ClientID (env "CLIENT_ID") give GITHUB_CLIENT_ID.
+func settings(cfg *Config) []setting {
+	var out []setting
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			env := prefix + field.Tag.Get("env")
+			if field.Type.Kind() == reflect.Struct {
+				walk(v.Field(i), env)
+				continue
+			}
+			out = append(out, setting{
+				env:    env,
+				secret: field.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return out
+}
+
+// flagName returns the flag for an environment variable: DB_HOST is -db-host.
+func flagName(env string) string {
+	return strings.ReplaceAll(strings.ToLower(env), "_", "-")
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "REDACTED"
+
+// Redacted returns a copy of c with secrets replaced, safe to log.
+func (c *Config) Redacted() Config {
+	out := *c
+	for _, s := range settings(&out) {
+		if s.secret && s.value.Kind() == reflect.String && s.value.String() != "" {
+			s.value.SetString(redacted)
+		}
+	}
+	return out
+}
+
+// Print writes the redacted configuration to w as YAML.
+func (c *Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -1,39 +1,22 @@
 package database
 
 import (
-	"fmt"
-	"os"
+	"time"
+
+	"go-backend/internal/infrastructure/config"
+	"go-backend/internal/infrastructure/logging"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func Connect() (*gorm.DB, error) {
-	host := os.Getenv("DB_HOST")
-	if host == "" {
-		host = "localhost"
-	}
-	port := os.Getenv("DB_PORT")
-	if port == "" {
-		port = "5432"
-	}
-	user := os.Getenv("DB_USER")
-	if user == "" {
-		user = "postgres"
-	}
-	password := os.Getenv("DB_PASSWORD")
-	if password == "" {
-		password = "postgres"
-	}
-	dbname := os.Getenv("DB_NAME")
-	if dbname == "" {
-		dbname = "go_backend"
-	}
-
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func Connect(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{
+		Logger: logging.NewGormLogger(time.Duration(cfg.SlowQueryThreshold)),
+		// Report unique violations as gorm.ErrDuplicatedKey, which
+		// apperror.From turns into conflicts.
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1,26 +1,39 @@
 package database
 
 import (
-	experienceEntity "go-backend/internal/modules/experience/domain/entity"
-	imageEntity "go-backend/internal/modules/images/domain/entity"
-	postEntity "go-backend/internal/modules/post/domain/entity"
-	profileEntity "go-backend/internal/modules/profile/domain/entity"
-	projectEntity "go-backend/internal/modules/project/domain/entity"
-	socialMediaEntity "go-backend/internal/modules/socialmedia/domain/entity"
-	toolEntity "go-backend/internal/modules/tool/domain/entity"
-	userEntity "go-backend/internal/modules/user/domain/entity"
+	"context"
+	"log/slog"
+
+	"go-backend/internal/infrastructure/migrate"
+	"go-backend/internal/modules"
 	"gorm.io/gorm"
 )
 
+// NewMigrator returns a migrator for the migrations of every module.
+func NewMigrator(db *gorm.DB) (*migrate.Migrator, error) {
+	migrations, err := migrate.Load(modules.Migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.New(sqlDB, migrations), nil
+}
+
+// Migrate applies every pending migration.
 func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&userEntity.User{},
-		&postEntity.Post{},
-		&imageEntity.Images{},
-		&projectEntity.Project{},
-		&toolEntity.Tool{},
-		&profileEntity.Profile{},
-		&socialMediaEntity.SocialMedia{},
-		&experienceEntity.Experience{},
-	)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(context.Background())
+	for _, m := range applied {
+		slog.Info("Applied migration", "migration", m.String())
+	}
+	return err
 }
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The users table as AutoMigrate created it before the migrations existed.
+const baselineUsersTable = `CREATE TABLE users (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    email text NOT NULL,
+    password text NOT NULL,
+    token text UNIQUE,
+    expires_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+)`
+
+func TestMigrate_UpgradesBaselineSchema(t *testing.T) {
+	db := openTestDB(t, "_upgrade_test")
+	defer CleanupTestDB(t, db)
+
+	require.NoError(t, db.Exec("DROP SCHEMA public CASCADE").Error)
+	require.NoError(t, db.Exec("CREATE SCHEMA public").Error)
+	require.NoError(t, db.Exec(baselineUsersTable).Error)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.Exec("INSERT INTO users (name, email, password, created_at) VALUES (?, ?, ?, ?)",
+		"Existing User", "existing@example.com", "hash", createdAt).Error)
+
+	require.NoError(t, Migrate(db))
+
+	var user struct {
+		Role            string
+		EmailVerifiedAt *time.Time
+		MFALastStep     int64 `gorm:"column:mfa_last_step"`
+		FailedLogins    int
+		LockedUntil     *time.Time
+	}
+	require.NoError(t, db.Raw("SELECT role, email_verified_at, mfa_secret, mfa_enabled_at, mfa_last_step, failed_logins, locked_until FROM users").Scan(&user).Error)
+
+	assert.Equal(t, "viewer", user.Role)
+	require.NotNil(t, user.EmailVerifiedAt, "existing users stay verified")
+	assert.True(t, createdAt.Equal(*user.EmailVerifiedAt))
+	assert.Zero(t, user.MFALastStep)
+	assert.Zero(t, user.FailedLogins)
+	assert.Nil(t, user.LockedUntil)
+
+	// Users registered after the upgrade are not verified until they confirm
+	require.NoError(t, db.Exec("INSERT INTO users (name, email, password) VALUES (?, ?, ?)", "New User", "new@example.com", "hash").Error)
+	var verified *time.Time
+	require.NoError(t, db.Raw("SELECT email_verified_at FROM users WHERE email = ?", "new@example.com").Scan(&verified).Error)
+	assert.Nil(t, verified)
+}
+
+func TestMigrate_NumbersDuplicateSlugs(t *testing.T) {
+	db := openTestDB(t, "_slug_test")
+	defer CleanupTestDB(t, db)
+
+	require.NoError(t, db.Exec("DROP SCHEMA public CASCADE").Error)
+	require.NoError(t, db.Exec("CREATE SCHEMA public").Error)
+	require.NoError(t, Migrate(db))
+
+	// Roll back to the posts as they were before slugs
+	ctx := context.Background()
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	var since int
+	for _, s := range status {
+		if s.Version >= 20261018090700 && s.AppliedAt != nil {
+			since++
+		}
+	}
+	_, err = migrator.Down(ctx, since)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Exec("INSERT INTO users (id, name, email, password) VALUES (1, 'Author', 'author@example.com', 'hash')").Error)
+	long := strings.Repeat("a", 80)
+	for id, title := range map[int]string{1: "Foo", 2: "Foo 2", 3: "Foo", 4: long, 5: long, 6: "!!!", 7: "Post 6"} {
+		require.NoError(t, db.Exec("INSERT INTO posts (id, title, content, user_id) VALUES (?, ?, '', 1)", id, title).Error)
+	}
+
+	require.NoError(t, Migrate(db))
+
+	var posts []struct {
+		ID   uint
+		Slug string
+	}
+	require.NoError(t, db.Raw("SELECT id, slug FROM posts ORDER BY id").Scan(&posts).Error)
+	slugs := make(map[uint]string, len(posts))
+	for _, post := range posts {
+		slugs[post.ID] = post.Slug
+	}
+	assert.Equal(t, map[uint]string{
+		1: "foo",
+		2: "foo-2",
+		3: "foo-3",
+		4: long,
+		5: strings.Repeat("a", 78) + "-2",
+		6: "post-6",
+		7: "post-6-2",
+	}, slugs)
+}
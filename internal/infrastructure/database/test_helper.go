@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/joho/godotenv"
@@ -11,7 +12,12 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-func init() {
+var loadTestEnvOnce sync.Once
+
+// loadTestEnv sets the test database defaults. It runs when a test first
+// calls SetupTestDB rather than in init, so that binaries importing this
+// package do not inherit test settings.
+func loadTestEnv() {
 	// Load .env file if it exists
 	if err := godotenv.Load("../../../.env"); err != nil {
 		// It's okay if .env doesn't exist in test environment
@@ -40,8 +46,22 @@ func init() {
 }
 
 func SetupTestDB(t *testing.T) *gorm.DB {
-	dbName := os.Getenv("DB_NAME") + "_test"
-	
+	db := openTestDB(t, "_test")
+
+	// Run migrations
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	return db
+}
+
+// openTestDB connects to the test database named DB_NAME followed by suffix,
+// creating it if needed, without migrating it.
+func openTestDB(t *testing.T, suffix string) *gorm.DB {
+	loadTestEnvOnce.Do(loadTestEnv)
+	dbName := os.Getenv("DB_NAME") + suffix
+
 	// Create test database if it doesn't exist
 	err := createTestDatabase(dbName)
 	if err != nil {
@@ -63,11 +83,6 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
 
-	// Run migrations
-	if err := Migrate(db); err != nil {
-		t.Fatalf("Failed to run migrations: %v", err)
-	}
-
 	return db
 }
 
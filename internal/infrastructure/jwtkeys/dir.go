@@ -0,0 +1,182 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A key directory holds one PKCS #8 PEM file per key, named "<kid>.pem", and
+// a file named "active" with the ID of the key used for signing.
+const (
+	activeFile = "active"
+	keyExt     = ".pem"
+)
+
+// KeyInfo describes a key in a key directory.
+type KeyInfo struct {
+	ID        string
+	Algorithm string
+	Active    bool
+	CreatedAt time.Time
+}
+
+// LoadDir loads every key in dir and signs with the active one.
+func LoadDir(dir string) (*KeySet, error) {
+	activeID, err := ActiveKeyID(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+keyExt))
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{keys: make(map[string]*Key, len(paths))}
+	for _, path := range paths {
+		key, err := readKey(path)
+		if err != nil {
+			return nil, err
+		}
+		ks.keys[key.ID] = key
+	}
+
+	ks.signing = ks.keys[activeID]
+	if ks.signing == nil {
+		return nil, fmt.Errorf("active key %q not found in %s", activeID, dir)
+	}
+	return ks, nil
+}
+
+// ListDir describes the keys in dir, oldest first.
+func ListDir(dir string) ([]KeyInfo, error) {
+	activeID, err := ActiveKeyID(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+keyExt))
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]KeyInfo, 0, len(paths))
+	for _, path := range paths {
+		key, err := readKey(path)
+		if err != nil {
+			return nil, err
+		}
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, KeyInfo{
+			ID:        key.ID,
+			Algorithm: key.Algorithm,
+			Active:    key.ID == activeID,
+			CreatedAt: stat.ModTime(),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CreatedAt.Before(infos[j].CreatedAt)
+	})
+	return infos, nil
+}
+
+// GenerateKey writes a new key for algorithm (RS256 or EdDSA) to dir and
+// returns its ID. The key is not activated.
+func GenerateKey(dir, algorithm string) (string, error) {
+	var private interface{}
+	var err error
+	switch algorithm {
+	case AlgRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return "", fmt.Errorf("unsupported algorithm %q, use %s or %s", algorithm, AlgRS256, AlgEdDSA)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return "", err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	id := time.Now().UTC().Format("20060102") + "-" + hex.EncodeToString(suffix)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	block := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, id+keyExt), block, 0o600); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// ActiveKeyID returns the ID of the signing key in dir.
+func ActiveKeyID(dir string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(dir, activeFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Activate makes id the signing key in dir.
+func Activate(dir, id string) error {
+	if _, err := readKey(filepath.Join(dir, id+keyExt)); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, activeFile), []byte(id+"\n"), 0o600)
+}
+
+// Retire deletes a key that is no longer needed for verification. The active
+// key cannot be retired.
+func Retire(dir, id string) error {
+	activeID, err := ActiveKeyID(dir)
+	if err != nil {
+		return err
+	}
+	if id == activeID {
+		return errors.New("cannot retire the active key")
+	}
+	return os.Remove(filepath.Join(dir, id+keyExt))
+}
+
+func readKey(path string) (*Key, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("%s: not a PKCS #8 private key", path)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return newKey(strings.TrimSuffix(filepath.Base(path), keyExt), private)
+}
@@ -0,0 +1,142 @@
+// Package jwtkeys holds the keys used to sign and verify JWTs.
+//
+// With JWT_KEYS_DIR unset, tokens are signed with HS256 and JWT_SECRET, as
+// before. With JWT_KEYS_DIR set, tokens are signed with the active RS256 or
+// EdDSA key from that directory and carry its ID in the "kid" header. Every
+// key in the directory is accepted for verification, so keys can be rotated
+// without invalidating tokens that are still in use.
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go-backend/internal/infrastructure/config"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// Key is a signing key and its verification counterpart.
+type Key struct {
+	ID        string
+	Algorithm string
+	private   interface{}
+	public    interface{}
+}
+
+func newKey(id string, private interface{}) (*Key, error) {
+	switch k := private.(type) {
+	case []byte:
+		return &Key{ID: id, Algorithm: AlgHS256, private: k, public: k}, nil
+	case *rsa.PrivateKey:
+		return &Key{ID: id, Algorithm: AlgRS256, private: k, public: &k.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: id, Algorithm: AlgEdDSA, private: k, public: k.Public()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", private)
+	}
+}
+
+func (k *Key) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// KeySet signs with one key and verifies with any of its keys.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	// legacy verifies HS256 tokens without a kid, issued before the switch
+	// to asymmetric keys.
+	legacy *Key
+}
+
+// NewHMAC returns a KeySet that signs and verifies with HS256.
+func NewHMAC(secret []byte) *KeySet {
+	key, _ := newKey("", secret)
+	return &KeySet{signing: key, legacy: key}
+}
+
+// Sign signs claims with the active key.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.method(), claims)
+	if ks.signing.ID != "" {
+		token.Header["kid"] = ks.signing.ID
+	}
+	return token.SignedString(ks.signing.private)
+}
+
+// Parse verifies tokenString with the key named by its kid header and
+// decodes it into claims.
+func (ks *KeySet) Parse(tokenString string, claims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	opts = append(opts, jwt.WithValidMethods([]string{AlgHS256, AlgRS256, AlgEdDSA}))
+	return jwt.ParseWithClaims(tokenString, claims, ks.keyFunc, opts...)
+}
+
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	key := ks.legacy
+	if kid, ok := token.Header["kid"].(string); ok {
+		key = ks.keys[kid]
+	}
+	// The algorithm must match the key, otherwise a public key could be
+	// used as an HMAC secret.
+	if key == nil || token.Method.Alg() != key.Algorithm {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+	return key.public, nil
+}
+
+// ErrNotConfigured is returned by Default before SetDefault is called.
+var ErrNotConfigured = errors.New("JWT keys are not configured")
+
+var (
+	defaultMu  sync.RWMutex
+	defaultSet *KeySet
+)
+
+// Default returns the process wide KeySet installed with SetDefault.
+func Default() (*KeySet, error) {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	if defaultSet == nil {
+		return nil, ErrNotConfigured
+	}
+	return defaultSet, nil
+}
+
+// SetDefault replaces the process wide KeySet.
+func SetDefault(ks *KeySet) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultSet = ks
+}
+
+// FromConfig loads the keys in cfg.KeysDir, or falls back to HS256 with
+// cfg.Secret. If both are set, the secret is still accepted for tokens
+// without a kid so that sessions survive the switch to asymmetric keys.
+func FromConfig(cfg config.JWTConfig) (*KeySet, error) {
+	if cfg.KeysDir == "" {
+		if cfg.Secret == "" {
+			return nil, errors.New("JWT_SECRET or JWT_KEYS_DIR must be set")
+		}
+		return NewHMAC([]byte(cfg.Secret)), nil
+	}
+
+	ks, err := LoadDir(cfg.KeysDir)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Secret != "" {
+		ks.legacy, _ = newKey("", []byte(cfg.Secret))
+	}
+	return ks, nil
+}
@@ -0,0 +1,211 @@
+package jwtkeys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-backend/internal/infrastructure/config"
+	"go-backend/internal/infrastructure/jwtkeys"
+)
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Minute).Unix()}
+}
+
+func TestKeySet_SignAndParse(t *testing.T) {
+	for _, alg := range []string{jwtkeys.AlgRS256, jwtkeys.AlgEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			dir := t.TempDir()
+			id, err := jwtkeys.GenerateKey(dir, alg)
+			require.NoError(t, err)
+			require.NoError(t, jwtkeys.Activate(dir, id))
+
+			ks, err := jwtkeys.LoadDir(dir)
+			require.NoError(t, err)
+
+			tokenString, err := ks.Sign(testClaims())
+			require.NoError(t, err)
+
+			claims := jwt.MapClaims{}
+			token, err := ks.Parse(tokenString, claims)
+			require.NoError(t, err)
+			assert.Equal(t, alg, token.Method.Alg())
+			assert.Equal(t, id, token.Header["kid"])
+			assert.Equal(t, float64(1), claims["user_id"])
+		})
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	oldID, err := jwtkeys.GenerateKey(dir, jwtkeys.AlgEdDSA)
+	require.NoError(t, err)
+	require.NoError(t, jwtkeys.Activate(dir, oldID))
+
+	oldKeys, err := jwtkeys.LoadDir(dir)
+	require.NoError(t, err)
+	oldToken, err := oldKeys.Sign(testClaims())
+	require.NoError(t, err)
+
+	newID, err := jwtkeys.GenerateKey(dir, jwtkeys.AlgRS256)
+	require.NoError(t, err)
+	require.NoError(t, jwtkeys.Activate(dir, newID))
+
+	t.Run("tokens signed with the previous key still verify", func(t *testing.T) {
+		ks, err := jwtkeys.LoadDir(dir)
+		require.NoError(t, err)
+
+		newToken, err := ks.Sign(testClaims())
+		require.NoError(t, err)
+
+		token, err := ks.Parse(newToken, jwt.MapClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, newID, token.Header["kid"])
+
+		_, err = ks.Parse(oldToken, jwt.MapClaims{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("the active key cannot be retired", func(t *testing.T) {
+		assert.Error(t, jwtkeys.Retire(dir, newID))
+	})
+
+	t.Run("tokens signed with a retired key are rejected", func(t *testing.T) {
+		require.NoError(t, jwtkeys.Retire(dir, oldID))
+
+		ks, err := jwtkeys.LoadDir(dir)
+		require.NoError(t, err)
+
+		_, err = ks.Parse(oldToken, jwt.MapClaims{})
+		assert.Error(t, err)
+	})
+}
+
+func TestKeySet_RejectsMismatchedTokens(t *testing.T) {
+	dir := t.TempDir()
+	id, err := jwtkeys.GenerateKey(dir, jwtkeys.AlgRS256)
+	require.NoError(t, err)
+	require.NoError(t, jwtkeys.Activate(dir, id))
+
+	ks, err := jwtkeys.LoadDir(dir)
+	require.NoError(t, err)
+
+	t.Run("HS256 token without a kid", func(t *testing.T) {
+		tokenString, err := jwtkeys.NewHMAC([]byte("secret")).Sign(testClaims())
+		require.NoError(t, err)
+
+		_, err = ks.Parse(tokenString, jwt.MapClaims{})
+		assert.Error(t, err)
+	})
+
+	t.Run("HS256 token claiming an RSA kid", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+		token.Header["kid"] = id
+		tokenString, err := token.SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		_, err = ks.Parse(tokenString, jwt.MapClaims{})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		other := t.TempDir()
+		otherID, err := jwtkeys.GenerateKey(other, jwtkeys.AlgRS256)
+		require.NoError(t, err)
+		require.NoError(t, jwtkeys.Activate(other, otherID))
+		otherKeys, err := jwtkeys.LoadDir(other)
+		require.NoError(t, err)
+
+		tokenString, err := otherKeys.Sign(testClaims())
+		require.NoError(t, err)
+
+		_, err = ks.Parse(tokenString, jwt.MapClaims{})
+		assert.Error(t, err)
+	})
+}
+
+func TestKeySet_HMAC(t *testing.T) {
+	ks := jwtkeys.NewHMAC([]byte("secret"))
+
+	tokenString, err := ks.Sign(testClaims())
+	require.NoError(t, err)
+
+	token, err := ks.Parse(tokenString, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, jwtkeys.AlgHS256, token.Method.Alg())
+	assert.NotContains(t, token.Header, "kid")
+
+	_, err = jwtkeys.NewHMAC([]byte("other")).Parse(tokenString, jwt.MapClaims{})
+	assert.Error(t, err)
+
+	assert.Empty(t, ks.JWKS().Keys)
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Run("falls back to the secret", func(t *testing.T) {
+		ks, err := jwtkeys.FromConfig(config.JWTConfig{Secret: "secret"})
+		require.NoError(t, err)
+
+		tokenString, err := ks.Sign(testClaims())
+		require.NoError(t, err)
+		_, err = jwtkeys.NewHMAC([]byte("secret")).Parse(tokenString, jwt.MapClaims{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("requires a key", func(t *testing.T) {
+		_, err := jwtkeys.FromConfig(config.JWTConfig{})
+		assert.Error(t, err)
+	})
+
+	t.Run("accepts legacy HS256 tokens alongside a key directory", func(t *testing.T) {
+		dir := t.TempDir()
+		id, err := jwtkeys.GenerateKey(dir, jwtkeys.AlgEdDSA)
+		require.NoError(t, err)
+		require.NoError(t, jwtkeys.Activate(dir, id))
+		ks, err := jwtkeys.FromConfig(config.JWTConfig{Secret: "secret", KeysDir: dir})
+		require.NoError(t, err)
+
+		legacyToken, err := jwtkeys.NewHMAC([]byte("secret")).Sign(testClaims())
+		require.NoError(t, err)
+		_, err = ks.Parse(legacyToken, jwt.MapClaims{})
+		assert.NoError(t, err)
+
+		tokenString, err := ks.Sign(testClaims())
+		require.NoError(t, err)
+		token, err := ks.Parse(tokenString, jwt.MapClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, jwtkeys.AlgEdDSA, token.Method.Alg())
+	})
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	dir := t.TempDir()
+	rsaID, err := jwtkeys.GenerateKey(dir, jwtkeys.AlgRS256)
+	require.NoError(t, err)
+	edID, err := jwtkeys.GenerateKey(dir, jwtkeys.AlgEdDSA)
+	require.NoError(t, err)
+	require.NoError(t, jwtkeys.Activate(dir, edID))
+
+	ks, err := jwtkeys.LoadDir(dir)
+	require.NoError(t, err)
+
+	keys := map[string]jwtkeys.JWK{}
+	for _, key := range ks.JWKS().Keys {
+		keys[key.KeyID] = key
+	}
+	require.Len(t, keys, 2)
+
+	assert.Equal(t, "RSA", keys[rsaID].KeyType)
+	assert.Equal(t, jwtkeys.AlgRS256, keys[rsaID].Algorithm)
+	assert.Equal(t, "AQAB", keys[rsaID].E)
+	assert.NotEmpty(t, keys[rsaID].N)
+
+	assert.Equal(t, "OKP", keys[edID].KeyType)
+	assert.Equal(t, "Ed25519", keys[edID].Curve)
+	assert.Equal(t, jwtkeys.AlgEdDSA, keys[edID].Algorithm)
+	assert.NotEmpty(t, keys[edID].X)
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/infrastructure/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// lines decodes the JSON log lines written to buf.
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		out = append(out, entry)
+	}
+	return out
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(config.LogConfig{Format: "json", Level: "warn"}, &buf)
+	require.NoError(t, err)
+
+	log.Info("hidden")
+	log.Warn("shown", "key", "value")
+
+	entries := lines(t, &buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "shown", entries[0]["msg"])
+	assert.Equal(t, "value", entries[0]["key"])
+
+	buf.Reset()
+	log, err = New(config.LogConfig{Format: "text", Level: "debug"}, &buf)
+	require.NoError(t, err)
+	log.Debug("text line")
+	assert.Contains(t, buf.String(), `msg="text line"`)
+
+	_, err = New(config.LogConfig{Format: "xml", Level: "info"}, &buf)
+	assert.Error(t, err)
+	_, err = New(config.LogConfig{Format: "json", Level: "loud"}, &buf)
+	assert.Error(t, err)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+
+	log := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, log, FromContext(WithLogger(context.Background(), log)))
+}
+
+func TestGormLogger_Trace(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})).With("request_id", "req-1")
+	ctx := WithLogger(context.Background(), log)
+	query := func() (string, int64) { return "SELECT 1", 1 }
+
+	gormLogger := NewGormLogger(100 * time.Millisecond)
+
+	gormLogger.Trace(ctx, time.Now(), query, nil)
+	gormLogger.Trace(ctx, time.Now().Add(-time.Second), query, nil)
+	gormLogger.Trace(ctx, time.Now(), query, errors.New("connection reset"))
+	gormLogger.Trace(ctx, time.Now(), query, gorm.ErrRecordNotFound)
+
+	entries := lines(t, &buf)
+	require.Len(t, entries, 4)
+	for _, entry := range entries {
+		assert.Equal(t, "req-1", entry["request_id"], "queries carry the request logger's attributes")
+		assert.Equal(t, "SELECT 1", entry["sql"])
+	}
+	assert.Equal(t, "DEBUG", entries[0]["level"])
+	assert.Equal(t, "WARN", entries[1]["level"])
+	assert.Equal(t, "slow query", entries[1]["msg"])
+	assert.Equal(t, "ERROR", entries[2]["level"])
+	assert.Equal(t, "connection reset", entries[2]["error"])
+	assert.Equal(t, "DEBUG", entries[3]["level"], "record not found is not an error")
+
+	buf.Reset()
+	gormLogger.LogMode(logger.Silent).Trace(ctx, time.Now(), query, errors.New("connection reset"))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LogSender logs that a message would have been sent instead of sending it.
+// The body is left out, as it holds single-use links; use FileSender to
+// read messages.
+type LogSender struct {
+	from string
+}
+
+func NewLogSender(from string) *LogSender {
+	return &LogSender{from: from}
+}
+
+func (s *LogSender) Send(msg Message) error {
+	slog.Info("mail not sent", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
+
+// FileSender writes every message to its own .eml file in dir, which makes it
+// easy to inspect outgoing mail in development and tests.
+type FileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) *FileSender {
+	return &FileSender{dir: dir, from: from}
+}
+
+func (s *FileSender) Send(msg Message) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), recipient)
+	return os.WriteFile(filepath.Join(s.dir, name), format(s.from, msg), 0o644)
+}
@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"fmt"
+	"strconv"
+
+	"go-backend/internal/infrastructure/config"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers email messages.
+type Sender interface {
+	Send(msg Message) error
+}
+
+// New returns the Sender selected by cfg.Driver: "smtp", "file" or "log".
+func New(cfg config.MailConfig) (Sender, error) {
+	switch cfg.Driver {
+	case "log":
+		return NewLogSender(cfg.From), nil
+	case "file":
+		return NewFileSender(cfg.Dir, cfg.From), nil
+	case "smtp":
+		return NewSMTPSender(SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     strconv.Itoa(cfg.SMTP.Port),
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.From,
+		})
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// format renders msg as an RFC 5322 message.
+func format(from string, msg Message) []byte {
+	return []byte(fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		from, msg.To, msg.Subject, msg.Body,
+	))
+}
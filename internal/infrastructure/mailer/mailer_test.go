@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go-backend/internal/infrastructure/config"
+)
+
+func TestFileSender_Send(t *testing.T) {
+	dir := t.TempDir()
+	sender := NewFileSender(dir, "no-reply@example.com")
+
+	err := sender.Send(Message{
+		To:      "user@example.com",
+		Subject: "Hello",
+		Body:    "Test body",
+	})
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "From: no-reply@example.com\r\n"))
+	assert.Contains(t, string(content), "To: user@example.com\r\n")
+	assert.Contains(t, string(content), "Subject: Hello\r\n")
+	assert.Contains(t, string(content), "Test body")
+}
+
+func TestLogSender_Send(t *testing.T) {
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	err := NewLogSender("no-reply@example.com").Send(Message{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "http://localhost:3000/reset-password?token=secret-token",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "user@example.com")
+	assert.NotContains(t, buf.String(), "secret-token", "links are not logged")
+}
+
+func TestNew(t *testing.T) {
+	cfg := config.Default().Mail
+	sender, err := New(cfg)
+	assert.NoError(t, err)
+	assert.IsType(t, &LogSender{}, sender)
+
+	cfg.Driver = "smtp"
+	cfg.SMTP.Host = ""
+	_, err = New(cfg)
+	assert.Error(t, err)
+
+	cfg.Driver = "carrier-pigeon"
+	_, err = New(cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   uint
+	Name string
+}
+
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, observer.(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+// dryRunDB returns a database that builds SQL without connecting.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(GormPlugin{}))
+	return db
+}
+
+func TestGormPlugin(t *testing.T) {
+	db := dryRunDB(t)
+
+	queries := DBQueryDuration.WithLabelValues("query", "widgets")
+	creates := DBQueryDuration.WithLabelValues("create", "widgets")
+	queriesBefore := sampleCount(t, queries)
+	createsBefore := sampleCount(t, creates)
+
+	var widgets []widget
+	db.Find(&widgets)
+	db.Where("name = ?", "a").Find(&widgets)
+	db.Create(&widget{Name: "a"})
+
+	assert.Equal(t, uint64(2), sampleCount(t, queries)-queriesBefore)
+	assert.Equal(t, uint64(1), sampleCount(t, creates)-createsBefore)
+}
+
+func TestGormPlugin_Errors(t *testing.T) {
+	db := dryRunDB(t)
+	errs := DBQueryErrors.WithLabelValues("query", "widgets")
+	before := testutil.ToFloat64(errs)
+
+	var w widget
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:fail", func(db *gorm.DB) {
+		if db.Statement.Table == "widgets" {
+			db.AddError(errors.New("connection reset"))
+		}
+	}))
+	db.Find(&w)
+	assert.Equal(t, 1.0, testutil.ToFloat64(errs)-before)
+
+	require.NoError(t, db.Callback().Query().Replace("test:fail", func(db *gorm.DB) {
+		db.AddError(gorm.ErrRecordNotFound)
+	}))
+	db.Find(&w)
+	assert.Equal(t, 1.0, testutil.ToFloat64(errs)-before, "record not found is not an error")
+}
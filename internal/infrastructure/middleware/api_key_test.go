@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go-backend/internal/infrastructure/database"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/domain/repository"
+	"go-backend/internal/pkg/authz"
+)
+
+func TestJWTOrAPIKeyAuth(t *testing.T) {
+	router, db := setupTestRouter(t)
+	defer database.CleanupTestDB(t, db)
+	defer cleanupTestData(t, db)
+
+	userRepo := repository.NewUserRepository(db)
+	user := &entity.User{
+		Name:     "API User",
+		Email:    "api@example.com",
+		Password: "password123",
+	}
+	err := userRepo.Create(context.Background(), user)
+	assert.NoError(t, err)
+
+	keyRepo := repository.NewAPIKeyRepository(db)
+	expired := time.Now().Add(-time.Hour)
+	keys := map[string]*entity.APIKey{
+		"gbk_projects": {Scopes: []entity.APIKeyScope{entity.ScopeProjectsWrite}},
+		"gbk_readonly": {Scopes: []entity.APIKeyScope{entity.ScopeRead}},
+		"gbk_expired":  {Scopes: []entity.APIKeyScope{entity.ScopeProjectsWrite}, ExpiresAt: &expired},
+	}
+	for key, apiKey := range keys {
+		apiKey.UserID = user.ID
+		apiKey.Name = key
+		apiKey.Prefix = key
+		apiKey.KeyHash = entity.HashAPIKey(key)
+		assert.NoError(t, keyRepo.Create(context.Background(), apiKey))
+	}
+
+	router.POST("/projects", JWTOrAPIKeyAuth(entity.ScopeProjectsWrite), func(c *gin.Context) {
+		assert.Equal(t, user.ID, c.GetUint("user_id"))
+		actor, ok := authz.ActorFromContext(c.Request.Context())
+		assert.True(t, ok)
+		assert.Equal(t, authz.Actor{UserID: user.ID}, actor)
+		c.String(http.StatusOK, "success")
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"X-API-Key header", "X-API-Key", "gbk_projects", http.StatusOK},
+		{"Authorization header", "Authorization", "ApiKey gbk_projects", http.StatusOK},
+		{"missing scope", "X-API-Key", "gbk_readonly", http.StatusForbidden},
+		{"expired key", "X-API-Key", "gbk_expired", http.StatusUnauthorized},
+		{"unknown key", "X-API-Key", "gbk_unknown", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/projects", nil)
+			req.Header.Set(tt.header, tt.value)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+
+	t.Run("admin keys carry the admin override", func(t *testing.T) {
+		admin := &entity.User{Name: "API Admin", Email: "api-admin@example.com", Password: "password123", Role: entity.RoleAdmin}
+		assert.NoError(t, userRepo.Create(context.Background(), admin))
+		assert.NoError(t, keyRepo.Create(context.Background(), &entity.APIKey{
+			UserID:  admin.ID,
+			Name:    "gbk_admin",
+			Prefix:  "gbk_admin",
+			KeyHash: entity.HashAPIKey("gbk_admin"),
+			Scopes:  []entity.APIKeyScope{entity.ScopeRead},
+		}))
+
+		router.GET("/admin-override", JWTOrAPIKeyAuth(entity.ScopeRead), func(c *gin.Context) {
+			assert.True(t, authz.CanModify(c.Request.Context(), admin.ID, user.ID))
+			c.String(http.StatusOK, "success")
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin-override", nil)
+		req.Header.Set("X-API-Key", "gbk_admin")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -1,35 +1,23 @@
 package middleware
 
 import (
-	"net/http"
-	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"go-backend/internal/infrastructure/jwtkeys"
+	"go-backend/internal/infrastructure/metrics"
+	"go-backend/internal/modules/user/domain/entity"
 	"go-backend/internal/modules/user/domain/repository"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/response"
 	"golang.org/x/time/rate"
 	"gorm.io/gorm"
 )
 
-type Response struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
-
-func formatResponse(status int, message string, data interface{}, err string) Response {
-	return Response{
-		Status:  status,
-		Message: message,
-		Data:    data,
-		Error:   err,
-	}
-}
-
 // RateLimiter stores IP-based rate limiters
 type RateLimiter struct {
 	visitors map[string]*rate.Limiter
@@ -64,13 +52,8 @@ func RateLimitMiddleware(rl *RateLimiter) gin.HandlerFunc {
 		ip := c.ClientIP()
 		limiter := rl.GetLimiter(ip)
 		if !limiter.Allow() {
-			c.JSON(http.StatusTooManyRequests, formatResponse(
-				http.StatusTooManyRequests,
-				"Rate limit exceeded",
-				nil,
-				"Too many requests. Please try again later.",
-			))
-			c.Abort()
+			metrics.RateLimitRejections.WithLabelValues(routeLabel(c)).Inc()
+			response.Abort(c, apperror.TooManyRequests("Too many requests. Please try again later."))
 			return
 		}
 		c.Next()
@@ -88,123 +71,103 @@ const (
 // JWTAuth middleware for protecting routes
 func JWTAuth(tokenType TokenType) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication failed",
-				nil,
-				"Authorization header is required",
-			))
-			c.Abort()
-			return
+		if authenticateJWT(c, tokenType) {
+			c.Next()
 		}
+	}
+}
 
-		bearerToken := strings.Split(authHeader, " ")
-		if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication failed",
-				nil,
-				"Invalid token format. Use 'Bearer <token>'",
-			))
-			c.Abort()
-			return
-		}
+// authenticateJWT validates the bearer token and stores the user in the
+// context. It aborts the request and returns false on failure.
+func authenticateJWT(c *gin.Context, tokenType TokenType) bool {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		response.Abort(c, apperror.Unauthorized("Authorization header is required"))
+		return false
+	}
 
-		tokenString := bearerToken[1]
-		claims := jwt.MapClaims{}
+	bearerToken := strings.Split(authHeader, " ")
+	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
+		response.Abort(c, apperror.Unauthorized("Invalid token format. Use 'Bearer <token>'"))
+		return false
+	}
 
-		// Validate token signature and claims
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			// Validate signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication failed",
-				nil,
-				"Invalid token signature",
-			))
-			c.Abort()
-			return
-		}
+	tokenString := bearerToken[1]
+	claims := jwt.MapClaims{}
 
-		if !token.Valid {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication failed",
-				nil,
-				"Invalid token claims",
-			))
-			c.Abort()
-			return
-		}
+	keys, err := jwtkeys.Default()
+	if err != nil {
+		response.Abort(c, apperror.Internal(err))
+		return false
+	}
 
-		// Validate token type
-		if tokenTypeClaim, ok := claims["token_type"].(string); !ok || TokenType(tokenTypeClaim) != tokenType {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication failed",
-				nil,
-				"Invalid token type",
-			))
-			c.Abort()
-			return
-		}
+	// Validate token signature and claims
+	token, err := keys.Parse(tokenString, claims)
 
-		// Check token expiration
-		if exp, ok := claims["exp"].(float64); ok {
-			if time.Now().Unix() > int64(exp) {
-				c.JSON(http.StatusUnauthorized, formatResponse(
-					http.StatusUnauthorized,
-					"Authentication failed",
-					nil,
-					"Token has expired",
-				))
-				c.Abort()
-				return
-			}
-		}
+	if err != nil {
+		response.Abort(c, apperror.Unauthorized("Invalid token signature"))
+		return false
+	}
 
-		// Get user from database and verify token
-		userID := uint(claims["user_id"].(float64))
-		db := c.MustGet("db").(*gorm.DB)
-		userRepo := repository.NewUserRepository(db)
-		user, err := userRepo.GetByID(userID)
-
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication failed",
-				nil,
-				"User not found",
-			))
-			c.Abort()
-			return
-		}
+	if !token.Valid {
+		response.Abort(c, apperror.Unauthorized("Invalid token claims"))
+		return false
+	}
 
-		if user.Token == nil || *user.Token != tokenString {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication failed",
-				nil,
-				"Token has been revoked",
-			))
-			c.Abort()
-			return
+	// Validate token type
+	if tokenTypeClaim, ok := claims["token_type"].(string); !ok || TokenType(tokenTypeClaim) != tokenType {
+		response.Abort(c, apperror.Unauthorized("Invalid token type"))
+		return false
+	}
+
+	// Check token expiration
+	if exp, ok := claims["exp"].(float64); ok {
+		if time.Now().Unix() > int64(exp) {
+			response.Abort(c, apperror.Unauthorized("Token has expired"))
+			return false
 		}
+	}
 
-		// Store user information in context
-		c.Set("user_id", userID)
-		c.Set("user_role", claims["role"])
-		c.Next()
+	// Get user from database and verify the session is still active
+	userID := uint(claims["user_id"].(float64))
+	db := c.MustGet("db").(*gorm.DB)
+	userRepo := repository.NewUserRepository(db)
+	user, err := userRepo.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		response.Abort(c, apperror.Unauthorized("User not found"))
+		return false
 	}
+
+	sessionID, _ := claims["sid"].(string)
+	sessionRepo := repository.NewSessionRepository(db)
+	session, err := sessionRepo.GetByID(c.Request.Context(), sessionID)
+	if err != nil || session.UserID != userID || !session.IsActive() {
+		response.Abort(c, apperror.Unauthorized("Token has been revoked"))
+		return false
+	}
+
+	// Avoid a write on every request; minute granularity is enough for
+	// the session listing.
+	if now := time.Now(); now.Sub(session.LastSeenAt) > time.Minute {
+		_ = sessionRepo.Touch(c.Request.Context(), session.ID, c.ClientIP(), now)
+	}
+
+	// Store user information in context
+	c.Set("user_id", userID)
+	c.Set("session_id", session.ID)
+	c.Set("email_verified", user.IsEmailVerified())
+	// The role claim is only informational; use the stored role so that
+	// role changes apply without waiting for the token to expire.
+	c.Set("user_role", string(user.Role))
+	setActor(c, user)
+	return true
+}
+
+// setActor stores the authenticated user in the request context, where
+// services read it to decide about ownership; see authz.CanModify.
+func setActor(c *gin.Context, user *entity.User) {
+	actor := authz.Actor{UserID: user.ID, Admin: user.Role == entity.RoleAdmin}
+	c.Request = c.Request.WithContext(authz.WithActor(c.Request.Context(), actor))
 }
 
 // RequireAuth protects routes that require authentication
@@ -217,13 +180,19 @@ func RequireAuth(enabled bool) gin.HandlerFunc {
 
 		_, exists := c.Get("user_id")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, formatResponse(
-				http.StatusUnauthorized,
-				"Authentication required",
-				nil,
-				"You must be logged in to access this resource",
-			))
-			c.Abort()
+			response.Abort(c, apperror.Unauthorized("You must be logged in to access this resource"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail blocks users who have not confirmed their email address
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("email_verified") {
+			response.Abort(c, apperror.Forbidden("Please verify your email address first"))
 			return
 		}
 
@@ -231,29 +200,25 @@ func RequireAuth(enabled bool) gin.HandlerFunc {
 	}
 }
 
-// RequireRole checks if the authenticated user has the required role
-func RequireRole(role string) gin.HandlerFunc {
+// RequireRole checks if the authenticated user has one of the given roles
+func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("user_role")
 		if !exists {
-			c.JSON(http.StatusForbidden, formatResponse(
-				http.StatusForbidden,
-				"Access denied",
-				nil,
-				"Role information not found",
-			))
-			c.Abort()
+			response.Abort(c, apperror.Forbidden("Role information not found"))
 			return
 		}
 
-		if userRole != role {
-			c.JSON(http.StatusForbidden, formatResponse(
-				http.StatusForbidden,
-				"Access denied",
-				nil,
-				"Insufficient permissions to access this resource",
-			))
-			c.Abort()
+		allowed := false
+		for _, role := range roles {
+			if userRole == role {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			response.Abort(c, apperror.Forbidden("Insufficient permissions to access this resource"))
 			return
 		}
 
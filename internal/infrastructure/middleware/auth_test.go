@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -34,15 +35,32 @@ func setupTestRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
 	return router, db
 }
 
-func generateToken(userID uint) (string, error) {
+func generateToken(userID uint, sessionID string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+		"user_id":    userID,
+		"sid":        sessionID,
+		"token_type": string(AccessToken),
+		"exp":        time.Now().Add(24 * time.Hour).Unix(),
 	})
 
 	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
 }
 
+func createSession(t *testing.T, db *gorm.DB, userID uint, id string, revoked bool) {
+	session := &entity.Session{
+		ID:         id,
+		UserID:     userID,
+		LastSeenAt: time.Now(),
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+	}
+	if revoked {
+		now := time.Now()
+		session.RevokedAt = &now
+	}
+	err := repository.NewSessionRepository(db).Create(context.Background(), session)
+	assert.NoError(t, err)
+}
+
 func TestJWTAuth_ValidToken(t *testing.T) {
 	router, db := setupTestRouter(t)
 	defer database.CleanupTestDB(t, db)
@@ -55,15 +73,11 @@ func TestJWTAuth_ValidToken(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	err := userRepo.Create(user)
-	assert.NoError(t, err)
-
-	token, err := generateToken(user.ID)
+	err := userRepo.Create(context.Background(), user)
 	assert.NoError(t, err)
 
-	expiresAt := time.Now().Add(24 * time.Hour)
-	user.SetToken(token, expiresAt)
-	err = userRepo.Update(user)
+	createSession(t, db, user.ID, "session-1", false)
+	token, err := generateToken(user.ID, "session-1")
 	assert.NoError(t, err)
 
 	// Setup test endpoint
@@ -71,6 +85,7 @@ func TestJWTAuth_ValidToken(t *testing.T) {
 		userID, exists := c.Get("user_id")
 		assert.True(t, exists)
 		assert.Equal(t, user.ID, userID.(uint))
+		assert.Equal(t, "session-1", c.GetString("session_id"))
 		c.String(http.StatusOK, "success")
 	})
 
@@ -133,23 +148,22 @@ func TestJWTAuth_ExpiredToken(t *testing.T) {
 		Email:    "test2@example.com",
 		Password: "password123",
 	}
-	err := userRepo.Create(user)
+	err := userRepo.Create(context.Background(), user)
 	assert.NoError(t, err)
 
+	createSession(t, db, user.ID, "session-2", false)
+
 	// Generate expired token
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(-24 * time.Hour).Unix(),
+		"user_id":    user.ID,
+		"sid":        "session-2",
+		"token_type": string(AccessToken),
+		"exp":        time.Now().Add(-24 * time.Hour).Unix(),
 	})
 
 	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
 	assert.NoError(t, err)
 
-	expiresAt := time.Now().Add(-24 * time.Hour)
-	user.SetToken(tokenString, expiresAt)
-	err = userRepo.Update(user)
-	assert.NoError(t, err)
-
 	// Setup test endpoint
 	router.GET("/test", JWTAuth(AccessToken), func(c *gin.Context) {
 		t.Error("This handler should not be called")
@@ -163,3 +177,99 @@ func TestJWTAuth_ExpiredToken(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
+
+func TestJWTAuth_RevokedSession(t *testing.T) {
+	router, db := setupTestRouter(t)
+	defer database.CleanupTestDB(t, db)
+	defer cleanupTestData(t, db)
+
+	userRepo := repository.NewUserRepository(db)
+	user := &entity.User{
+		Name:     "Test User 3",
+		Email:    "test3@example.com",
+		Password: "password123",
+	}
+	err := userRepo.Create(context.Background(), user)
+	assert.NoError(t, err)
+
+	createSession(t, db, user.ID, "session-3", true)
+	token, err := generateToken(user.ID, "session-3")
+	assert.NoError(t, err)
+
+	router.GET("/test", JWTAuth(AccessToken), func(c *gin.Context) {
+		t.Error("This handler should not be called")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		role           interface{}
+		expectedStatus int
+	}{
+		{name: "allowed role", role: "admin", expectedStatus: http.StatusOK},
+		{name: "second allowed role", role: "editor", expectedStatus: http.StatusOK},
+		{name: "other role", role: "viewer", expectedStatus: http.StatusForbidden},
+		{name: "no role", role: nil, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/test", func(c *gin.Context) {
+				if tt.role != nil {
+					c.Set("user_role", tt.role)
+				}
+				c.Next()
+			}, RequireRole("admin", "editor"), func(c *gin.Context) {
+				c.String(http.StatusOK, "success")
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/test", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		verified       bool
+		expectedStatus int
+	}{
+		{name: "verified", verified: true, expectedStatus: http.StatusOK},
+		{name: "unverified", verified: false, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/test", func(c *gin.Context) {
+				c.Set("email_verified", tt.verified)
+				c.Next()
+			}, RequireVerifiedEmail(), func(c *gin.Context) {
+				c.String(http.StatusOK, "success")
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/test", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-backend/internal/pkg/response"
+)
+
+// ErrorMiddleware writes the error that a handler recorded with c.Error, as
+// in
+//
+//	if err != nil {
+//		c.Error(err)
+//		return
+//	}
+//
+// mapping apperror kinds to HTTP status codes; see response.Error. Nothing
+// is written when the handler has already written a response.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		response.Error(c, c.Errors.Last().Err)
+	}
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/response"
+	"go-backend/internal/pkg/validation"
+	"gorm.io/gorm"
+)
+
+type errorTestRequest struct {
+	Title string `json:"title" binding:"required,max=10"`
+	Email string `json:"email" binding:"omitempty,email"`
+}
+
+func TestErrorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validation.Setup()
+	router := gin.New()
+	router.Use(ErrorMiddleware())
+	router.POST("/error-test", func(c *gin.Context) {
+		var req errorTestRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(apperror.FromBinding(err))
+			return
+		}
+		c.JSON(http.StatusCreated, response.New(http.StatusCreated, "created", req))
+	})
+	router.GET("/error-test/missing", func(c *gin.Context) {
+		c.Error(apperror.NotFoundOr(gorm.ErrRecordNotFound, "post not found"))
+	})
+	router.GET("/error-test/internal", func(c *gin.Context) {
+		c.Error(errors.New("connection refused"))
+	})
+	router.GET("/error-test/written", func(c *gin.Context) {
+		c.Error(errors.New("ignored"))
+		c.JSON(http.StatusAccepted, response.New(http.StatusAccepted, "accepted", nil))
+	})
+
+	serve := func(req *http.Request) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body map[string]interface{}
+		if w.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		}
+		return w, body
+	}
+
+	t.Run("wraps successful responses", func(t *testing.T) {
+		w, body := serve(httptest.NewRequest(http.MethodPost, "/error-test", strings.NewReader(`{"title":"hello"}`)))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "created", body["message"])
+		assert.Equal(t, map[string]interface{}{"title": "hello", "email": ""}, body["data"])
+		assert.NotContains(t, body, "error")
+	})
+
+	t.Run("lists invalid fields by JSON name", func(t *testing.T) {
+		w, body := serve(httptest.NewRequest(http.MethodPost, "/error-test", strings.NewReader(`{"email":"nope"}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "validation_failed", body["error"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"field": "title", "rule": "required", "message": "is required"},
+			map[string]interface{}{"field": "email", "rule": "email", "message": "must be a valid email address"},
+		}, body["details"])
+	})
+
+	t.Run("reports rule parameters", func(t *testing.T) {
+		w, body := serve(httptest.NewRequest(http.MethodPost, "/error-test", strings.NewReader(`{"title":"`+strings.Repeat("a", 11)+`"}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"field": "title", "rule": "max_length", "param": "10", "message": "must be at most 10 characters long"},
+		}, body["details"])
+	})
+
+	t.Run("translates messages to the accepted language", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/error-test", strings.NewReader(`{"email":"nope"}`))
+		req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
+		w, body := serve(req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "id", w.Header().Get("Content-Language"))
+		assert.Equal(t, "validasi permintaan gagal", body["message"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"field": "title", "rule": "required", "message": "wajib diisi"},
+			map[string]interface{}{"field": "email", "rule": "email", "message": "harus berupa alamat email yang valid"},
+		}, body["details"])
+	})
+
+	t.Run("reports malformed JSON", func(t *testing.T) {
+		w, body := serve(httptest.NewRequest(http.MethodPost, "/error-test", strings.NewReader(`{"title":`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "bad_request", body["error"])
+		assert.Equal(t, "request body is not valid JSON", body["message"])
+	})
+
+	t.Run("maps kinds to status codes", func(t *testing.T) {
+		w, body := serve(httptest.NewRequest(http.MethodGet, "/error-test/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "not_found", body["error"])
+		assert.Equal(t, "post not found", body["message"])
+	})
+
+	t.Run("hides internal errors", func(t *testing.T) {
+		w, body := serve(httptest.NewRequest(http.MethodGet, "/error-test/internal", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "internal", body["error"])
+		assert.Equal(t, "internal server error", body["message"])
+		assert.NotContains(t, w.Body.String(), "connection refused")
+	})
+
+	t.Run("writes problem details when accepted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/error-test/missing", nil)
+		req.Header.Set("Accept", "application/problem+json")
+		w, body := serve(req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, response.ProblemContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, map[string]interface{}{
+			"type":     "about:blank",
+			"title":    "Not Found",
+			"status":   float64(http.StatusNotFound),
+			"detail":   "post not found",
+			"instance": "/error-test/missing",
+			"code":     "not_found",
+		}, body)
+	})
+
+	t.Run("leaves written responses alone", func(t *testing.T) {
+		w, body := serve(httptest.NewRequest(http.MethodGet, "/error-test/written", nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "accepted", body["message"])
+	})
+}
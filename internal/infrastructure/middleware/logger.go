@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go-backend/internal/infrastructure/logging"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/response"
+)
+
+// LoggerMiddleware logs every request with the request logger once it has
+// been served: at error level for 5xx responses, warn for 4xx and info
+// otherwise. It replaces gin.Logger, whose text format cannot be parsed.
+func LoggerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			slog.String("method", c.Request.Method),
+			slog.String("route", routeLabel(c)),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if userID, ok := c.Get("user_id"); ok {
+			attrs = append(attrs, slog.Any("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).Log(ctx, level, "request", attrs...)
+	}
+}
+
+// RecoveryMiddleware turns a panic into a 500 response and logs it with the
+// stack trace through the request logger.
+func RecoveryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if err := recover(); err != nil {
+				ctx := c.Request.Context()
+				logging.FromContext(ctx).ErrorContext(ctx, "panic recovered",
+					slog.Any("error", err),
+					slog.String("stack", string(debug.Stack())),
+				)
+				response.Abort(c, apperror.Internal(fmt.Errorf("panic: %v", err)))
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/infrastructure/logging"
+)
+
+// captureLogs makes slog.Default write JSON to the returned buffer for the
+// duration of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		out = append(out, entry)
+	}
+	return out
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+
+	var gotID string
+	router.GET("/test", func(c *gin.Context) {
+		gotID = c.GetString("request_id")
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		reuse  bool
+	}{
+		{name: "generates an ID", header: ""},
+		{name: "reuses a valid ID", header: "abc-123", reuse: true},
+		{name: "replaces an ID with spaces", header: "abc 123"},
+		{name: "replaces an overlong ID", header: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			assert.NotEmpty(t, id)
+			assert.Equal(t, id, gotID)
+			if tt.reuse {
+				assert.Equal(t, tt.header, id)
+			} else {
+				assert.NotEqual(t, tt.header, id)
+				assert.Len(t, id, 32)
+			}
+		})
+	}
+}
+
+func TestLoggerMiddleware(t *testing.T) {
+	buf := captureLogs(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), LoggerMiddleware(), RecoveryMiddleware())
+	router.GET("/items/:id", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).InfoContext(ctx, "handler")
+		c.Status(http.StatusNotFound)
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/7", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logEntries(t, buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "handler", entries[0]["msg"])
+	assert.Equal(t, "req-1", entries[0]["request_id"], "handlers log with the request logger")
+
+	assert.Equal(t, "request", entries[1]["msg"])
+	assert.Equal(t, "WARN", entries[1]["level"])
+	assert.Equal(t, "req-1", entries[1]["request_id"])
+	assert.Equal(t, "/items/:id", entries[1]["route"])
+	assert.Equal(t, "/items/7", entries[1]["path"])
+	assert.Equal(t, float64(http.StatusNotFound), entries[1]["status"])
+
+	buf.Reset()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	entries = logEntries(t, buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "panic recovered", entries[0]["msg"])
+	assert.Equal(t, "boom", entries[0]["error"])
+	assert.Contains(t, entries[0]["stack"], "runtime/debug.Stack")
+	assert.Equal(t, "ERROR", entries[1]["level"])
+	assert.Equal(t, float64(http.StatusInternalServerError), entries[1]["status"])
+}
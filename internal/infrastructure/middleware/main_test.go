@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"os"
+	"testing"
+
+	"go-backend/internal/infrastructure/jwtkeys"
+)
+
+func TestMain(m *testing.M) {
+	// The tests sign tokens with JWT_SECRET
+	if os.Getenv("JWT_SECRET") == "" {
+		os.Setenv("JWT_SECRET", "test_secret")
+	}
+	jwtkeys.SetDefault(jwtkeys.NewHMAC([]byte(os.Getenv("JWT_SECRET"))))
+	os.Exit(m.Run())
+}
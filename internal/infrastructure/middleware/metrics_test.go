@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/infrastructure/metrics"
+)
+
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, observer.(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MetricsMiddleware())
+
+	var inFlight float64
+	router.GET("/metrics-test/:id", func(c *gin.Context) {
+		inFlight = testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues(http.MethodGet, "/metrics-test/:id"))
+		c.Status(http.StatusNoContent)
+	})
+
+	requests := metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/metrics-test/:id", "204")
+	duration := metrics.HTTPRequestDuration.WithLabelValues(http.MethodGet, "/metrics-test/:id", "204")
+	unmatched := metrics.HTTPRequests.WithLabelValues(http.MethodGet, metrics.UnmatchedRoute, "404")
+	requestsBefore := testutil.ToFloat64(requests)
+	durationBefore := sampleCount(t, duration)
+	unmatchedBefore := testutil.ToFloat64(unmatched)
+
+	for _, path := range []string{"/metrics-test/1", "/metrics-test/2", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(requests)-requestsBefore, "requests are labelled by route template")
+	assert.Equal(t, uint64(2), sampleCount(t, duration)-durationBefore)
+	assert.Equal(t, 1.0, testutil.ToFloat64(unmatched)-unmatchedBefore)
+	assert.Equal(t, 1.0, inFlight)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues(http.MethodGet, "/metrics-test/:id")))
+}
+
+func TestMetricsMiddleware_Panic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MetricsMiddleware(), RecoveryMiddleware())
+	router.GET("/metrics-panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	requests := metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/metrics-panic", "500")
+	before := testutil.ToFloat64(requests)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics-panic", nil))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(requests)-before)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues(http.MethodGet, "/metrics-panic")))
+}
+
+func TestRateLimitMiddleware_Metrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/rate-limit-test", RateLimitMiddleware(NewRateLimiter()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rejections := metrics.RateLimitRejections.WithLabelValues("/rate-limit-test")
+	before := testutil.ToFloat64(rejections)
+
+	var limited int
+	for i := 0; i < 105; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/rate-limit-test", nil))
+		if w.Code == http.StatusTooManyRequests {
+			limited++
+		}
+	}
+
+	assert.Positive(t, limited)
+	assert.Equal(t, float64(limited), testutil.ToFloat64(rejections)-before)
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/infrastructure/logging"
+	"go-backend/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a W3C propagator and a tracer provider that keeps the
+// spans in memory.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return exporter
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := recordSpans(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(TracingMiddleware())
+	router.GET("/tracing-test/:id", func(c *gin.Context) {
+		_, span := tracing.Start(c.Request.Context(), "TestService.Get")
+		span.End()
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/tracing-test/:id/fail", func(c *gin.Context) {
+		c.Status(http.StatusInternalServerError)
+	})
+
+	t.Run("continues the incoming trace", func(t *testing.T) {
+		exporter.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/tracing-test/1", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		child, server := spans[0], spans[1]
+
+		assert.Equal(t, "GET /tracing-test/:id", server.Name)
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+		assert.True(t, server.Parent.IsRemote())
+		assert.Contains(t, server.Attributes, semconv.HTTPRoute("/tracing-test/:id"))
+		assert.Contains(t, server.Attributes, semconv.HTTPResponseStatusCode(http.StatusNoContent))
+		assert.Equal(t, codes.Unset, server.Status.Code)
+
+		assert.Equal(t, "TestService.Get", child.Name)
+		assert.Equal(t, server.SpanContext.SpanID(), child.Parent.SpanID(), "handlers see the request span")
+	})
+
+	t.Run("starts a trace without traceparent", func(t *testing.T) {
+		exporter.Reset()
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tracing-test/1", nil))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.False(t, spans[1].Parent.IsValid())
+	})
+
+	t.Run("marks server errors", func(t *testing.T) {
+		exporter.Reset()
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tracing-test/1/fail", nil))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+	})
+
+	t.Run("names unmatched routes", func(t *testing.T) {
+		exporter.Reset()
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GET unmatched", spans[0].Name)
+	})
+}
+
+func TestTracingMiddleware_LogsTraceID(t *testing.T) {
+	recordSpans(t)
+	buf := captureLogs(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(TracingMiddleware(), RequestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		logging.FromContext(c.Request.Context()).Info("handled")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logEntries(t, buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entries[0]["trace_id"])
+}
@@ -0,0 +1,122 @@
+// Package migrate applies versioned SQL migrations and records them in the
+// schema_migrations table.
+//
+// Every module keeps its migrations in internal/modules/<module>/migrations
+// as pairs of files named <version>_<name>.up.sql and <version>_<name>.down.sql.
+// The version is a UTC timestamp (YYYYMMDDHHMMSS) and orders migrations
+// across all modules.
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// VersionFormat is the time layout of migration versions.
+const VersionFormat = "20060102150405"
+
+var (
+	fileNamePattern = regexp.MustCompile(`^(\d{14})_([a-z0-9_]+)\.(up|down)\.sql$`)
+	namePattern     = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// Migration is one schema change and its rollback.
+type Migration struct {
+	Version int64
+	Name    string
+	Module  string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%d_%s (%s)", m.Version, m.Name, m.Module)
+}
+
+// Load reads the migrations in fsys, which must contain
+// <module>/migrations/<version>_<name>.(up|down).sql files, and returns them
+// ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	paths, err := fs.Glob(fsys, "*/migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	files := make(map[string]bool)
+	for _, p := range paths {
+		match := fileNamePattern.FindStringSubmatch(path.Base(p))
+		if match == nil {
+			return nil, fmt.Errorf("%s: migration files must be named <version>_<name>.up.sql or <version>_<name>.down.sql", p)
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		name, direction := match[2], match[3]
+		module := path.Dir(path.Dir(p))
+
+		body, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name, Module: module}
+			byVersion[version] = m
+		} else if m.Name != name || m.Module != module {
+			return nil, fmt.Errorf("%s: version %d is already used by %s", p, version, m)
+		}
+
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+		files[match[1]+"."+direction] = true
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		version := strconv.FormatInt(m.Version, 10)
+		if !files[version+".up"] || !files[version+".down"] {
+			return nil, fmt.Errorf("%s: both an up and a down file are required", m)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Create writes empty up and down files for a new migration to dir and
+// returns their paths.
+func Create(dir, name string, now time.Time) (string, string, error) {
+	if !namePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid migration name %q, use lowercase letters, digits and underscores", name)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", err
+	}
+
+	base := filepath.Join(dir, now.UTC().Format(VersionFormat)+"_"+name)
+	up, down := base+".up.sql", base+".down.sql"
+	for _, file := range []string{up, down} {
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", "", err
+		}
+		if err := f.Close(); err != nil {
+			return "", "", err
+		}
+	}
+	return up, down, nil
+}
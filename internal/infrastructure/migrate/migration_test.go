@@ -0,0 +1,100 @@
+package migrate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-backend/internal/infrastructure/migrate"
+	"go-backend/internal/modules"
+)
+
+func file(body string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(body)}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("orders migrations across modules by version", func(t *testing.T) {
+		migrations, err := migrate.Load(fstest.MapFS{
+			"post/migrations/20250102000000_create_posts.up.sql":   file("CREATE TABLE posts ();"),
+			"post/migrations/20250102000000_create_posts.down.sql": file("DROP TABLE posts;"),
+			"user/migrations/20250101000000_create_users.up.sql":   file("CREATE TABLE users ();"),
+			"user/migrations/20250101000000_create_users.down.sql": file("DROP TABLE users;"),
+			"user/migrations/20250103000000_add_index.up.sql":      file(""),
+			"user/migrations/20250103000000_add_index.down.sql":    file(""),
+			"user/domain/entity/user.go":                           file("package entity"),
+		})
+		require.NoError(t, err)
+		require.Len(t, migrations, 3)
+
+		assert.Equal(t, migrate.Migration{
+			Version: 20250101000000,
+			Name:    "create_users",
+			Module:  "user",
+			Up:      "CREATE TABLE users ();",
+			Down:    "DROP TABLE users;",
+		}, migrations[0])
+		assert.Equal(t, "post", migrations[1].Module)
+		assert.Equal(t, "add_index", migrations[2].Name)
+	})
+
+	t.Run("requires a down file", func(t *testing.T) {
+		_, err := migrate.Load(fstest.MapFS{
+			"user/migrations/20250101000000_create_users.up.sql": file("CREATE TABLE users ();"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a version used by two migrations", func(t *testing.T) {
+		_, err := migrate.Load(fstest.MapFS{
+			"user/migrations/20250101000000_create_users.up.sql":   file(""),
+			"user/migrations/20250101000000_create_users.down.sql": file(""),
+			"post/migrations/20250101000000_create_posts.up.sql":   file(""),
+			"post/migrations/20250101000000_create_posts.down.sql": file(""),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects badly named files", func(t *testing.T) {
+		_, err := migrate.Load(fstest.MapFS{
+			"user/migrations/create_users.sql": file(""),
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestModuleMigrations(t *testing.T) {
+	migrations, err := migrate.Load(modules.Migrations)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	// Other tables reference users, so its migration must run first.
+	assert.Equal(t, "user", migrations[0].Module)
+}
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "tool", "migrations")
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	up, down, err := migrate.Create(dir, "add_slug", now)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250102030405_add_slug.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "20250102030405_add_slug.down.sql"), down)
+
+	migrations, err := migrate.Load(os.DirFS(root))
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	assert.Equal(t, int64(20250102030405), migrations[0].Version)
+
+	_, _, err = migrate.Create(dir, "add_slug", now)
+	assert.Error(t, err, "existing files are not overwritten")
+
+	_, _, err = migrate.Create(dir, "Add Slug", now)
+	assert.Error(t, err)
+}
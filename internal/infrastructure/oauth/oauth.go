@@ -0,0 +1,83 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-backend/internal/infrastructure/config"
+	"golang.org/x/oauth2"
+)
+
+// Identity is the profile an identity provider returns for a logged in user.
+type Identity struct {
+	// Subject is the provider's stable ID for the user.
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider runs the OAuth2 authorization code flow with PKCE against one
+// identity provider.
+type Provider interface {
+	// AuthCodeURL returns the URL the user is sent to. verifier is the PKCE
+	// code verifier; only its S256 challenge is included in the URL.
+	AuthCodeURL(ctx context.Context, state, verifier string) (string, error)
+	// Exchange redeems the authorization code and returns the user's identity.
+	Exchange(ctx context.Context, code, verifier string) (*Identity, error)
+}
+
+// Config holds the client registration of a provider.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes replaces the provider's default scopes if set.
+	Scopes []string
+
+	// AuthURL, TokenURL and APIURL override the GitHub endpoints, for
+	// example for GitHub Enterprise. OIDC providers discover theirs.
+	AuthURL  string
+	TokenURL string
+	APIURL   string
+}
+
+// NewVerifier returns a random PKCE code verifier.
+func NewVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// ProvidersFromConfig returns the providers whose client ID is set, keyed by
+// the name used in the login URL: "github", "google" and the configured OIDC
+// provider name. Redirect URLs are the callback URL followed by
+// "/<name>/callback".
+func ProvidersFromConfig(cfg config.OAuthConfig) (map[string]Provider, error) {
+	providers := make(map[string]Provider)
+	callbackURL := strings.TrimRight(cfg.CallbackURL, "/")
+
+	clientConfig := func(name, clientID, clientSecret string) Config {
+		return Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  fmt.Sprintf("%s/%s/callback", callbackURL, name),
+		}
+	}
+
+	if cfg.GitHub.ClientID != "" {
+		providers["github"] = NewGitHubProvider(clientConfig("github", cfg.GitHub.ClientID, cfg.GitHub.ClientSecret))
+	}
+	if cfg.Google.ClientID != "" {
+		providers["google"] = NewOIDCProvider("https://accounts.google.com", clientConfig("google", cfg.Google.ClientID, cfg.Google.ClientSecret))
+	}
+	if oidc := cfg.OIDC; oidc.IssuerURL != "" {
+		providers[oidc.ProviderName] = NewOIDCProvider(oidc.IssuerURL, clientConfig(oidc.ProviderName, oidc.ClientID, oidc.ClientSecret))
+	}
+
+	if len(providers) > 0 && callbackURL == "" {
+		return nil, errors.New("OAUTH_CALLBACK_URL is required when an OAuth provider is configured")
+	}
+
+	return providers, nil
+}
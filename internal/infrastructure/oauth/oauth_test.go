@@ -0,0 +1,94 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/infrastructure/oauth"
+	"go-backend/internal/infrastructure/oauth/oauthtest"
+)
+
+var testUser = oauthtest.User{Subject: "user-123", Email: "dev@example.com", EmailVerified: true, Name: "Dev User"}
+
+func TestOIDCProvider(t *testing.T) {
+	stub := oauthtest.NewServer(t, "client-1")
+	provider := oauth.NewOIDCProvider(stub.URL, oauth.Config{ClientID: "client-1", ClientSecret: "secret", RedirectURL: "http://localhost/callback"})
+	ctx := context.Background()
+
+	t.Run("login with PKCE", func(t *testing.T) {
+		verifier := oauth.NewVerifier()
+		authURL, err := provider.AuthCodeURL(ctx, "state-1", verifier)
+		require.NoError(t, err)
+		assert.Contains(t, authURL, stub.URL+"/authorize")
+		assert.NotContains(t, authURL, verifier)
+
+		code, state := stub.Authorize(authURL, testUser)
+		assert.Equal(t, "state-1", state)
+
+		identity, err := provider.Exchange(ctx, code, verifier)
+		require.NoError(t, err)
+		assert.Equal(t, &oauth.Identity{Subject: "user-123", Email: "dev@example.com", EmailVerified: true, Name: "Dev User"}, identity)
+	})
+
+	t.Run("wrong verifier is rejected", func(t *testing.T) {
+		authURL, err := provider.AuthCodeURL(ctx, "state-2", oauth.NewVerifier())
+		require.NoError(t, err)
+		code, _ := stub.Authorize(authURL, testUser)
+
+		_, err = provider.Exchange(ctx, code, oauth.NewVerifier())
+		assert.Error(t, err)
+	})
+
+	t.Run("ID token for another client is rejected", func(t *testing.T) {
+		other := oauth.NewOIDCProvider(stub.URL, oauth.Config{ClientID: "client-2", RedirectURL: "http://localhost/callback"})
+		verifier := oauth.NewVerifier()
+		authURL, err := other.AuthCodeURL(ctx, "state-3", verifier)
+		require.NoError(t, err)
+		code, _ := stub.Authorize(authURL, testUser)
+
+		_, err = other.Exchange(ctx, code, verifier)
+		assert.Error(t, err)
+	})
+}
+
+func TestGitHubProvider(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.FormValue("code_verifier"))
+		writeJSON(w, map[string]interface{}{"access_token": "gh-token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gh-token", r.Header.Get("Authorization"))
+		writeJSON(w, map[string]interface{}{"id": 42, "login": "octocat", "name": ""})
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{"email": "old@example.com", "primary": false, "verified": true},
+			{"email": "octocat@example.com", "primary": true, "verified": true},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := oauth.NewGitHubProvider(oauth.Config{
+		ClientID:    "client-1",
+		RedirectURL: "http://localhost/callback",
+		AuthURL:     server.URL + "/login/oauth/authorize",
+		TokenURL:    server.URL + "/login/oauth/access_token",
+		APIURL:      server.URL,
+	})
+
+	identity, err := provider.Exchange(context.Background(), "code", oauth.NewVerifier())
+	require.NoError(t, err)
+	assert.Equal(t, &oauth.Identity{Subject: "42", Email: "octocat@example.com", EmailVerified: true, Name: "octocat"}, identity)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
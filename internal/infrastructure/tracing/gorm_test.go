@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   uint
+	Name string
+}
+
+// recordSpans installs a tracer provider that keeps the spans in memory.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+// dryRunDB returns a database that builds SQL without connecting.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(GormPlugin{}))
+	return db
+}
+
+func TestGormPlugin(t *testing.T) {
+	exporter := recordSpans(t)
+	db := dryRunDB(t)
+
+	ctx, parent := Start(context.Background(), "WidgetService.List")
+	var widgets []widget
+	db.WithContext(ctx).Where("name = ?", "a").Find(&widgets)
+	db.WithContext(ctx).Create(&widget{Name: "a"})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	query, create, service := spans[0], spans[1], spans[2]
+	assert.Equal(t, "gorm.query widgets", query.Name)
+	assert.Equal(t, "gorm.create widgets", create.Name)
+	for _, span := range []tracetest.SpanStub{query, create} {
+		assert.Equal(t, service.SpanContext.SpanID(), span.Parent.SpanID(), "queries are children of the span in the context")
+		assert.Equal(t, service.SpanContext.TraceID(), span.SpanContext.TraceID())
+		assert.Contains(t, span.Attributes, semconv.DBSystemPostgreSQL)
+		assert.Contains(t, span.Attributes, semconv.DBCollectionName("widgets"))
+	}
+	assert.Contains(t, query.Attributes, semconv.DBQueryText(`SELECT * FROM "widgets" WHERE name = $1`))
+}
+
+func TestGormPlugin_Errors(t *testing.T) {
+	exporter := recordSpans(t)
+	db := dryRunDB(t)
+
+	var w widget
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:fail", func(db *gorm.DB) {
+		db.AddError(errors.New("connection reset"))
+	}))
+	db.Find(&w)
+
+	require.NoError(t, db.Callback().Query().Replace("test:fail", func(db *gorm.DB) {
+		db.AddError(gorm.ErrRecordNotFound)
+	}))
+	db.Find(&w)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "connection reset", spans[0].Status.Description)
+	assert.Equal(t, codes.Unset, spans[1].Status.Code, "record not found is not an error")
+}
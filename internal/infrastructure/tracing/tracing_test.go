@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go-backend/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup_Stdout(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	var buf bytes.Buffer
+	shutdown, err := Setup(context.Background(), config.TracingConfig{
+		Exporter:    "stdout",
+		ServiceName: "portfolio-api",
+		SampleRatio: 1,
+	}, &buf)
+	require.NoError(t, err)
+
+	_, span := Start(context.Background(), "PostService.GetPost")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	assert.Contains(t, buf.String(), `"Name":"PostService.GetPost"`)
+	assert.Contains(t, buf.String(), "portfolio-api")
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, otel.GetTextMapPropagator().Fields())
+}
+
+func TestSetup_None(t *testing.T) {
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: "none"}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.Equal(t, previous, otel.GetTracerProvider(), "no provider is installed")
+}
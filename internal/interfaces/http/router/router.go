@@ -1,15 +1,20 @@
 package router
 
 import (
+	"go-backend/internal/infrastructure/config"
+	"go-backend/internal/infrastructure/metrics"
+	"go-backend/internal/infrastructure/middleware"
 	"go-backend/internal/modules/experience"
 	"go-backend/internal/modules/health"
 	"go-backend/internal/modules/post"
 	"go-backend/internal/modules/profile"
 	"go-backend/internal/modules/project"
 	"go-backend/internal/modules/public"
+	"go-backend/internal/modules/search"
 	"go-backend/internal/modules/socialmedia"
 	"go-backend/internal/modules/tool"
 	"go-backend/internal/modules/user"
+	"go-backend/internal/pkg/validation"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -17,11 +22,33 @@ import (
 
 type Router struct {
 	*gin.Engine
-	db *gorm.DB
+	db     *gorm.DB
+	cfg    *config.Config
+	health *health.Module
 }
 
-func NewRouter(db *gorm.DB) *Router {
-	engine := gin.Default()
+func NewRouter(db *gorm.DB, cfg *config.Config) *Router {
+	// Validation errors name fields by their JSON names, and requests use
+	// the custom rules of the validation package.
+	validation.Setup()
+
+	engine := gin.New()
+	engine.Use(
+		middleware.TracingMiddleware(),
+		middleware.RequestIDMiddleware(),
+		middleware.LoggerMiddleware(),
+	)
+
+	// Metrics wrap the recovery so that requests that panic are counted as
+	// the 500 they are answered with.
+	if cfg.Metrics.Enabled {
+		engine.Use(middleware.MetricsMiddleware())
+	}
+
+	engine.Use(
+		middleware.RecoveryMiddleware(),
+		middleware.ErrorMiddleware(),
+	)
 
 	// Enable CORS with improved configuration
 	engine.Use(func(c *gin.Context) {
@@ -37,7 +64,8 @@ func NewRouter(db *gorm.DB) *Router {
 		}
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID, traceparent, tracestate")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
 
 		// Handle preflight requests
@@ -52,6 +80,7 @@ func NewRouter(db *gorm.DB) *Router {
 	return &Router{
 		Engine: engine,
 		db:     db,
+		cfg:    cfg,
 	}
 }
 
@@ -62,15 +91,21 @@ func (r *Router) SetupRoutes() {
 		c.Next()
 	})
 
+	// Metrics are served here unless they have their own admin port
+	if r.cfg.Metrics.Enabled && r.cfg.Metrics.AdminPort == 0 {
+		r.GET(r.cfg.Metrics.Path, gin.WrapH(metrics.Handler()))
+	}
+
 	api := r.Group("/api")
 
 	// Health check module (no auth required)
-	healthModule := health.NewModule(r.db)
-	healthModule.RegisterRoutes(api)
+	r.health = health.NewModule(r.db, r.cfg)
+	r.health.RegisterRoutes(api)
 
 	// User module
-	userModule := user.NewModule(r.db)
+	userModule := user.NewModule(r.db, r.cfg)
 	userModule.RegisterRoutes(api)
+	userModule.RegisterWellKnownRoutes(r.Group("/.well-known"))
 
 	// Post module
 	postModule := post.NewModule(r.db)
@@ -96,11 +131,18 @@ func (r *Router) SetupRoutes() {
 	experienceModule := experience.NewModule(r.db)
 	experienceModule.RegisterRoutes(api)
 
+	// Search module
+	searchModule := search.NewModule(r.db)
+	searchModule.RegisterRoutes(api)
+
 	// Public API module
 	publicModule := public.NewModule(r.db)
 	publicModule.RegisterRoutes(api)
 }
 
-func (r *Router) Run(addr string) error {
-	return r.Engine.Run(addr)
+// Drain makes the health check report that the server is shutting down.
+func (r *Router) Drain() {
+	if r.health != nil {
+		r.health.Drain()
+	}
 }
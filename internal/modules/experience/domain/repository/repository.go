@@ -1,17 +1,20 @@
 package repository
 
 import (
+	"context"
 	"go-backend/internal/modules/experience/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 type ExperienceRepository interface {
-	Create(experience *entity.Experience) error
-	GetAll() ([]entity.Experience, error)
-	GetByID(id uint) (*entity.Experience, error)
-	GetByUserID(userID uint) ([]entity.Experience, error)
-	Update(experience *entity.Experience) error
-	Delete(id uint) error
+	Create(ctx context.Context, experience *entity.Experience) error
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Experience, pagination.Meta, error)
+	GetByID(ctx context.Context, id uint) (*entity.Experience, error)
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Experience, pagination.Meta, error)
+	Update(ctx context.Context, experience *entity.Experience) error
+	Delete(ctx context.Context, id uint) error
 }
 
 type experienceRepository struct {
@@ -24,37 +27,33 @@ func NewExperienceRepository(db *gorm.DB) ExperienceRepository {
 	}
 }
 
-func (r *experienceRepository) Create(experience *entity.Experience) error {
-	result := r.db.Create(experience)
+func (r *experienceRepository) Create(ctx context.Context, experience *entity.Experience) error {
+	result := r.db.WithContext(ctx).Create(experience)
 	return result.Error
 }
 
-func (r *experienceRepository) GetAll() ([]entity.Experience, error) {
-	var experiences []entity.Experience
-	result := r.db.Find(&experiences)
-	return experiences, result.Error
+func (r *experienceRepository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Experience, pagination.Meta, error) {
+	return pagination.Find[entity.Experience](r.db.WithContext(ctx).Scopes(f.Scope), params)
 }
 
-func (r *experienceRepository) GetByID(id uint) (*entity.Experience, error) {
+func (r *experienceRepository) GetByID(ctx context.Context, id uint) (*entity.Experience, error) {
 	var experience entity.Experience
-	result := r.db.First(&experience, id)
+	result := r.db.WithContext(ctx).First(&experience, id)
 	return &experience, result.Error
 }
 
-func (r *experienceRepository) GetByUserID(userID uint) ([]entity.Experience, error) {
-	var experiences []entity.Experience
-	result := r.db.Where("user_id = ?", userID).Find(&experiences)
-	return experiences, result.Error
+func (r *experienceRepository) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Experience, pagination.Meta, error) {
+	return pagination.Find[entity.Experience](r.db.WithContext(ctx).Where("user_id = ?", userID), params)
 }
 
 
 
-func (r *experienceRepository) Update(experience *entity.Experience) error {
-	result := r.db.Save(experience)
+func (r *experienceRepository) Update(ctx context.Context, experience *entity.Experience) error {
+	result := r.db.WithContext(ctx).Save(experience)
 	return result.Error
 }
 
-func (r *experienceRepository) Delete(id uint) error {
-	result := r.db.Delete(&entity.Experience{}, id)
+func (r *experienceRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&entity.Experience{}, id)
 	return result.Error
 }
@@ -1,17 +1,23 @@
 package service
 
 import (
+	"context"
+	"go-backend/internal/infrastructure/tracing"
 	"go-backend/internal/modules/experience/domain/repository"
 	"go-backend/internal/modules/experience/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type ExperienceService interface {
-	Create(request *dto.CreateExperienceRequest, userID uint) (*dto.ExperienceResponse, error)
-	GetAll() ([]*dto.ExperienceResponse, error)
-	GetByID(id uint) (*dto.ExperienceResponse, error)
-	GetByUserID(userID uint) ([]*dto.ExperienceResponse, error)
-	Update(id uint, request *dto.UpdateExperienceRequest) (*dto.ExperienceResponse, error)
-	Delete(id uint) error
+	Create(ctx context.Context, request *dto.CreateExperienceRequest, userID uint) (*dto.ExperienceResponse, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]*dto.ExperienceResponse, pagination.Meta, error)
+	GetByID(ctx context.Context, id uint) (*dto.ExperienceResponse, error)
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]*dto.ExperienceResponse, pagination.Meta, error)
+	Update(ctx context.Context, id uint, request *dto.UpdateExperienceRequest, userID uint) (*dto.ExperienceResponse, error)
+	Delete(ctx context.Context, id, userID uint) error
 }
 
 type experienceService struct {
@@ -24,51 +30,78 @@ func NewExperienceService(repo repository.ExperienceRepository) ExperienceServic
 	}
 }
 
-func (s *experienceService) Create(request *dto.CreateExperienceRequest, userID uint) (*dto.ExperienceResponse, error) {
+func (s *experienceService) Create(ctx context.Context, request *dto.CreateExperienceRequest, userID uint) (*dto.ExperienceResponse, error) {
+	ctx, span := tracing.Start(ctx, "ExperienceService.Create")
+	defer span.End()
+
 	experience, err := request.ToEntity(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Create(experience); err != nil {
+	if err := s.repo.Create(ctx, experience); err != nil {
 		return nil, err
 	}
 
 	return dto.ToResponse(experience)
 }
 
-func (s *experienceService) GetAll() ([]*dto.ExperienceResponse, error) {
-	experiences, err := s.repo.GetAll()
+func (s *experienceService) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]*dto.ExperienceResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ExperienceService.GetAll")
+	defer span.End()
+
+	experiences, meta, err := s.repo.GetAll(ctx, f, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
-	return dto.ToResponseList(experiences)
+	response, err := dto.ToResponseList(experiences)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	return response, meta, nil
 }
 
-func (s *experienceService) GetByID(id uint) (*dto.ExperienceResponse, error) {
-	experience, err := s.repo.GetByID(id)
+func (s *experienceService) GetByID(ctx context.Context, id uint) (*dto.ExperienceResponse, error) {
+	ctx, span := tracing.Start(ctx, "ExperienceService.GetByID")
+	defer span.End()
+
+	experience, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "experience not found")
 	}
 
 	return dto.ToResponse(experience)
 }
 
-func (s *experienceService) GetByUserID(userID uint) ([]*dto.ExperienceResponse, error) {
-	experiences, err := s.repo.GetByUserID(userID)
+func (s *experienceService) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]*dto.ExperienceResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ExperienceService.GetByUserID")
+	defer span.End()
+
+	experiences, meta, err := s.repo.GetByUserID(ctx, userID, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
-	return dto.ToResponseList(experiences)
+	response, err := dto.ToResponseList(experiences)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	return response, meta, nil
 }
 
 
 
-func (s *experienceService) Update(id uint, request *dto.UpdateExperienceRequest) (*dto.ExperienceResponse, error) {
-	experience, err := s.repo.GetByID(id)
+func (s *experienceService) Update(ctx context.Context, id uint, request *dto.UpdateExperienceRequest, userID uint) (*dto.ExperienceResponse, error) {
+	ctx, span := tracing.Start(ctx, "ExperienceService.Update")
+	defer span.End()
+
+	experience, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		return nil, apperror.NotFoundOr(err, "experience not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, experience.UserID, "update", "experiences"); err != nil {
 		return nil, err
 	}
 
@@ -76,13 +109,31 @@ func (s *experienceService) Update(id uint, request *dto.UpdateExperienceRequest
 		return nil, err
 	}
 
-	if err := s.repo.Update(experience); err != nil {
+	// The request may change only one of the dates, so the binding rule
+	// cannot compare them
+	if experience.EndDate != nil && experience.EndDate.Before(experience.StartDate) {
+		return nil, apperror.Validation(apperror.Field("end_date", "notbefore", "start_date"))
+	}
+
+	if err := s.repo.Update(ctx, experience); err != nil {
 		return nil, err
 	}
 
 	return dto.ToResponse(experience)
 }
 
-func (s *experienceService) Delete(id uint) error {
-	return s.repo.Delete(id)
+func (s *experienceService) Delete(ctx context.Context, id, userID uint) error {
+	ctx, span := tracing.Start(ctx, "ExperienceService.Delete")
+	defer span.End()
+
+	experience, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return apperror.NotFoundOr(err, "experience not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, experience.UserID, "delete", "experiences"); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
 }
@@ -3,29 +3,51 @@ package dto
 import (
 	"encoding/json"
 	"go-backend/internal/modules/experience/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 	"time"
 )
 
+// ListOptions are the sorts of experience lists, latest start first by
+// default.
+var ListOptions = pagination.Options{
+	Sortable:    []string{"id", "title", "company", "start_date", "created_at", "updated_at"},
+	DefaultSort: "-start_date",
+}
+
+// FilterFields are the fields that experience lists can be filtered by.
+var FilterFields = filter.Fields{
+	"id":         {Column: "id", Type: filter.Integer},
+	"title":      {Column: "title", Type: filter.String},
+	"company":    {Column: "company", Type: filter.String},
+	"location":   {Column: "location", Type: filter.String},
+	"start_date": {Column: "start_date", Type: filter.Time},
+	"end_date":   {Column: "end_date", Type: filter.Time},
+	"user_id":    {Column: "user_id", Type: filter.Integer},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+}
+
 // CreateExperienceRequest represents the request for creating a new experience
 type CreateExperienceRequest struct {
-	Title       string    `json:"title" binding:"required"`
-	Company     string    `json:"company" binding:"required"`
-	Location    string    `json:"location"`
+	Title       string    `json:"title" binding:"required,max=255"`
+	Company     string    `json:"company" binding:"required,max=255"`
+	Location    string    `json:"location" binding:"max=255"`
 	StartDate   time.Time `json:"start_date" binding:"required"`
-	EndDate     *time.Time `json:"end_date"`
-	Description string    `json:"description"`
-	TechStack   []string  `json:"tech_stack"`
+	EndDate     *time.Time `json:"end_date" binding:"omitempty,notbefore=start_date"`
+	Description string    `json:"description" binding:"max=5000"`
+	TechStack   []string  `json:"tech_stack" binding:"max=50,dive,max=100"`
 }
 
 // UpdateExperienceRequest represents the request for updating an experience
 type UpdateExperienceRequest struct {
-	Title       string    `json:"title"`
-	Company     string    `json:"company"`
-	Location    string    `json:"location"`
+	Title       string    `json:"title" binding:"omitempty,max=255"`
+	Company     string    `json:"company" binding:"omitempty,max=255"`
+	Location    string    `json:"location" binding:"max=255"`
 	StartDate   time.Time `json:"start_date"`
-	EndDate     *time.Time `json:"end_date"`
-	Description string    `json:"description"`
-	TechStack   []string  `json:"tech_stack"`
+	EndDate     *time.Time `json:"end_date" binding:"omitempty,notbefore=start_date"`
+	Description string    `json:"description" binding:"max=5000"`
+	TechStack   []string  `json:"tech_stack" binding:"max=50,dive,max=100"`
 }
 
 // ExperienceResponse represents the response for an experience
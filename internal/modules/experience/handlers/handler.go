@@ -7,6 +7,10 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 )
 
 type ExperienceHandler struct {
@@ -19,159 +23,138 @@ func NewExperienceHandler(service service.ExperienceService) *ExperienceHandler
 	}
 }
 
-// formatResponse formats the response to a standard format
-func formatResponse(statusCode int, message string, data interface{}, error string) gin.H {
-	return gin.H{
-		"status":  statusCode,
-		"message": message,
-		"data":    data,
-		"error":   error,
-	}
-}
-
 // Create handles the creation of a new experience
 func (h *ExperienceHandler) Create(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
 	var request dto.CreateExperienceRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request body", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	response, err := h.service.Create(&request, userID.(uint))
+	resp, err := h.service.Create(c.Request.Context(), &request, userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to create experience", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, formatResponse(http.StatusCreated, "Experience created successfully", response, ""))
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "Experience created successfully", resp))
 }
 
 // GetAll handles retrieving all experiences
 func (h *ExperienceHandler) GetAll(c *gin.Context) {
-	response, err := h.service.GetAll()
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve experiences", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Experiences retrieved successfully", response, ""))
+	resp, meta, err := h.service.GetAll(c.Request.Context(), f, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Experiences retrieved successfully", resp, meta))
 }
 
 // GetByID handles retrieving an experience by ID
 func (h *ExperienceHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	response, err := h.service.GetByID(uint(id))
+	resp, err := h.service.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Experience not found", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Experience retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Experience retrieved successfully", resp))
 }
 
 // GetByUserID handles retrieving experiences by user ID
 func (h *ExperienceHandler) GetByUserID(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	response, err := h.service.GetByUserID(userID.(uint))
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve user's experiences", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User's experiences retrieved successfully", response, ""))
-}
-
+	resp, meta, err := h.service.GetByUserID(c.Request.Context(), userID.(uint), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "User's experiences retrieved successfully", resp, meta))
+}
 
 // Update handles updating an experience
 func (h *ExperienceHandler) Update(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
-		return
-	}
-
-	// Get the experience to verify ownership
-	experience, err := h.service.GetByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Experience not found", nil, err.Error()))
-		return
-	}
-
-	// Verify that the user owns this experience
-	if experience.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, formatResponse(http.StatusForbidden, "Not authorized to update this experience", nil, "You can only update your own experiences"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var request dto.UpdateExperienceRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request body", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	response, err := h.service.Update(uint(id), &request)
+	resp, err := h.service.Update(c.Request.Context(), uint(id), &request, userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to update experience", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Experience updated successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Experience updated successfully", resp))
 }
 
 // Delete handles deleting an experience
 func (h *ExperienceHandler) Delete(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
-		return
-	}
-
-	// Get the experience to verify ownership
-	experience, err := h.service.GetByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Experience not found", nil, err.Error()))
-		return
-	}
-
-	// Verify that the user owns this experience
-	if experience.UserID != userID.(uint) {
-		c.JSON(http.StatusForbidden, formatResponse(http.StatusForbidden, "Not authorized to delete this experience", nil, "You can only delete your own experiences"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	if err := h.service.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to delete experience", nil, err.Error()))
+	if err := h.service.Delete(c.Request.Context(), uint(id), userID.(uint)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Experience deleted successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Experience deleted successfully", nil))
 }
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS experiences (
+    id bigserial PRIMARY KEY,
+    title text NOT NULL,
+    company text NOT NULL,
+    location text,
+    start_date timestamptz,
+    end_date timestamptz,
+    description text,
+    tech_stack json,
+    user_id bigint NOT NULL CONSTRAINT fk_experiences_user REFERENCES users (id),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_experiences_deleted_at ON experiences (deleted_at);
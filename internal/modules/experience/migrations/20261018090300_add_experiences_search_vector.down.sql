@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_experiences_search_vector;
+ALTER TABLE experiences DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,8 @@
+ALTER TABLE experiences ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(company, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'C') ||
+        setweight(to_tsvector('simple', coalesce(tech_stack::text, '')), 'C')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_experiences_search_vector ON experiences USING gin (search_vector);
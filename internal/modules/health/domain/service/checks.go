@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go-backend/internal/infrastructure/migrate"
+	"gorm.io/gorm"
+)
+
+// DatabaseCheck pings the database.
+func DatabaseCheck(db *gorm.DB) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+}
+
+// MigrationsCheck fails while migrations are pending, for example while
+// another replica is still applying them.
+func MigrationsCheck(migrator *migrate.Migrator) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migrations", len(pending))
+		}
+		return nil
+	})
+}
+
+// DiskSpaceCheck fails when the file system holding path has less than
+// minFreeBytes available.
+func DiskSpaceCheck(path string, minFreeBytes uint64) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		free, err := freeDiskSpace(path)
+		if err != nil {
+			return err
+		}
+		if free < minFreeBytes {
+			return fmt.Errorf("%d MB free in %s, need %d MB", free>>20, path, minFreeBytes>>20)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-backend/internal/modules/health/dto"
+)
+
+const (
+	CheckUp   = "up"
+	CheckDown = "down"
+)
+
+// Checker reports whether a dependency the service needs is usable.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to a Checker.
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type HealthService interface {
+	// Register adds a readiness check. Registering a name again replaces
+	// the previous check.
+	Register(name string, checker Checker)
+	// Ready runs every check concurrently and reports whether all passed.
+	Ready(ctx context.Context) (bool, map[string]dto.CheckResult)
+	// Drain marks the service as shutting down.
+	Drain()
+	IsDraining() bool
+}
+
+type healthService struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	checkers map[string]Checker
+	draining atomic.Bool
+}
+
+// NewHealthService returns a HealthService that gives each check up to
+// timeout to finish.
+func NewHealthService(timeout time.Duration) HealthService {
+	return &healthService{
+		timeout:  timeout,
+		checkers: make(map[string]Checker),
+	}
+}
+
+func (s *healthService) Register(name string, checker Checker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkers[name] = checker
+}
+
+func (s *healthService) Ready(ctx context.Context) (bool, map[string]dto.CheckResult) {
+	s.mu.RLock()
+	checkers := make(map[string]Checker, len(s.checkers))
+	for name, checker := range s.checkers {
+		checkers[name] = checker
+	}
+	s.mu.RUnlock()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		ready   = true
+		results = make(map[string]dto.CheckResult, len(checkers))
+	)
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker Checker) {
+			defer wg.Done()
+			result := s.run(ctx, checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = result
+			if result.Status != CheckUp {
+				ready = false
+			}
+		}(name, checker)
+	}
+	wg.Wait()
+
+	return ready, results
+}
+
+// run runs a single check. A check that ignores its context still counts as
+// failed once the timeout has passed.
+func (s *healthService) run(ctx context.Context, checker Checker) dto.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := dto.CheckResult{
+		Status:    CheckUp,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = CheckDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (s *healthService) Drain() {
+	s.draining.Store(true)
+}
+
+func (s *healthService) IsDraining() bool {
+	return s.draining.Load()
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type HealthResponse struct {
+	Status    string                 `json:"status"`
+	Timestamp time.Time              `json:"timestamp"`
+	Version   string                 `json:"version"`
+	Commit    string                 `json:"commit,omitempty"`
+	BuildDate string                 `json:"build_date,omitempty"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
@@ -4,26 +4,63 @@ import (
 	"net/http"
 	"time"
 
+	"go-backend/internal/infrastructure/buildinfo"
+	"go-backend/internal/modules/health/domain/service"
+	"go-backend/internal/modules/health/dto"
+
 	"github.com/gin-gonic/gin"
 )
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	service service.HealthService
+}
 
-type HealthResponse struct {
-	Status    string    `json:"status"`
-	Timestamp time.Time `json:"timestamp"`
-	Version   string    `json:"version"`
+func NewHealthHandler(service service.HealthService) *HealthHandler {
+	return &HealthHandler{service: service}
 }
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func newResponse(status string) dto.HealthResponse {
+	info := buildinfo.Get()
+	return dto.HealthResponse{
+		Status:    status,
+		Timestamp: time.Now(),
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildDate: info.BuildDate,
+	}
 }
 
+// Check reports "healthy", or "draining" with 503 Service Unavailable while
+// the server shuts down. It does not check dependencies; use Ready for that.
 func (h *HealthHandler) Check(c *gin.Context) {
-	response := HealthResponse{
-		Status:    "healthy",
-		Timestamp: time.Now(),
-		Version:   "1.0.0", // You can make this configurable through environment variables
+	if h.service.IsDraining() {
+		c.JSON(http.StatusServiceUnavailable, newResponse("draining"))
+		return
+	}
+	c.JSON(http.StatusOK, newResponse("healthy"))
+}
+
+// Live reports that the process is running. It never checks dependencies so
+// that a failing database does not get the container restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, newResponse("alive"))
+}
+
+// Ready runs the registered checks and reports 503 Service Unavailable when
+// any of them fails or the server is shutting down.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if h.service.IsDraining() {
+		c.JSON(http.StatusServiceUnavailable, newResponse("draining"))
+		return
+	}
+
+	ready, checks := h.service.Ready(c.Request.Context())
+	response := newResponse("ready")
+	response.Checks = checks
+	if !ready {
+		response.Status = "not_ready"
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
 	}
 	c.JSON(http.StatusOK, response)
 }
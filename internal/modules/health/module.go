@@ -1,6 +1,11 @@
 package health
 
 import (
+	"time"
+
+	"go-backend/internal/infrastructure/config"
+	"go-backend/internal/infrastructure/database"
+	"go-backend/internal/modules/health/domain/service"
 	"go-backend/internal/modules/health/handlers"
 
 	"github.com/gin-gonic/gin"
@@ -8,17 +13,50 @@ import (
 )
 
 type Module struct {
-	db *gorm.DB
+	db      *gorm.DB
+	service service.HealthService
+	handler *handlers.HealthHandler
 }
 
-func NewModule(db *gorm.DB) *Module {
-	return &Module{db: db}
+// NewModule returns the health module with the database, migration and,
+// when cfg.Health.DiskPath is set, disk space checks registered.
+func NewModule(db *gorm.DB, cfg *config.Config) *Module {
+	healthService := service.NewHealthService(time.Duration(cfg.Health.CheckTimeout))
+
+	healthService.Register("database", service.DatabaseCheck(db))
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		panic("Failed to load migrations: " + err.Error())
+	}
+	healthService.Register("migrations", service.MigrationsCheck(migrator))
+
+	if cfg.Health.DiskPath != "" {
+		minFree := uint64(cfg.Health.DiskMinFreeMB) << 20
+		healthService.Register("disk", service.DiskSpaceCheck(cfg.Health.DiskPath, minFree))
+	}
+
+	return &Module{
+		db:      db,
+		service: healthService,
+		handler: handlers.NewHealthHandler(healthService),
+	}
 }
 
 // RegisterRoutes registers the health check routes
 func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
-	handler := handlers.NewHealthHandler()
-
 	// Register directly to the router, not in a group
-	router.GET("/health", handler.Check)
+	router.GET("/health", m.handler.Check)
+	router.GET("/health/live", m.handler.Live)
+	router.GET("/health/ready", m.handler.Ready)
+}
+
+// RegisterCheck adds a readiness check reported by /health/ready.
+func (m *Module) RegisterCheck(name string, checker service.Checker) {
+	m.service.Register(name, checker)
+}
+
+// Drain marks the service as shutting down in the health checks.
+func (m *Module) Drain() {
+	m.service.Drain()
 }
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-backend/internal/modules/health/domain/service"
+	"go-backend/internal/modules/health/dto"
+	"go-backend/internal/modules/health/handlers"
+)
+
+func setupHealthRouter(healthService service.HealthService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewHealthHandler(healthService)
+	r := gin.New()
+	r.GET("/health", handler.Check)
+	r.GET("/health/live", handler.Live)
+	r.GET("/health/ready", handler.Ready)
+	return r
+}
+
+func get(t *testing.T, r *gin.Engine, path string) (int, dto.HealthResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var resp dto.HealthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func TestHealthHandler_Check(t *testing.T) {
+	healthService := service.NewHealthService(time.Second)
+	r := setupHealthRouter(healthService)
+
+	code, resp := get(t, r, "/health")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "healthy", resp.Status)
+	assert.NotEmpty(t, resp.Version)
+
+	healthService.Drain()
+
+	code, resp = get(t, r, "/health")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "draining", resp.Status)
+}
+
+func TestHealthHandler_Live(t *testing.T) {
+	healthService := service.NewHealthService(time.Second)
+	healthService.Register("database", service.CheckerFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}))
+	r := setupHealthRouter(healthService)
+
+	code, resp := get(t, r, "/health/live")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "alive", resp.Status)
+	assert.Empty(t, resp.Checks)
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	var dbErr error
+	healthService := service.NewHealthService(time.Second)
+	healthService.Register("database", service.CheckerFunc(func(ctx context.Context) error {
+		return dbErr
+	}))
+	r := setupHealthRouter(healthService)
+
+	code, resp := get(t, r, "/health/ready")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ready", resp.Status)
+	assert.Equal(t, service.CheckUp, resp.Checks["database"].Status)
+
+	dbErr = errors.New("connection refused")
+
+	code, resp = get(t, r, "/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "not_ready", resp.Status)
+	assert.Equal(t, service.CheckDown, resp.Checks["database"].Status)
+	assert.Equal(t, "connection refused", resp.Checks["database"].Error)
+
+	healthService.Drain()
+
+	code, resp = get(t, r, "/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "draining", resp.Status)
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-backend/internal/modules/health/domain/service"
+)
+
+func TestHealthService_Ready(t *testing.T) {
+	t.Run("no checks", func(t *testing.T) {
+		ready, checks := service.NewHealthService(time.Second).Ready(context.Background())
+		assert.True(t, ready)
+		assert.Empty(t, checks)
+	})
+
+	t.Run("reports every check", func(t *testing.T) {
+		healthService := service.NewHealthService(time.Second)
+		healthService.Register("up", service.CheckerFunc(func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}))
+		healthService.Register("down", service.CheckerFunc(func(ctx context.Context) error {
+			return errors.New("broken")
+		}))
+
+		ready, checks := healthService.Ready(context.Background())
+		assert.False(t, ready)
+		assert.Len(t, checks, 2)
+		assert.Equal(t, service.CheckUp, checks["up"].Status)
+		assert.GreaterOrEqual(t, checks["up"].LatencyMS, 10.0)
+		assert.Empty(t, checks["up"].Error)
+		assert.Equal(t, service.CheckDown, checks["down"].Status)
+		assert.Equal(t, "broken", checks["down"].Error)
+	})
+
+	t.Run("times out slow checks", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		healthService := service.NewHealthService(20 * time.Millisecond)
+		healthService.Register("slow", service.CheckerFunc(func(ctx context.Context) error {
+			<-block
+			return nil
+		}))
+
+		start := time.Now()
+		ready, checks := healthService.Ready(context.Background())
+		assert.Less(t, time.Since(start), time.Second)
+		assert.False(t, ready)
+		assert.Equal(t, service.CheckDown, checks["slow"].Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), checks["slow"].Error)
+	})
+
+	t.Run("register replaces a check", func(t *testing.T) {
+		healthService := service.NewHealthService(time.Second)
+		healthService.Register("db", service.CheckerFunc(func(ctx context.Context) error {
+			return errors.New("broken")
+		}))
+		healthService.Register("db", service.CheckerFunc(func(ctx context.Context) error {
+			return nil
+		}))
+
+		ready, checks := healthService.Ready(context.Background())
+		assert.True(t, ready)
+		assert.Len(t, checks, 1)
+	})
+}
+
+func TestDiskSpaceCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, service.DiskSpaceCheck(dir, 0).Check(context.Background()))
+	assert.Error(t, service.DiskSpaceCheck(dir, 1<<62).Check(context.Background()))
+	assert.Error(t, service.DiskSpaceCheck(dir+"/missing", 0).Check(context.Background()))
+}
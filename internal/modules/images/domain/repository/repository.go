@@ -1,17 +1,18 @@
 package repository
 
 import (
+	"context"
 	"go-backend/internal/modules/images/domain/entity"
 
 	"gorm.io/gorm"
 )
 
 type ImagesRepository interface {
-	Create(image *entity.Images) error
-	GetByID(id uint) (*entity.Images, error)
-	Update(image *entity.Images) error
-	Delete(id uint) error
-	GetByPostID(postID uint) ([]entity.Images, error)
+	Create(ctx context.Context, image *entity.Images) error
+	GetByID(ctx context.Context, id uint) (*entity.Images, error)
+	Update(ctx context.Context, image *entity.Images) error
+	Delete(ctx context.Context, id uint) error
+	GetByPostID(ctx context.Context, postID uint) ([]entity.Images, error)
 }
 
 type imagesRepository struct {
@@ -22,29 +23,29 @@ func NewImagesRepository(db *gorm.DB) ImagesRepository {
 	return &imagesRepository{db: db}
 }
 
-func (r *imagesRepository) Create(image *entity.Images) error {
-	return r.db.Create(image).Error
+func (r *imagesRepository) Create(ctx context.Context, image *entity.Images) error {
+	return r.db.WithContext(ctx).Create(image).Error
 }
 
-func (r *imagesRepository) GetByID(id uint) (*entity.Images, error) {
+func (r *imagesRepository) GetByID(ctx context.Context, id uint) (*entity.Images, error) {
 	var image entity.Images
-	if err := r.db.First(&image, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&image, id).Error; err != nil {
 		return nil, err
 	}
 	return &image, nil
 }
 
-func (r *imagesRepository) Update(image *entity.Images) error {
-	return r.db.Save(image).Error
+func (r *imagesRepository) Update(ctx context.Context, image *entity.Images) error {
+	return r.db.WithContext(ctx).Save(image).Error
 }
 
-func (r *imagesRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Images{}, id).Error
+func (r *imagesRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.Images{}, id).Error
 }
 
-func (r *imagesRepository) GetByPostID(postID uint) ([]entity.Images, error) {
+func (r *imagesRepository) GetByPostID(ctx context.Context, postID uint) ([]entity.Images, error) {
 	var images []entity.Images
-	if err := r.db.Where("post_id = ?", postID).Find(&images).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("post_id = ?", postID).Find(&images).Error; err != nil {
 		return nil, err
 	}
 	return images, nil
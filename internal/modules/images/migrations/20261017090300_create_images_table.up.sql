@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS images (
+    id bigserial PRIMARY KEY,
+    url text NOT NULL,
+    post_id bigint DEFAULT NULL CONSTRAINT fk_posts_images REFERENCES posts (id),
+    project_id bigint DEFAULT NULL CONSTRAINT fk_projects_images REFERENCES projects (id),
+    user_id bigint NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_images_deleted_at ON images (deleted_at);
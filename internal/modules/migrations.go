@@ -0,0 +1,10 @@
+// Package modules embeds the SQL migrations of every module.
+package modules
+
+import "embed"
+
+// Migrations holds internal/modules/<module>/migrations/*.sql. Migrations of
+// a new module are picked up the next time the binary is built.
+//
+//go:embed */migrations/*.sql
+var Migrations embed.FS
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"go-backend/internal/infrastructure/tracing"
+	experienceDTO "go-backend/internal/modules/experience/dto"
 	experienceService "go-backend/internal/modules/experience/domain/service"
 	postDTO "go-backend/internal/modules/post/dto"
 	postService "go-backend/internal/modules/post/domain/service"
@@ -13,11 +16,14 @@ import (
 	toolDTO "go-backend/internal/modules/tool/dto"
 	toolService "go-backend/internal/modules/tool/domain/service"
 	"go-backend/internal/modules/portfolio/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type PortfolioService interface {
-	GetUserPortfolio(userID uint) (*dto.PortfolioResponse, error)
-	GetAllPortfolios() ([]*dto.PortfolioSummaryResponse, error)
+	GetUserPortfolio(ctx context.Context, userID uint) (*dto.PortfolioResponse, error)
+	GetAllPortfolios(ctx context.Context, f filter.Filter, params pagination.Params) ([]*dto.PortfolioSummaryResponse, pagination.Meta, error)
 }
 
 type portfolioService struct {
@@ -47,23 +53,29 @@ func NewPortfolioService(
 	}
 }
 
-func (s *portfolioService) GetUserPortfolio(userID uint) (*dto.PortfolioResponse, error) {
+func (s *portfolioService) GetUserPortfolio(ctx context.Context, userID uint) (*dto.PortfolioResponse, error) {
+	ctx, span := tracing.Start(ctx, "PortfolioService.GetUserPortfolio")
+	defer span.End()
+
 	// Get user profile
-	profiles, err := s.profileService.GetByUserID(userID)
+	profiles, err := s.profileService.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Check if profile exists
 	if len(profiles) == 0 {
-		return nil, fmt.Errorf("profile not found for user ID %d", userID)
+		return nil, apperror.NotFound(fmt.Sprintf("profile not found for user ID %d", userID))
 	}
 
 	// Use the first profile
 	profile := &profiles[0]
 
+	// A portfolio shows the first page of each list; the lists themselves
+	// are paged at their own endpoints
+
 	// Get user posts
-	postsList, err := s.postService.ListByUserID(userID, 1, 100) // Using page 1 with 100 items per page
+	postsList, _, err := s.postService.ListByUserID(ctx, userID, pagination.FirstPage(postDTO.ListOptions))
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +87,7 @@ func (s *portfolioService) GetUserPortfolio(userID uint) (*dto.PortfolioResponse
 	}
 
 	// Get user projects
-	projectsList, err := s.projectService.GetByUserID(userID)
+	projectsList, _, err := s.projectService.GetByUserID(ctx, userID, pagination.FirstPage(projectDTO.ListOptions))
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +99,7 @@ func (s *portfolioService) GetUserPortfolio(userID uint) (*dto.PortfolioResponse
 	}
 
 	// Get user social media
-	socialMediaList, err := s.socialMediaService.GetByUserID(userID)
+	socialMediaList, _, err := s.socialMediaService.GetByUserID(ctx, userID, pagination.FirstPage(socialMediaDTO.ListOptions))
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +111,7 @@ func (s *portfolioService) GetUserPortfolio(userID uint) (*dto.PortfolioResponse
 	}
 
 	// Get user tools
-	toolsList, err := s.toolService.GetByUserID(userID)
+	toolsList, _, err := s.toolService.GetByUserID(ctx, userID, pagination.FirstPage(toolDTO.ListOptions))
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +123,7 @@ func (s *portfolioService) GetUserPortfolio(userID uint) (*dto.PortfolioResponse
 	}
 
 	// Get user experiences
-	experiences, err := s.experienceService.GetByUserID(userID)
+	experiences, _, err := s.experienceService.GetByUserID(ctx, userID, pagination.FirstPage(experienceDTO.ListOptions))
 	if err != nil {
 		return nil, err
 	}
@@ -126,14 +138,17 @@ func (s *portfolioService) GetUserPortfolio(userID uint) (*dto.PortfolioResponse
 	}, nil
 }
 
-func (s *portfolioService) GetAllPortfolios() ([]*dto.PortfolioSummaryResponse, error) {
-	// Get all profiles
-	profiles, err := s.profileService.GetAll()
+func (s *portfolioService) GetAllPortfolios(ctx context.Context, f filter.Filter, params pagination.Params) ([]*dto.PortfolioSummaryResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "PortfolioService.GetAllPortfolios")
+	defer span.End()
+
+	// Get a page of profiles
+	profiles, meta, err := s.profileService.GetAll(ctx, f, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
-	var portfolioSummaries []*dto.PortfolioSummaryResponse
+	portfolioSummaries := make([]*dto.PortfolioSummaryResponse, 0, len(profiles))
 	for _, profile := range profiles {
 		portfolioSummaries = append(portfolioSummaries, &dto.PortfolioSummaryResponse{
 			UserID:      profile.UserID,
@@ -143,5 +158,5 @@ func (s *portfolioService) GetAllPortfolios() ([]*dto.PortfolioSummaryResponse,
 		})
 	}
 
-	return portfolioSummaries, nil
+	return portfolioSummaries, meta, nil
 }
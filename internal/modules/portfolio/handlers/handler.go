@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"go-backend/internal/modules/portfolio/domain/service"
+	profileDTO "go-backend/internal/modules/profile/dto"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 )
 
 type PortfolioHandler struct {
@@ -18,40 +23,41 @@ func NewPortfolioHandler(service service.PortfolioService) *PortfolioHandler {
 	}
 }
 
-// formatResponse formats the response to a standard format
-func formatResponse(statusCode int, message string, data interface{}, error string) gin.H {
-	return gin.H{
-		"status":  statusCode,
-		"message": message,
-		"data":    data,
-		"error":   error,
-	}
-}
-
 // GetUserPortfolio handles retrieving a complete portfolio for a specific user
 func (h *PortfolioHandler) GetUserPortfolio(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid User ID", nil, "Invalid User ID format"))
+		c.Error(apperror.BadRequest("Invalid User ID format"))
 		return
 	}
 
-	portfolio, err := h.service.GetUserPortfolio(uint(userID))
+	portfolio, err := h.service.GetUserPortfolio(c.Request.Context(), uint(userID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve user portfolio", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User portfolio retrieved successfully", portfolio, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User portfolio retrieved successfully", portfolio))
 }
 
 // GetAllPortfolios handles retrieving summaries of all user portfolios
 func (h *PortfolioHandler) GetAllPortfolios(c *gin.Context) {
-	portfolios, err := h.service.GetAllPortfolios()
+	params, err := pagination.Parse(c.Request.URL.Query(), profileDTO.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), profileDTO.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	portfolios, meta, err := h.service.GetAllPortfolios(c.Request.Context(), f, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve portfolios", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Portfolios retrieved successfully", portfolios, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Portfolios retrieved successfully", portfolios, meta))
 }
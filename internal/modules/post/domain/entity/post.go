@@ -5,6 +5,8 @@ import (
 
 	imageEntity "go-backend/internal/modules/images/domain/entity"
 	userEntity "go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/seo"
 
 	"gorm.io/gorm"
 )
@@ -12,6 +14,7 @@ import (
 type Post struct {
 	ID        uint              `json:"id" gorm:"primaryKey"`
 	Title     string            `json:"title" gorm:"not null"`
+	Slug      string            `json:"slug" gorm:"not null"`
 	Content   string            `json:"content" gorm:"not null"`
 	UserID    uint              `json:"user_id" gorm:"not null"`
 	User      userEntity.User   `json:"user" gorm:"foreignKey:UserID"`
@@ -19,4 +22,21 @@ type Post struct {
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
 	DeletedAt gorm.DeletedAt    `json:"-" gorm:"index"`
+
+	publishing.State
+	SEO seo.Fields `json:"seo" gorm:"embedded"`
+}
+
+// SEOMeta returns the SEO metadata of the post, with the fields its owner
+// left empty derived from its title, content and first image.
+func (p *Post) SEOMeta() seo.Fields {
+	defaults := seo.Fields{
+		MetaTitle:       p.Title,
+		MetaDescription: seo.Description(p.Content),
+		CanonicalURL:    seo.CanonicalURL("posts", p.Slug),
+	}
+	if len(p.Images) > 0 {
+		defaults.OGImage = p.Images[0].URL
+	}
+	return p.SEO.WithDefaults(defaults)
 }
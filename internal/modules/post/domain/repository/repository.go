@@ -1,56 +1,92 @@
 package repository
 
 import (
+	"context"
 	"go-backend/internal/modules/post/domain/entity"
+	redirectEntity "go-backend/internal/modules/redirect/domain/entity"
+	redirectRepository "go-backend/internal/modules/redirect/domain/repository"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/publishing"
 	"gorm.io/gorm"
 )
 
 type PostRepository interface {
-	Create(post *entity.Post) error
-	GetByID(id uint) (*entity.Post, error)
-	Update(post *entity.Post) error
-	Delete(id uint) error
-	List(offset, limit int) ([]entity.Post, error)
-	ListByUserID(userID uint, offset, limit int) ([]entity.Post, error)
+	Create(ctx context.Context, post *entity.Post) error
+	GetByID(ctx context.Context, id uint) (*entity.Post, error)
+	Update(ctx context.Context, post *entity.Post) error
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Post, pagination.Meta, error)
+	ListByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Post, pagination.Meta, error)
+	ListByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]entity.Post, pagination.Meta, error)
+	SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error)
 }
 
 type postRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	redirects redirectRepository.RedirectRepository
 }
 
 func NewPostRepository(db *gorm.DB) PostRepository {
-	return &postRepository{db: db}
+	return &postRepository{db: db, redirects: redirectRepository.NewRedirectRepository(db)}
 }
 
-func (r *postRepository) Create(post *entity.Post) error {
-	return r.db.Create(post).Error
+func (r *postRepository) Create(ctx context.Context, post *entity.Post) error {
+	return r.db.WithContext(ctx).Create(post).Error
 }
 
-func (r *postRepository) GetByID(id uint) (*entity.Post, error) {
+func (r *postRepository) GetByID(ctx context.Context, id uint) (*entity.Post, error) {
 	var post entity.Post
-	err := r.db.Preload("User").First(&post, id).Error
+	err := r.db.WithContext(ctx).Preload("User").First(&post, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &post, nil
 }
 
-func (r *postRepository) Update(post *entity.Post) error {
-	return r.db.Save(post).Error
+// Update saves post. When its slug changed, the old slug redirects to it.
+func (r *postRepository) Update(ctx context.Context, post *entity.Post) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var oldSlug string
+		if err := tx.Model(&entity.Post{}).Where("id = ?", post.ID).Pluck("slug", &oldSlug).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(post).Error; err != nil {
+			return err
+		}
+		if oldSlug == "" || oldSlug == post.Slug {
+			return nil
+		}
+		return redirectRepository.NewRedirectRepository(tx).Move(ctx, redirectEntity.ResourcePost, oldSlug, post.Slug, post.ID)
+	})
 }
 
-func (r *postRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Post{}, id).Error
+func (r *postRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.Post{}, id).Error
 }
 
-func (r *postRepository) List(offset, limit int) ([]entity.Post, error) {
-	var posts []entity.Post
-	err := r.db.Preload("User").Offset(offset).Limit(limit).Find(&posts).Error
-	return posts, err
+func (r *postRepository) List(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Post, pagination.Meta, error) {
+	return pagination.Find[entity.Post](r.db.WithContext(ctx).Scopes(publishing.Public, f.Scope), params, "User")
 }
 
-func (r *postRepository) ListByUserID(userID uint, offset, limit int) ([]entity.Post, error) {
-	var posts []entity.Post
-	err := r.db.Preload("User").Where("user_id = ?", userID).Offset(offset).Limit(limit).Find(&posts).Error
-	return posts, err
-}
\ No newline at end of file
+func (r *postRepository) ListByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Post, pagination.Meta, error) {
+	return pagination.Find[entity.Post](r.db.WithContext(ctx).Scopes(publishing.Public).Where("user_id = ?", userID), params, "User")
+}
+
+// ListByOwner lists the posts of userID in every status.
+func (r *postRepository) ListByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]entity.Post, pagination.Meta, error) {
+	return pagination.Find[entity.Post](r.db.WithContext(ctx).Scopes(f.Scope).Where("user_id = ?", userID), params, "User")
+}
+
+// SlugTaken reports whether another post than excludeID has slug, or had it
+// and is redirected from it.
+func (r *postRepository) SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Post{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	return r.redirects.Taken(ctx, redirectEntity.ResourcePost, slug, excludeID)
+}
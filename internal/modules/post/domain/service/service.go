@@ -1,20 +1,36 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"time"
+
+	"go-backend/internal/infrastructure/tracing"
 	imageEntity "go-backend/internal/modules/images/domain/entity"
 	postEntity "go-backend/internal/modules/post/domain/entity"
 	"go-backend/internal/modules/post/domain/repository"
 	"go-backend/internal/modules/post/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/slug"
 )
 
+// ErrSlugTaken is returned when a post is given a slug that another post has,
+// or had before it was renamed.
+var ErrSlugTaken = apperror.Conflict("slug is already taken")
+
 type PostService interface {
-	Create(userID uint, req *dto.CreatePostRequest) (*dto.CreatePostResponse, error)
-	GetByID(id uint) (*dto.GetPostResponse, error)
-	Update(id, userID uint, req *dto.UpdatePostRequest) (*dto.UpdatePostResponse, error)
-	Delete(id, userID uint) error
-	List(page, pageSize int) ([]dto.GetPostResponse, error)
-	ListByUserID(userID uint, page, pageSize int) ([]dto.GetPostResponse, error)
+	Create(ctx context.Context, userID uint, req *dto.CreatePostRequest) (*dto.CreatePostResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.GetPostResponse, error)
+	Update(ctx context.Context, id, userID uint, req *dto.UpdatePostRequest) (*dto.UpdatePostResponse, error)
+	Delete(ctx context.Context, id, userID uint) error
+	List(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error)
+	ListByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error)
+	ListByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error)
+	Preview(ctx context.Context, id, userID uint) (*dto.GetPostResponse, error)
+	UpdateStatus(ctx context.Context, id, userID uint, req *dto.UpdateStatusRequest) (*dto.GetPostResponse, error)
 }
 
 type postService struct {
@@ -25,11 +41,36 @@ func NewPostService(repo repository.PostRepository) PostService {
 	return &postService{repo: repo}
 }
 
-func (s *postService) Create(userID uint, req *dto.CreatePostRequest) (*dto.CreatePostResponse, error) {
+func (s *postService) Create(ctx context.Context, userID uint, req *dto.CreatePostRequest) (*dto.CreatePostResponse, error) {
+	ctx, span := tracing.Start(ctx, "PostService.Create")
+	defer span.End()
+
 	post := &postEntity.Post{
 		Title:   req.Title,
 		Content: req.Content,
 		UserID:  userID,
+		SEO:     req.SEO,
+	}
+
+	if req.Slug != "" {
+		if err := s.checkSlug(ctx, req.Slug, 0); err != nil {
+			return nil, err
+		}
+		post.Slug = req.Slug
+	} else {
+		generated, err := s.uniqueSlug(ctx, req.Title)
+		if err != nil {
+			return nil, err
+		}
+		post.Slug = generated
+	}
+
+	status := req.Status
+	if status == "" {
+		status = publishing.Draft
+	}
+	if err := post.Change(status, req.PublishedAt, time.Now()); err != nil {
+		return nil, err
 	}
 
 	// Create images if provided
@@ -44,7 +85,7 @@ func (s *postService) Create(userID uint, req *dto.CreatePostRequest) (*dto.Crea
 		post.Images = images
 	}
 
-	if err := s.repo.Create(post); err != nil {
+	if err := s.repo.Create(ctx, post); err != nil {
 		return nil, err
 	}
 
@@ -55,54 +96,47 @@ func (s *postService) Create(userID uint, req *dto.CreatePostRequest) (*dto.Crea
 	}
 
 	return &dto.CreatePostResponse{
-		ID:        post.ID,
-		Title:     post.Title,
-		Content:   post.Content,
-		UserID:    post.UserID,
-		ImageURLs: imageURLs,
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Content:     post.Content,
+		UserID:      post.UserID,
+		ImageURLs:   imageURLs,
+		Status:      post.Status,
+		PublishedAt: post.PublishedAt,
+		SEO:         post.SEOMeta(),
 	}, nil
 }
 
-func (s *postService) GetByID(id uint) (*dto.GetPostResponse, error) {
-	post, err := s.repo.GetByID(id)
-	if err != nil {
-		return nil, err
-	}
+func (s *postService) GetByID(ctx context.Context, id uint) (*dto.GetPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "PostService.GetByID")
+	defer span.End()
 
-	// Extract image URLs for response
-	imageURLs := make([]string, len(post.Images))
-	for i, img := range post.Images {
-		imageURLs[i] = img.URL
+	post, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.NotFoundOr(err, "post not found")
 	}
 
-	resp := &dto.GetPostResponse{
-		ID:        post.ID,
-		Title:     post.Title,
-		Content:   post.Content,
-		UserID:    post.UserID,
-		ImageURLs: imageURLs,
-		User: struct {
-			ID    uint   `json:"id"`
-			Name  string `json:"name"`
-			Email string `json:"email"`
-		}{
-			ID:    post.User.ID,
-			Name:  post.User.Name,
-			Email: post.User.Email,
-		},
+	// Unpublished posts are only shown to their owner, through Preview
+	if !post.IsPublished() {
+		return nil, apperror.NotFound("post not found")
 	}
 
-	return resp, nil
+	resp := toGetPostResponse(post)
+	return &resp, nil
 }
 
-func (s *postService) Update(id, userID uint, req *dto.UpdatePostRequest) (*dto.UpdatePostResponse, error) {
-	post, err := s.repo.GetByID(id)
+func (s *postService) Update(ctx context.Context, id, userID uint, req *dto.UpdatePostRequest) (*dto.UpdatePostResponse, error) {
+	ctx, span := tracing.Start(ctx, "PostService.Update")
+	defer span.End()
+
+	post, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "post not found")
 	}
 
-	if post.UserID != userID {
-		return nil, errors.New("unauthorized")
+	if err := authz.RequireOwner(ctx, userID, post.UserID, "update", "posts"); err != nil {
+		return nil, err
 	}
 
 	if req.Title != "" {
@@ -111,20 +145,30 @@ func (s *postService) Update(id, userID uint, req *dto.UpdatePostRequest) (*dto.
 	if req.Content != "" {
 		post.Content = req.Content
 	}
+	if req.Slug != "" && req.Slug != post.Slug {
+		if err := s.checkSlug(ctx, req.Slug, post.ID); err != nil {
+			return nil, err
+		}
+		post.Slug = req.Slug
+	}
+	if req.SEO != nil {
+		post.SEO = *req.SEO
+	}
 
-	// Update images if provided
+	// Update images if provided. They belong to the owner, also when an
+	// admin makes the change.
 	if len(req.ImageURLs) > 0 {
 		images := make([]imageEntity.Images, len(req.ImageURLs))
 		for i, url := range req.ImageURLs {
 			images[i] = imageEntity.Images{
 				URL:    url,
-				UserID: userID,
+				UserID: post.UserID,
 			}
 		}
 		post.Images = images
 	}
 
-	if err := s.repo.Update(post); err != nil {
+	if err := s.repo.Update(ctx, post); err != nil {
 		return nil, err
 	}
 
@@ -135,95 +179,178 @@ func (s *postService) Update(id, userID uint, req *dto.UpdatePostRequest) (*dto.
 	}
 
 	return &dto.UpdatePostResponse{
-		ID:        post.ID,
-		Title:     post.Title,
-		Content:   post.Content,
-		UserID:    post.UserID,
-		ImageURLs: imageURLs,
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Content:     post.Content,
+		UserID:      post.UserID,
+		ImageURLs:   imageURLs,
+		Status:      post.Status,
+		PublishedAt: post.PublishedAt,
+		SEO:         post.SEOMeta(),
 	}, nil
 }
 
-func (s *postService) Delete(id, userID uint) error {
-	post, err := s.repo.GetByID(id)
+func (s *postService) Delete(ctx context.Context, id, userID uint) error {
+	ctx, span := tracing.Start(ctx, "PostService.Delete")
+	defer span.End()
+
+	post, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		return apperror.NotFoundOr(err, "post not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, post.UserID, "delete", "posts"); err != nil {
 		return err
 	}
 
-	if post.UserID != userID {
-		return errors.New("unauthorized")
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *postService) List(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "PostService.List")
+	defer span.End()
+
+	posts, meta, err := s.repo.List(ctx, f, params)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	response := make([]dto.GetPostResponse, len(posts))
+	for i := range posts {
+		response[i] = toGetPostResponse(&posts[i])
 	}
 
-	return s.repo.Delete(id)
+	return response, meta, nil
 }
 
-func (s *postService) List(page, pageSize int) ([]dto.GetPostResponse, error) {
-	offset := (page - 1) * pageSize
-	posts, err := s.repo.List(offset, pageSize)
+func (s *postService) ListByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "PostService.ListByUserID")
+	defer span.End()
+
+	posts, meta, err := s.repo.ListByUserID(ctx, userID, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.GetPostResponse, len(posts))
-	for i, post := range posts {
-		// Extract image URLs for response
-		imageURLs := make([]string, len(post.Images))
-		for j, img := range post.Images {
-			imageURLs[j] = img.URL
-		}
+	for i := range posts {
+		response[i] = toGetPostResponse(&posts[i])
+	}
 
-		response[i] = dto.GetPostResponse{
-			ID:        post.ID,
-			Title:     post.Title,
-			Content:   post.Content,
-			UserID:    post.UserID,
-			ImageURLs: imageURLs,
-			User: struct {
-				ID    uint   `json:"id"`
-				Name  string `json:"name"`
-				Email string `json:"email"`
-			}{
-				ID:    post.User.ID,
-				Name:  post.User.Name,
-				Email: post.User.Email,
-			},
-		}
+	return response, meta, nil
+}
+
+// ListByOwner lists the posts of userID in every status, for their owner.
+func (s *postService) ListByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "PostService.ListByOwner")
+	defer span.End()
+
+	posts, meta, err := s.repo.ListByOwner(ctx, userID, f, params)
+	if err != nil {
+		return nil, pagination.Meta{}, err
 	}
 
-	return response, nil
+	response := make([]dto.GetPostResponse, len(posts))
+	for i := range posts {
+		response[i] = toGetPostResponse(&posts[i])
+	}
+
+	return response, meta, nil
 }
 
-func (s *postService) ListByUserID(userID uint, page, pageSize int) ([]dto.GetPostResponse, error) {
-	offset := (page - 1) * pageSize
-	posts, err := s.repo.ListByUserID(userID, offset, pageSize)
+// Preview returns a post in any status to its owner.
+func (s *postService) Preview(ctx context.Context, id, userID uint) (*dto.GetPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "PostService.Preview")
+	defer span.End()
+
+	post, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		return nil, apperror.NotFoundOr(err, "post not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, post.UserID, "preview", "posts"); err != nil {
 		return nil, err
 	}
 
-	response := make([]dto.GetPostResponse, len(posts))
-	for i, post := range posts {
-		// Extract image URLs for response
-		imageURLs := make([]string, len(post.Images))
-		for j, img := range post.Images {
-			imageURLs[j] = img.URL
-		}
+	resp := toGetPostResponse(post)
+	return &resp, nil
+}
 
-		response[i] = dto.GetPostResponse{
-			ID:        post.ID,
-			Title:     post.Title,
-			Content:   post.Content,
-			UserID:    post.UserID,
-			ImageURLs: imageURLs,
-			User: struct {
-				ID    uint   `json:"id"`
-				Name  string `json:"name"`
-				Email string `json:"email"`
-			}{
-				ID:    post.User.ID,
-				Name:  post.User.Name,
-				Email: post.User.Email,
-			},
-		}
+func (s *postService) UpdateStatus(ctx context.Context, id, userID uint, req *dto.UpdateStatusRequest) (*dto.GetPostResponse, error) {
+	ctx, span := tracing.Start(ctx, "PostService.UpdateStatus")
+	defer span.End()
+
+	post, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.NotFoundOr(err, "post not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, post.UserID, "publish", "posts"); err != nil {
+		return nil, err
+	}
+
+	if err := post.Change(req.Status, req.PublishedAt, time.Now()); err != nil {
+		return nil, err
 	}
 
-	return response, nil
-}
\ No newline at end of file
+	if err := s.repo.Update(ctx, post); err != nil {
+		return nil, err
+	}
+
+	resp := toGetPostResponse(post)
+	return &resp, nil
+}
+
+// checkSlug returns ErrSlugTaken when another post than id has candidate.
+func (s *postService) checkSlug(ctx context.Context, candidate string, id uint) error {
+	taken, err := s.repo.SlugTaken(ctx, candidate, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// uniqueSlug returns a slug made from title that no post has, numbered when
+// the plain one is taken.
+func (s *postService) uniqueSlug(ctx context.Context, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = "post"
+	}
+	return slug.Unique(base, slug.MaxLength, func(candidate string) (bool, error) {
+		return s.repo.SlugTaken(ctx, candidate, 0)
+	})
+}
+
+func toGetPostResponse(post *postEntity.Post) dto.GetPostResponse {
+	// Extract image URLs for response
+	imageURLs := make([]string, len(post.Images))
+	for i, img := range post.Images {
+		imageURLs[i] = img.URL
+	}
+
+	return dto.GetPostResponse{
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Content:     post.Content,
+		UserID:      post.UserID,
+		ImageURLs:   imageURLs,
+		Status:      post.Status,
+		PublishedAt: post.PublishedAt,
+		SEO:         post.SEOMeta(),
+		User: struct {
+			ID    uint   `json:"id"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}{
+			ID:    post.User.ID,
+			Name:  post.User.Name,
+			Email: post.User.Email,
+		},
+	}
+}
@@ -1,42 +1,100 @@
 package dto
 
+import (
+	"time"
+
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/seo"
+)
+
+// ListOptions are the sorts of post lists, newest first by default.
+var ListOptions = pagination.Options{
+	Sortable:    []string{"id", "title", "published_at", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// FilterFields are the fields that post lists can be filtered by.
+var FilterFields = filter.Fields{
+	"id":         {Column: "id", Type: filter.Integer},
+	"title":      {Column: "title", Type: filter.String},
+	"user_id":    {Column: "user_id", Type: filter.Integer},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+
+	"status":       {Column: "status", Type: filter.String},
+	"published_at": {Column: "published_at", Type: filter.Time},
+
+	"slug": {Column: "slug", Type: filter.String},
+}
+
 type CreatePostRequest struct {
-	Title    string   `json:"title" binding:"required"`
-	Content  string   `json:"content" binding:"required"`
-	ImageURLs []string `json:"image_urls"`
+	Title    string   `json:"title" binding:"required,max=255"`
+	Content  string   `json:"content" binding:"required,max=50000"`
+	ImageURLs []string `json:"image_urls" binding:"max=20,dive,httpurl"`
+	// Status is draft unless given. A scheduled post needs PublishedAt.
+	Status      string     `json:"status" binding:"omitempty,publishstatus"`
+	PublishedAt *time.Time `json:"published_at"`
+	// Slug is made from Title unless given.
+	Slug string     `json:"slug" binding:"omitempty,slug,max=80"`
+	SEO  seo.Fields `json:"seo"`
 }
 
 type CreatePostResponse struct {
-	ID        uint     `json:"id"`
-	Title     string   `json:"title"`
-	Content   string   `json:"content"`
-	UserID    uint     `json:"user_id"`
-	ImageURLs []string `json:"image_urls"`
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Content     string     `json:"content"`
+	UserID      uint       `json:"user_id"`
+	ImageURLs   []string   `json:"image_urls"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	SEO         seo.Fields `json:"seo"`
 }
 
 type UpdatePostRequest struct {
-	Title    string   `json:"title"`
-	Content  string   `json:"content"`
-	ImageURLs []string `json:"image_urls"`
+	Title    string   `json:"title" binding:"omitempty,max=255"`
+	Content  string   `json:"content" binding:"omitempty,max=50000"`
+	ImageURLs []string `json:"image_urls" binding:"max=20,dive,httpurl"`
+	// Slug renames the post. Its old slug redirects to it.
+	Slug string `json:"slug" binding:"omitempty,slug,max=80"`
+	// SEO replaces the SEO metadata of the post when given.
+	SEO *seo.Fields `json:"seo"`
 }
 
 type UpdatePostResponse struct {
-	ID        uint     `json:"id"`
-	Title     string   `json:"title"`
-	Content   string   `json:"content"`
-	UserID    uint     `json:"user_id"`
-	ImageURLs []string `json:"image_urls"`
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Content     string     `json:"content"`
+	UserID      uint       `json:"user_id"`
+	ImageURLs   []string   `json:"image_urls"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	SEO         seo.Fields `json:"seo"`
 }
 
 type GetPostResponse struct {
-	ID        uint     `json:"id"`
-	Title     string   `json:"title"`
-	Content   string   `json:"content"`
-	UserID    uint     `json:"user_id"`
-	ImageURLs []string `json:"image_urls"`
-	User      struct {
+	ID          uint       `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Content     string     `json:"content"`
+	UserID      uint       `json:"user_id"`
+	ImageURLs   []string   `json:"image_urls"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	SEO         seo.Fields `json:"seo"`
+	User        struct {
 		ID    uint   `json:"id"`
 		Name  string `json:"name"`
 		Email string `json:"email"`
 	} `json:"user"`
-}
\ No newline at end of file
+}
+
+// UpdateStatusRequest moves a post through the publishing workflow.
+// PublishedAt is required to schedule the post, and backdates it when it is
+// published.
+type UpdateStatusRequest struct {
+	Status      string     `json:"status" binding:"required,publishstatus"`
+	PublishedAt *time.Time `json:"published_at"`
+}
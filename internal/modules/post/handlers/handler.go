@@ -7,24 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"go-backend/internal/modules/post/domain/service"
 	"go-backend/internal/modules/post/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 )
 
-type Response struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
-
-func formatResponse(status int, message string, data interface{}, err string) Response {
-	return Response{
-		Status:  status,
-		Message: message,
-		Data:    data,
-		Error:   err,
-	}
-}
-
 type PostHandler struct {
 	service service.PostService
 }
@@ -36,132 +24,207 @@ func NewPostHandler(service service.PostService) *PostHandler {
 func (h *PostHandler) Create(c *gin.Context) {
 	var req dto.CreatePostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	resp, err := h.service.Create(userID.(uint), &req)
+	resp, err := h.service.Create(c.Request.Context(), userID.(uint), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to create post", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, formatResponse(http.StatusCreated, "Post created successfully", resp, ""))
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "Post created successfully", resp))
 }
 
 func (h *PostHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	resp, err := h.service.GetByID(uint(id))
+	resp, err := h.service.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Post not found", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Post retrieved successfully", resp, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Post retrieved successfully", resp))
 }
 
 func (h *PostHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var req dto.UpdatePostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	resp, err := h.service.Update(uint(id), userID.(uint), &req)
+	resp, err := h.service.Update(c.Request.Context(), uint(id), userID.(uint), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to update post", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Post updated successfully", resp, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Post updated successfully", resp))
 }
 
 func (h *PostHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	if err := h.service.Delete(uint(id), userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to delete post", nil, err.Error()))
+	if err := h.service.Delete(c.Request.Context(), uint(id), userID.(uint)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Post deleted successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Post deleted successfully", nil))
 }
 
 func (h *PostHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-
-	if page < 1 {
-		page = 1
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	if pageSize < 1 {
-		pageSize = 10
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	posts, err := h.service.List(page, pageSize)
+	posts, meta, err := h.service.List(c.Request.Context(), f, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve posts", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Posts retrieved successfully", posts, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Posts retrieved successfully", posts, meta))
 }
 
 func (h *PostHandler) ListByUserID(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid user ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	if page < 1 {
-		page = 1
+	posts, meta, err := h.service.ListByUserID(c.Request.Context(), uint(userID), params)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	if pageSize < 1 {
-		pageSize = 10
+
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "User's posts retrieved successfully", posts, meta))
+}
+
+// ListMine lists the posts of the authenticated user in every status.
+func (h *PostHandler) ListMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	posts, err := h.service.ListByUserID(uint(userID), page, pageSize)
+	posts, meta, err := h.service.ListByOwner(c.Request.Context(), userID.(uint), f, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve user's posts", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User's posts retrieved successfully", posts, ""))
-}
\ No newline at end of file
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "User's posts retrieved successfully", posts, meta))
+}
+
+func (h *PostHandler) Preview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid ID format"))
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	resp, err := h.service.Preview(c.Request.Context(), uint(id), userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Post retrieved successfully", resp))
+}
+
+func (h *PostHandler) UpdateStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid ID format"))
+		return
+	}
+
+	var req dto.UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	resp, err := h.service.UpdateStatus(c.Request.Context(), uint(id), userID.(uint), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Post status updated successfully", resp))
+}
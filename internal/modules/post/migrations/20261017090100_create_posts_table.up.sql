@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS posts (
+    id bigserial PRIMARY KEY,
+    title text NOT NULL,
+    content text NOT NULL,
+    user_id bigint NOT NULL CONSTRAINT fk_posts_user REFERENCES users (id),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts (deleted_at);
@@ -0,0 +1,8 @@
+-- Existing posts were public, so they are published as of their creation.
+-- New posts start as drafts.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'published';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS published_at timestamptz;
+UPDATE posts SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+ALTER TABLE posts ALTER COLUMN status SET DEFAULT 'draft';
+-- Serves both the public listings and the scheduler's lookup of due posts.
+CREATE INDEX IF NOT EXISTS idx_posts_status_published_at ON posts (status, published_at);
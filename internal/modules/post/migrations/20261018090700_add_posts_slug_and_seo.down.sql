@@ -0,0 +1,6 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS og_image;
+ALTER TABLE posts DROP COLUMN IF EXISTS canonical_url;
+ALTER TABLE posts DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE posts DROP COLUMN IF EXISTS meta_title;
+DROP INDEX IF EXISTS idx_posts_slug;
+ALTER TABLE posts DROP COLUMN IF EXISTS slug;
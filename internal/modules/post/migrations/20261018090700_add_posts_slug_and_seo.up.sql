@@ -0,0 +1,36 @@
+-- Existing rows get the slug of their title, made like the service makes it
+-- except that accented letters are dropped rather than unaccented. Rows whose
+-- title has no letters or digits get their ID appended, and later rows sharing
+-- a slug are numbered -2, -3, ... with the first number that is free.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS slug text;
+UPDATE posts SET slug = trim(both '-' from left(trim(both '-' from regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g')), 80))
+    WHERE slug IS NULL;
+UPDATE posts SET slug = 'post-' || id WHERE slug = '';
+DO $$
+DECLARE
+    r record;
+    candidate text;
+    n int;
+BEGIN
+    FOR r IN
+        SELECT t.id, t.slug FROM posts t
+        WHERE EXISTS (SELECT 1 FROM posts o WHERE o.slug = t.slug AND o.id < t.id)
+        ORDER BY t.id
+    LOOP
+        n := 2;
+        LOOP
+            candidate := rtrim(left(r.slug, 80 - length('-' || n)), '-') || '-' || n;
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM posts WHERE slug = candidate);
+            n := n + 1;
+        END LOOP;
+        UPDATE posts SET slug = candidate WHERE id = r.id;
+    END LOOP;
+END $$;
+ALTER TABLE posts ALTER COLUMN slug SET NOT NULL;
+-- Deleted rows give up their slug.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_slug ON posts (slug) WHERE deleted_at IS NULL;
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS meta_title text NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS meta_description text NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS canonical_url text NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS og_image text NOT NULL DEFAULT '';
@@ -1,49 +1,65 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"go-backend/internal/modules/post/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type MockPostRepository struct {
 	mock.Mock
 }
 
-func (m *MockPostRepository) Create(post *entity.Post) error {
-	args := m.Called(post)
+func (m *MockPostRepository) Create(ctx context.Context, post *entity.Post) error {
+	args := m.Called(ctx, post)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) GetByID(id uint) (*entity.Post, error) {
-	args := m.Called(id)
+func (m *MockPostRepository) GetByID(ctx context.Context, id uint) (*entity.Post, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) Update(post *entity.Post) error {
-	args := m.Called(post)
+func (m *MockPostRepository) Update(ctx context.Context, post *entity.Post) error {
+	args := m.Called(ctx, post)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockPostRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockPostRepository) List(offset, limit int) ([]entity.Post, error) {
-	args := m.Called(offset, limit)
+func (m *MockPostRepository) List(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Post, pagination.Meta, error) {
+	args := m.Called(ctx, f, params)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pagination.Meta{}, args.Error(2)
 	}
-	return args.Get(0).([]entity.Post), args.Error(1)
+	return args.Get(0).([]entity.Post), args.Get(1).(pagination.Meta), args.Error(2)
 }
 
-func (m *MockPostRepository) ListByUserID(userID uint, offset, limit int) ([]entity.Post, error) {
-	args := m.Called(userID, offset, limit)
+func (m *MockPostRepository) ListByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Post, pagination.Meta, error) {
+	args := m.Called(ctx, userID, params)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, pagination.Meta{}, args.Error(2)
+	}
+	return args.Get(0).([]entity.Post), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockPostRepository) ListByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]entity.Post, pagination.Meta, error) {
+	args := m.Called(ctx, userID, f, params)
+	if args.Get(0) == nil {
+		return nil, pagination.Meta{}, args.Error(2)
 	}
-	return args.Get(0).([]entity.Post), args.Error(1)
+	return args.Get(0).([]entity.Post), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockPostRepository) SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
 }
@@ -1,55 +1,82 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"go-backend/internal/modules/post/dto"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type MockPostService struct {
 	mock.Mock
 }
 
-func (m *MockPostService) Create(userID uint, req *dto.CreatePostRequest) (*dto.CreatePostResponse, error) {
-	args := m.Called(userID, req)
+func (m *MockPostService) Create(ctx context.Context, userID uint, req *dto.CreatePostRequest) (*dto.CreatePostResponse, error) {
+	args := m.Called(ctx, userID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.CreatePostResponse), args.Error(1)
 }
 
-func (m *MockPostService) GetByID(id uint) (*dto.GetPostResponse, error) {
-	args := m.Called(id)
+func (m *MockPostService) GetByID(ctx context.Context, id uint) (*dto.GetPostResponse, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.GetPostResponse), args.Error(1)
 }
 
-func (m *MockPostService) Update(id, userID uint, req *dto.UpdatePostRequest) (*dto.UpdatePostResponse, error) {
-	args := m.Called(id, userID, req)
+func (m *MockPostService) Update(ctx context.Context, id, userID uint, req *dto.UpdatePostRequest) (*dto.UpdatePostResponse, error) {
+	args := m.Called(ctx, id, userID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.UpdatePostResponse), args.Error(1)
 }
 
-func (m *MockPostService) Delete(id, userID uint) error {
-	args := m.Called(id, userID)
+func (m *MockPostService) Delete(ctx context.Context, id, userID uint) error {
+	args := m.Called(ctx, id, userID)
 	return args.Error(0)
 }
 
-func (m *MockPostService) List(page, pageSize int) ([]dto.GetPostResponse, error) {
-	args := m.Called(page, pageSize)
+func (m *MockPostService) List(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error) {
+	args := m.Called(ctx, f, params)
+	if args.Get(0) == nil {
+		return nil, pagination.Meta{}, args.Error(2)
+	}
+	return args.Get(0).([]dto.GetPostResponse), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockPostService) ListByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error) {
+	args := m.Called(ctx, userID, params)
+	if args.Get(0) == nil {
+		return nil, pagination.Meta{}, args.Error(2)
+	}
+	return args.Get(0).([]dto.GetPostResponse), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockPostService) ListByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]dto.GetPostResponse, pagination.Meta, error) {
+	args := m.Called(ctx, userID, f, params)
+	if args.Get(0) == nil {
+		return nil, pagination.Meta{}, args.Error(2)
+	}
+	return args.Get(0).([]dto.GetPostResponse), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockPostService) Preview(ctx context.Context, id, userID uint) (*dto.GetPostResponse, error) {
+	args := m.Called(ctx, id, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]dto.GetPostResponse), args.Error(1)
+	return args.Get(0).(*dto.GetPostResponse), args.Error(1)
 }
 
-func (m *MockPostService) ListByUserID(userID uint, page, pageSize int) ([]dto.GetPostResponse, error) {
-	args := m.Called(userID, page, pageSize)
+func (m *MockPostService) UpdateStatus(ctx context.Context, id, userID uint, req *dto.UpdateStatusRequest) (*dto.GetPostResponse, error) {
+	args := m.Called(ctx, id, userID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]dto.GetPostResponse), args.Error(1)
+	return args.Get(0).(*dto.GetPostResponse), args.Error(1)
 }
@@ -2,6 +2,8 @@ package post
 
 import (
 	"github.com/gin-gonic/gin"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/validation"
 	"gorm.io/gorm"
 )
 
@@ -10,6 +12,8 @@ type Module struct {
 }
 
 func NewModule(db *gorm.DB) *Module {
+	validation.RegisterEnum("publishstatus", publishing.Statuses...)
+
 	return &Module{db: db}
 }
 
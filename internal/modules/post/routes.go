@@ -6,6 +6,7 @@ import (
 	"go-backend/internal/modules/post/domain/repository"
 	"go-backend/internal/modules/post/domain/service"
 	"go-backend/internal/modules/post/handlers"
+	userEntity "go-backend/internal/modules/user/domain/entity"
 	"gorm.io/gorm"
 )
 
@@ -21,12 +22,17 @@ func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB) {
 		posts.GET("/:id", handler.GetByID)
 		posts.GET("/user/:user_id", handler.ListByUserID)
 
-		// Protected routes
-		protected := posts.Use(middleware.JWTAuth(middleware.AccessToken))
+		// Protected routes - access token or API key with posts:write
+		protected := posts.Group("", middleware.JWTOrAPIKeyAuth(userEntity.ScopePostsWrite))
 		{
-			protected.POST("", handler.Create)
+			protected.POST("", middleware.RequireVerifiedEmail(), handler.Create)
 			protected.PUT("/:id", handler.Update)
 			protected.DELETE("/:id", handler.Delete)
+
+			// Owner routes, which see posts in every status
+			protected.GET("/mine", handler.ListMine)
+			protected.GET("/:id/preview", handler.Preview)
+			protected.PUT("/:id/status", handler.UpdateStatus)
 		}
 	}
 }
\ No newline at end of file
@@ -10,18 +10,27 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
+	"go-backend/internal/infrastructure/middleware"
 	"go-backend/internal/modules/post/dto"
 	"go-backend/internal/modules/post/handlers"
 	"go-backend/internal/modules/post/mocks"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/validation"
 )
 
 func setupTest() (*gin.Engine, *mocks.MockPostService) {
 	gin.SetMode(gin.TestMode)
+	validation.Setup()
+	validation.RegisterEnum("publishstatus", publishing.Statuses...)
 	r := gin.Default()
 	mockService := new(mocks.MockPostService)
 	handler := handlers.NewPostHandler(mockService)
 
+	r.Use(middleware.ErrorMiddleware())
+
 	// Add auth middleware before routes
 	r.Use(func(c *gin.Context) {
 		c.Set("user_id", uint(1))
@@ -34,6 +43,9 @@ func setupTest() (*gin.Engine, *mocks.MockPostService) {
 	group.GET("/:id", handler.GetByID)
 	group.PUT("/:id", handler.Update)
 	group.DELETE("/:id", handler.Delete)
+	group.GET("/mine", handler.ListMine)
+	group.GET("/:id/preview", handler.Preview)
+	group.PUT("/:id/status", handler.UpdateStatus)
 
 	return r, mockService
 }
@@ -54,7 +66,7 @@ func TestPostHandler_Create(t *testing.T) {
 			UserID:  1,
 		}
 
-		mockService.On("Create", uint(1), &req).Return(response, nil).Once()
+		mockService.On("Create", mock.Anything, uint(1), &req).Return(response, nil).Once()
 
 		body, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -86,7 +98,7 @@ func TestPostHandler_Create(t *testing.T) {
 			Content: "Test Content",
 		}
 
-		mockService.On("Create", uint(1), &req).Return(nil, errors.New("service error")).Once()
+		mockService.On("Create", mock.Anything, uint(1), &req).Return(nil, errors.New("service error")).Once()
 
 		body, _ := json.Marshal(req)
 		w := httptest.NewRecorder()
@@ -97,4 +109,51 @@ func TestPostHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+}
+
+func TestPostHandler_UpdateStatus(t *testing.T) {
+	r, mockService := setupTest()
+
+	t.Run("Success", func(t *testing.T) {
+		req := dto.UpdateStatusRequest{Status: publishing.Published}
+
+		mockService.On("UpdateStatus", mock.Anything, uint(1), uint(1), &req).
+			Return(&dto.GetPostResponse{ID: 1, Status: publishing.Published}, nil).Once()
+
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		req1, _ := http.NewRequest(http.MethodPut, "/posts/1/status", bytes.NewBuffer(body))
+
+		r.ServeHTTP(w, req1)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"published"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req1, _ := http.NewRequest(http.MethodPut, "/posts/1/status", bytes.NewBufferString(`{"status":"deleted"}`))
+
+		r.ServeHTTP(w, req1)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"rule":"oneof"`)
+	})
+}
+
+func TestPostHandler_ListMine(t *testing.T) {
+	r, mockService := setupTest()
+
+	mockService.On("ListByOwner", mock.Anything, uint(1), mock.Anything, mock.Anything).
+		Return([]dto.GetPostResponse{{ID: 1, Status: publishing.Draft}}, pagination.Meta{Limit: 20, Total: 1}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/posts/mine?filter[status]=draft", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"draft"`)
+	mockService.AssertExpectations(t)
+}
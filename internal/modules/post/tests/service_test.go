@@ -1,7 +1,10 @@
 package tests
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -10,6 +13,11 @@ import (
 	"go-backend/internal/modules/post/domain/service"
 	"go-backend/internal/modules/post/dto"
 	"go-backend/internal/modules/post/mocks"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/seo"
+	"gorm.io/gorm"
 )
 
 func TestCreatePostService(t *testing.T) {
@@ -32,33 +40,248 @@ func TestCreatePostService(t *testing.T) {
 				Content: "Test Content",
 			},
 			setupMock: func() {
-				mockRepo.On("Create", mock.MatchedBy(func(post *entity.Post) bool {
+				mockRepo.On("SlugTaken", mock.Anything, "test-post", uint(0)).Return(false, nil)
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(post *entity.Post) bool {
 					return post.Title == "Test Post" &&
 						post.Content == "Test Content" &&
 						post.UserID == uint(1)
 				})).Run(func(args mock.Arguments) {
-					post := args.Get(0).(*entity.Post)
+					post := args.Get(1).(*entity.Post)
 					post.ID = 1
 				}).Return(nil)
 			},
 			expectedResp: &dto.CreatePostResponse{
 				ID:      1,
 				Title:   "Test Post",
+				Slug:    "test-post",
 				Content: "Test Content",
 				UserID:  1,
+				Status:  publishing.Draft,
+				SEO:     seo.Fields{MetaTitle: "Test Post", MetaDescription: "Test Content"},
 			},
 			expectedError: nil,
 		},
+		{
+			name:   "Slug taken",
+			userID: 1,
+			input: &dto.CreatePostRequest{
+				Title:   "Another Post",
+				Content: "Test Content",
+				Slug:    "taken",
+			},
+			setupMock: func() {
+				mockRepo.On("SlugTaken", mock.Anything, "taken", uint(0)).Return(true, nil)
+			},
+			expectedError: service.ErrSlugTaken,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			resp, err := svc.Create(tt.userID, tt.input)
+			resp, err := svc.Create(context.Background(), tt.userID, tt.input)
 			assert.Equal(t, tt.expectedResp, resp)
 			assert.Equal(t, tt.expectedError, err)
 			mockRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestCreatePostService_GeneratedSlug(t *testing.T) {
+	mockRepo := new(mocks.MockPostRepository)
+	svc := service.NewPostService(mockRepo)
+	mockRepo.On("SlugTaken", mock.Anything, "hello-world", uint(0)).Return(true, nil)
+	mockRepo.On("SlugTaken", mock.Anything, "hello-world-2", uint(0)).Return(false, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Post")).Return(nil)
+
+	resp, err := svc.Create(context.Background(), 1, &dto.CreatePostRequest{Title: "Hello, World!", Content: "Hi"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world-2", resp.Slug)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdatePostService_Slug(t *testing.T) {
+	post := &entity.Post{ID: 1, Title: "Test Post", Slug: "test-post", UserID: 1}
+
+	tests := []struct {
+		name           string
+		req            *dto.UpdatePostRequest
+		setupMock      func(*mocks.MockPostRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Rename",
+			req:  &dto.UpdatePostRequest{Slug: "renamed", SEO: &seo.Fields{MetaTitle: "Custom"}},
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("SlugTaken", mock.Anything, "renamed", uint(1)).Return(false, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(post *entity.Post) bool {
+					return post.Slug == "renamed" && post.SEO.MetaTitle == "Custom"
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Same slug",
+			req:  &dto.UpdatePostRequest{Slug: "test-post"},
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Post")).Return(nil)
+			},
+		},
+		{
+			name: "Taken",
+			req:  &dto.UpdatePostRequest{Slug: "taken"},
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("SlugTaken", mock.Anything, "taken", uint(1)).Return(true, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockPostRepository)
+			svc := service.NewPostService(mockRepo)
+			existing := *post
+			mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&existing, nil)
+			tt.setupMock(mockRepo)
+
+			resp, err := svc.Update(context.Background(), 1, 1, tt.req)
+			if tt.expectedStatus == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.req.Slug, resp.Slug)
+			} else {
+				assert.Equal(t, tt.expectedStatus, apperror.From(err).Status())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeletePostService(t *testing.T) {
+	post := &entity.Post{ID: 1, Title: "Test Post", UserID: 1}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		userID         uint
+		setupMock      func(*mocks.MockPostRepository)
+		expectedStatus int
+	}{
+		{
+			name:   "Owner",
+			ctx:    context.Background(),
+			userID: 1,
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("GetByID", mock.Anything, uint(1)).Return(post, nil)
+				repo.On("Delete", mock.Anything, uint(1)).Return(nil)
+			},
+		},
+		{
+			name:   "Admin",
+			ctx:    authz.WithActor(context.Background(), authz.Actor{UserID: 2, Admin: true}),
+			userID: 2,
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("GetByID", mock.Anything, uint(1)).Return(post, nil)
+				repo.On("Delete", mock.Anything, uint(1)).Return(nil)
+			},
+		},
+		{
+			name:   "Another user",
+			ctx:    authz.WithActor(context.Background(), authz.Actor{UserID: 2}),
+			userID: 2,
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("GetByID", mock.Anything, uint(1)).Return(post, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Not found",
+			ctx:    context.Background(),
+			userID: 1,
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("GetByID", mock.Anything, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockPostRepository)
+			svc := service.NewPostService(mockRepo)
+			tt.setupMock(mockRepo)
+
+			err := svc.Delete(tt.ctx, 1, tt.userID)
+			if tt.expectedStatus == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.expectedStatus, apperror.From(err).Status())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetPostService_Unpublished(t *testing.T) {
+	mockRepo := new(mocks.MockPostRepository)
+	svc := service.NewPostService(mockRepo)
+	draft := &entity.Post{ID: 1, Title: "Draft", UserID: 1, State: publishing.State{Status: publishing.Draft}}
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(draft, nil)
+
+	// Hidden from the public, but shown to its owner
+	_, err := svc.GetByID(context.Background(), 1)
+	assert.Equal(t, http.StatusNotFound, apperror.From(err).Status())
+
+	resp, err := svc.Preview(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, publishing.Draft, resp.Status)
+
+	_, err = svc.Preview(authz.WithActor(context.Background(), authz.Actor{UserID: 2}), 1, 2)
+	assert.Equal(t, http.StatusForbidden, apperror.From(err).Status())
+}
+
+func TestUpdatePostStatusService(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour).UTC()
+
+	tests := []struct {
+		name           string
+		req            *dto.UpdateStatusRequest
+		setupMock      func(*mocks.MockPostRepository)
+		expectedStatus int
+	}{
+		{
+			name: "Schedule",
+			req:  &dto.UpdateStatusRequest{Status: publishing.Scheduled, PublishedAt: &tomorrow},
+			setupMock: func(repo *mocks.MockPostRepository) {
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(post *entity.Post) bool {
+					return post.Status == publishing.Scheduled && post.PublishedAt.Equal(tomorrow)
+				})).Return(nil)
+			},
+		},
+		{
+			name:           "Schedule without a date",
+			req:            &dto.UpdateStatusRequest{Status: publishing.Scheduled},
+			setupMock:      func(repo *mocks.MockPostRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockPostRepository)
+			svc := service.NewPostService(mockRepo)
+			draft := &entity.Post{ID: 1, Title: "Draft", UserID: 1, State: publishing.State{Status: publishing.Draft}}
+			mockRepo.On("GetByID", mock.Anything, uint(1)).Return(draft, nil)
+			tt.setupMock(mockRepo)
+
+			resp, err := svc.UpdateStatus(context.Background(), 1, 1, tt.req)
+			if tt.expectedStatus == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.req.Status, resp.Status)
+			} else {
+				assert.Equal(t, tt.expectedStatus, apperror.From(err).Status())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -5,12 +5,14 @@ import (
 
 	socialMediaEntity "go-backend/internal/modules/socialmedia/domain/entity"
 	userEntity "go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/pkg/seo"
 	"gorm.io/gorm"
 )
 
 type Profile struct {
 	ID           uint           `json:"id" gorm:"primaryKey"`
 	Name         string         `json:"name" gorm:"not null"`
+	Handle       string         `json:"handle" gorm:"not null"`
 	Bio          string         `json:"bio"`
 	ProfileImage string         `json:"profile_image"`
 	Email        string         `json:"email"`
@@ -22,4 +24,20 @@ type Profile struct {
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	SEO seo.Fields `json:"seo" gorm:"embedded"`
+}
+
+// SEOMeta returns the SEO metadata of the profile, with the fields its owner
+// left empty derived from its name, bio and profile image.
+func (p *Profile) SEOMeta() seo.Fields {
+	defaults := seo.Fields{
+		MetaTitle:       p.Name,
+		MetaDescription: seo.Description(p.Bio),
+		OGImage:         p.ProfileImage,
+	}
+	if p.Handle != "" {
+		defaults.CanonicalURL = seo.CanonicalURL("@" + p.Handle)
+	}
+	return p.SEO.WithDefaults(defaults)
 }
\ No newline at end of file
@@ -1,53 +1,85 @@
 package repository
 
 import (
+	"context"
 	"go-backend/internal/modules/profile/domain/entity"
+	redirectEntity "go-backend/internal/modules/redirect/domain/entity"
+	redirectRepository "go-backend/internal/modules/redirect/domain/repository"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 type ProfileRepository interface {
-	Create(profile *entity.Profile) error
-	GetByID(id uint) (*entity.Profile, error)
-	GetAll() ([]entity.Profile, error)
-	Update(profile *entity.Profile) error
-	Delete(id uint) error
-	GetByUserID(userID uint) ([]entity.Profile, error)
+	Create(ctx context.Context, profile *entity.Profile) error
+	GetByID(ctx context.Context, id uint) (*entity.Profile, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Profile, pagination.Meta, error)
+	Update(ctx context.Context, profile *entity.Profile) error
+	Delete(ctx context.Context, id uint) error
+	GetByUserID(ctx context.Context, userID uint) ([]entity.Profile, error)
+	HandleTaken(ctx context.Context, handle string, excludeID uint) (bool, error)
 }
 
 type profileRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	redirects redirectRepository.RedirectRepository
 }
 
 func NewProfileRepository(db *gorm.DB) ProfileRepository {
-	return &profileRepository{db: db}
+	return &profileRepository{db: db, redirects: redirectRepository.NewRedirectRepository(db)}
 }
 
-func (r *profileRepository) Create(profile *entity.Profile) error {
-	return r.db.Create(profile).Error
+func (r *profileRepository) Create(ctx context.Context, profile *entity.Profile) error {
+	return r.db.WithContext(ctx).Create(profile).Error
 }
 
-func (r *profileRepository) GetByID(id uint) (*entity.Profile, error) {
+func (r *profileRepository) GetByID(ctx context.Context, id uint) (*entity.Profile, error) {
 	var profile entity.Profile
-	err := r.db.First(&profile, id).Error
+	err := r.db.WithContext(ctx).First(&profile, id).Error
 	return &profile, err
 }
 
-func (r *profileRepository) GetAll() ([]entity.Profile, error) {
-	var profiles []entity.Profile
-	err := r.db.Find(&profiles).Error
-	return profiles, err
+func (r *profileRepository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Profile, pagination.Meta, error) {
+	return pagination.Find[entity.Profile](r.db.WithContext(ctx).Scopes(f.Scope), params)
 }
 
-func (r *profileRepository) Update(profile *entity.Profile) error {
-	return r.db.Save(profile).Error
+// Update saves profile. When its handle changed, the old handle redirects to
+// it.
+func (r *profileRepository) Update(ctx context.Context, profile *entity.Profile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var oldHandle string
+		if err := tx.Model(&entity.Profile{}).Where("id = ?", profile.ID).Pluck("handle", &oldHandle).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(profile).Error; err != nil {
+			return err
+		}
+		if oldHandle == "" || oldHandle == profile.Handle {
+			return nil
+		}
+		return redirectRepository.NewRedirectRepository(tx).Move(ctx, redirectEntity.ResourceProfile, oldHandle, profile.Handle, profile.ID)
+	})
 }
 
-func (r *profileRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Profile{}, id).Error
+func (r *profileRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.Profile{}, id).Error
 }
 
-func (r *profileRepository) GetByUserID(userID uint) ([]entity.Profile, error) {
+func (r *profileRepository) GetByUserID(ctx context.Context, userID uint) ([]entity.Profile, error) {
 	var profiles []entity.Profile
-	err := r.db.Where("user_id = ?", userID).Find(&profiles).Error
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&profiles).Error
 	return profiles, err
-}
\ No newline at end of file
+}
+
+// HandleTaken reports whether another profile than excludeID has handle, or
+// had it and is redirected from it.
+func (r *profileRepository) HandleTaken(ctx context.Context, handle string, excludeID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Profile{}).Where("handle = ? AND id <> ?", handle, excludeID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	return r.redirects.Taken(ctx, redirectEntity.ResourceProfile, handle, excludeID)
+}
@@ -1,20 +1,33 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"go-backend/internal/infrastructure/tracing"
 	"go-backend/internal/modules/profile/domain/entity"
 	"go-backend/internal/modules/profile/domain/repository"
 	"go-backend/internal/modules/profile/dto"
 	socialMediaDto "go-backend/internal/modules/socialmedia/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/slug"
 )
 
+// handleLength is the length of the longest handle, given or generated.
+const handleLength = 30
+
+// ErrHandleTaken is returned when a profile is given a handle that another
+// profile has, or had before it was renamed.
+var ErrHandleTaken = apperror.Conflict("handle is already taken")
+
 type ProfileService interface {
-	Create(profile *entity.Profile) (*dto.CreateProfileResponse, error)
-	GetByID(id uint) (*dto.ProfileResponse, error)
-	GetAll() ([]dto.ProfileResponse, error)
-	Update(id uint, userID uint, req *dto.UpdateProfileRequest) (*dto.UpdateProfileResponse, error)
-	Delete(id, userID uint) error
-	GetByUserID(userID uint) ([]dto.ProfileResponse, error)
+	Create(ctx context.Context, profile *entity.Profile) (*dto.CreateProfileResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.ProfileResponse, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.ProfileResponse, pagination.Meta, error)
+	Update(ctx context.Context, id uint, userID uint, req *dto.UpdateProfileRequest) (*dto.UpdateProfileResponse, error)
+	Delete(ctx context.Context, id, userID uint) error
+	GetByUserID(ctx context.Context, userID uint) ([]dto.ProfileResponse, error)
 }
 
 type profileService struct {
@@ -25,132 +38,81 @@ func NewProfileService(repo repository.ProfileRepository) ProfileService {
 	return &profileService{repo: repo}
 }
 
-func (s *profileService) Create(profile *entity.Profile) (*dto.CreateProfileResponse, error) {
-	if err := s.repo.Create(profile); err != nil {
+func (s *profileService) Create(ctx context.Context, profile *entity.Profile) (*dto.CreateProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProfileService.Create")
+	defer span.End()
+
+	if profile.Handle != "" {
+		if err := s.checkHandle(ctx, profile.Handle, 0); err != nil {
+			return nil, err
+		}
+	} else {
+		generated, err := s.uniqueHandle(ctx, profile.Name)
+		if err != nil {
+			return nil, err
+		}
+		profile.Handle = generated
+	}
+
+	if err := s.repo.Create(ctx, profile); err != nil {
 		return nil, err
 	}
 
 	return &dto.CreateProfileResponse{
 		ID:           profile.ID,
 		Name:         profile.Name,
+		Handle:       profile.Handle,
 		Bio:          profile.Bio,
 		ProfileImage: profile.ProfileImage,
 		Email:        profile.Email,
 		Phone:        profile.Phone,
 		Location:     profile.Location,
 		UserID:       profile.UserID,
+		SEO:          profile.SEOMeta(),
 	}, nil
 }
 
-func (s *profileService) GetByID(id uint) (*dto.ProfileResponse, error) {
-	profile, err := s.repo.GetByID(id)
-	if err != nil {
-		return nil, err
-	}
+func (s *profileService) GetByID(ctx context.Context, id uint) (*dto.ProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProfileService.GetByID")
+	defer span.End()
 
-	socialMediaResponses := make([]socialMediaDto.SocialMediaResponse, len(profile.SocialMedia))
-	for i, sm := range profile.SocialMedia {
-		socialMediaResponses[i] = socialMediaDto.SocialMediaResponse{
-			ID:        sm.ID,
-			Platform:  sm.Platform,
-			Url:       sm.Url,
-			ProfileID: sm.ProfileID,
-			UserID:    sm.UserID,
-			User: struct {
-				ID    uint   `json:"id"`
-				Name  string `json:"name"`
-				Email string `json:"email"`
-			}{
-				ID:    sm.User.ID,
-				Name:  sm.User.Name,
-				Email: sm.User.Email,
-			},
-		}
+	profile, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.NotFoundOr(err, "profile not found")
 	}
 
-	return &dto.ProfileResponse{
-		ID:           profile.ID,
-		Name:         profile.Name,
-		Bio:          profile.Bio,
-		ProfileImage: profile.ProfileImage,
-		Email:        profile.Email,
-		Phone:        profile.Phone,
-		Location:     profile.Location,
-		UserID:       profile.UserID,
-		SocialMedia:  socialMediaResponses,
-		User: struct {
-			ID    uint   `json:"id"`
-			Name  string `json:"name"`
-			Email string `json:"email"`
-		}{
-			ID:    profile.User.ID,
-			Name:  profile.User.Name,
-			Email: profile.User.Email,
-		},
-	}, nil
+	resp := toProfileResponse(profile)
+	return &resp, nil
 }
 
-func (s *profileService) GetAll() ([]dto.ProfileResponse, error) {
-	profiles, err := s.repo.GetAll()
+func (s *profileService) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.ProfileResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ProfileService.GetAll")
+	defer span.End()
+
+	profiles, meta, err := s.repo.GetAll(ctx, f, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.ProfileResponse, len(profiles))
-	for i, profile := range profiles {
-		socialMediaResponses := make([]socialMediaDto.SocialMediaResponse, len(profile.SocialMedia))
-		for j, sm := range profile.SocialMedia {
-			socialMediaResponses[j] = socialMediaDto.SocialMediaResponse{
-				ID:        sm.ID,
-				Platform:  sm.Platform,
-				Url:       sm.Url,
-				ProfileID: sm.ProfileID,
-				UserID:    sm.UserID,
-				User: struct {
-					ID    uint   `json:"id"`
-					Name  string `json:"name"`
-					Email string `json:"email"`
-				}{
-					ID:    sm.User.ID,
-					Name:  sm.User.Name,
-					Email: sm.User.Email,
-				},
-			}
-		}
-
-		response[i] = dto.ProfileResponse{
-			ID:           profile.ID,
-			Name:         profile.Name,
-			Bio:          profile.Bio,
-			ProfileImage: profile.ProfileImage,
-			Email:        profile.Email,
-			Phone:        profile.Phone,
-			Location:     profile.Location,
-			UserID:       profile.UserID,
-			SocialMedia:  socialMediaResponses,
-			User: struct {
-				ID    uint   `json:"id"`
-				Name  string `json:"name"`
-				Email string `json:"email"`
-			}{
-				ID:    profile.User.ID,
-				Name:  profile.User.Name,
-				Email: profile.User.Email,
-			},
-		}
+	for i := range profiles {
+		response[i] = toProfileResponse(&profiles[i])
 	}
 
-	return response, nil
+	return response, meta, nil
 }
 
-func (s *profileService) Update(id uint, userID uint, req *dto.UpdateProfileRequest) (*dto.UpdateProfileResponse, error) {
-	existing, err := s.repo.GetByID(id)
+func (s *profileService) Update(ctx context.Context, id uint, userID uint, req *dto.UpdateProfileRequest) (*dto.UpdateProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProfileService.Update")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "profile not found")
 	}
 
-	if existing.UserID != userID {
-		return nil, errors.New("unauthorized: you can only update your own profiles")
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "update", "profiles"); err != nil {
+		return nil, err
 	}
 
 	existing.Name = req.Name
@@ -159,85 +121,133 @@ func (s *profileService) Update(id uint, userID uint, req *dto.UpdateProfileRequ
 	existing.Email = req.Email
 	existing.Phone = req.Phone
 	existing.Location = req.Location
+	if req.Handle != "" && req.Handle != existing.Handle {
+		if err := s.checkHandle(ctx, req.Handle, existing.ID); err != nil {
+			return nil, err
+		}
+		existing.Handle = req.Handle
+	}
+	if req.SEO != nil {
+		existing.SEO = *req.SEO
+	}
 
-	if err := s.repo.Update(existing); err != nil {
+	if err := s.repo.Update(ctx, existing); err != nil {
 		return nil, err
 	}
 
 	return &dto.UpdateProfileResponse{
 		ID:           existing.ID,
 		Name:         existing.Name,
+		Handle:       existing.Handle,
 		Bio:          existing.Bio,
 		ProfileImage: existing.ProfileImage,
 		Email:        existing.Email,
 		Phone:        existing.Phone,
 		Location:     existing.Location,
 		UserID:       existing.UserID,
+		SEO:          existing.SEOMeta(),
 	}, nil
 }
 
-func (s *profileService) Delete(id, userID uint) error {
-	existing, err := s.repo.GetByID(id)
+func (s *profileService) Delete(ctx context.Context, id, userID uint) error {
+	ctx, span := tracing.Start(ctx, "ProfileService.Delete")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return apperror.NotFoundOr(err, "profile not found")
 	}
 
-	if existing.UserID != userID {
-		return errors.New("unauthorized: you can only delete your own profiles")
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "delete", "profiles"); err != nil {
+		return err
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(ctx, id)
 }
 
-func (s *profileService) GetByUserID(userID uint) ([]dto.ProfileResponse, error) {
-	profiles, err := s.repo.GetByUserID(userID)
+func (s *profileService) GetByUserID(ctx context.Context, userID uint) ([]dto.ProfileResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProfileService.GetByUserID")
+	defer span.End()
+
+	profiles, err := s.repo.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	response := make([]dto.ProfileResponse, len(profiles))
-	for i, profile := range profiles {
-		socialMediaResponses := make([]socialMediaDto.SocialMediaResponse, len(profile.SocialMedia))
-		for j, sm := range profile.SocialMedia {
-			socialMediaResponses[j] = socialMediaDto.SocialMediaResponse{
-				ID:        sm.ID,
-				Platform:  sm.Platform,
-				Url:       sm.Url,
-				ProfileID: sm.ProfileID,
-				UserID:    sm.UserID,
-				User: struct {
-					ID    uint   `json:"id"`
-					Name  string `json:"name"`
-					Email string `json:"email"`
-				}{
-					ID:    sm.User.ID,
-					Name:  sm.User.Name,
-					Email: sm.User.Email,
-				},
-			}
-		}
+	for i := range profiles {
+		response[i] = toProfileResponse(&profiles[i])
+	}
 
-		response[i] = dto.ProfileResponse{
-			ID:           profile.ID,
-			Name:         profile.Name,
-			Bio:          profile.Bio,
-			ProfileImage: profile.ProfileImage,
-			Email:        profile.Email,
-			Phone:        profile.Phone,
-			Location:     profile.Location,
-			UserID:       profile.UserID,
-			SocialMedia:  socialMediaResponses,
+	return response, nil
+}
+
+// checkHandle returns ErrHandleTaken when another profile than id has
+// candidate.
+func (s *profileService) checkHandle(ctx context.Context, candidate string, id uint) error {
+	taken, err := s.repo.HandleTaken(ctx, candidate, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrHandleTaken
+	}
+	return nil
+}
+
+// uniqueHandle returns a handle made from name that no profile has, numbered
+// when the plain one is taken.
+func (s *profileService) uniqueHandle(ctx context.Context, name string) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = "profile"
+	}
+	return slug.Unique(base, handleLength, func(candidate string) (bool, error) {
+		return s.repo.HandleTaken(ctx, candidate, 0)
+	})
+}
+
+func toProfileResponse(profile *entity.Profile) dto.ProfileResponse {
+	socialMediaResponses := make([]socialMediaDto.SocialMediaResponse, len(profile.SocialMedia))
+	for i, sm := range profile.SocialMedia {
+		socialMediaResponses[i] = socialMediaDto.SocialMediaResponse{
+			ID:        sm.ID,
+			Platform:  sm.Platform,
+			Url:       sm.Url,
+			ProfileID: sm.ProfileID,
+			UserID:    sm.UserID,
 			User: struct {
 				ID    uint   `json:"id"`
 				Name  string `json:"name"`
 				Email string `json:"email"`
 			}{
-				ID:    profile.User.ID,
-				Name:  profile.User.Name,
-				Email: profile.User.Email,
+				ID:    sm.User.ID,
+				Name:  sm.User.Name,
+				Email: sm.User.Email,
 			},
 		}
 	}
 
-	return response, nil
-}
\ No newline at end of file
+	return dto.ProfileResponse{
+		ID:           profile.ID,
+		Name:         profile.Name,
+		Handle:       profile.Handle,
+		Bio:          profile.Bio,
+		ProfileImage: profile.ProfileImage,
+		Email:        profile.Email,
+		Phone:        profile.Phone,
+		Location:     profile.Location,
+		UserID:       profile.UserID,
+		SEO:          profile.SEOMeta(),
+		SocialMedia:  socialMediaResponses,
+		User: struct {
+			ID    uint   `json:"id"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}{
+			ID:    profile.User.ID,
+			Name:  profile.User.Name,
+			Email: profile.User.Email,
+		},
+	}
+}
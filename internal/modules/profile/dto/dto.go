@@ -2,57 +2,93 @@ package dto
 
 import (
 	socialMediaDto "go-backend/internal/modules/socialmedia/dto"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/seo"
 )
 
+// ListOptions are the sorts of profile lists, newest first by default.
+var ListOptions = pagination.Options{
+	Sortable:    []string{"id", "name", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// FilterFields are the fields that profile lists can be filtered by.
+var FilterFields = filter.Fields{
+	"id":         {Column: "id", Type: filter.Integer},
+	"name":       {Column: "name", Type: filter.String},
+	"location":   {Column: "location", Type: filter.String},
+	"user_id":    {Column: "user_id", Type: filter.Integer},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+
+	"handle": {Column: "handle", Type: filter.String},
+}
+
 type CreateProfileRequest struct {
-	Name         string `json:"name" binding:"required"`
-	Bio          string `json:"bio"`
-	ProfileImage string `json:"profile_image"`
-	Email        string `json:"email"`
-	Phone        string `json:"phone"`
-	Location     string `json:"location"`
+	Name         string `json:"name" binding:"required,max=255"`
+	Bio          string `json:"bio" binding:"max=5000"`
+	ProfileImage string `json:"profile_image" binding:"omitempty,httpurl,max=2048"`
+	Email        string `json:"email" binding:"omitempty,email,max=255"`
+	Phone        string `json:"phone" binding:"omitempty,max=32"`
+	Location     string `json:"location" binding:"max=255"`
+	// Handle is made from Name unless given.
+	Handle string     `json:"handle" binding:"omitempty,slug,max=30"`
+	SEO    seo.Fields `json:"seo"`
 }
 
 type CreateProfileResponse struct {
 	ID           uint   `json:"id"`
 	Name         string `json:"name"`
+	Handle       string `json:"handle"`
 	Bio          string `json:"bio"`
 	ProfileImage string `json:"profile_image"`
 	Email        string `json:"email"`
 	Phone        string `json:"phone"`
 	Location     string `json:"location"`
 	UserID       uint   `json:"user_id"`
+
+	SEO seo.Fields `json:"seo"`
 }
 
 type UpdateProfileRequest struct {
-	Name         string `json:"name" binding:"required"`
-	Bio          string `json:"bio"`
-	ProfileImage string `json:"profile_image"`
-	Email        string `json:"email"`
-	Phone        string `json:"phone"`
-	Location     string `json:"location"`
+	Name         string `json:"name" binding:"required,max=255"`
+	Bio          string `json:"bio" binding:"max=5000"`
+	ProfileImage string `json:"profile_image" binding:"omitempty,httpurl,max=2048"`
+	Email        string `json:"email" binding:"omitempty,email,max=255"`
+	Phone        string `json:"phone" binding:"omitempty,max=32"`
+	Location     string `json:"location" binding:"max=255"`
+	// Handle renames the profile. Its old handle redirects to it.
+	Handle string `json:"handle" binding:"omitempty,slug,max=30"`
+	// SEO replaces the SEO metadata of the profile when given.
+	SEO *seo.Fields `json:"seo"`
 }
 
 type UpdateProfileResponse struct {
 	ID           uint   `json:"id"`
 	Name         string `json:"name"`
+	Handle       string `json:"handle"`
 	Bio          string `json:"bio"`
 	ProfileImage string `json:"profile_image"`
 	Email        string `json:"email"`
 	Phone        string `json:"phone"`
 	Location     string `json:"location"`
 	UserID       uint   `json:"user_id"`
+
+	SEO seo.Fields `json:"seo"`
 }
 
 type ProfileResponse struct {
 	ID           uint                             `json:"id"`
 	Name         string                           `json:"name"`
+	Handle       string                           `json:"handle"`
 	Bio          string                           `json:"bio"`
 	ProfileImage string                           `json:"profile_image"`
 	Email        string                           `json:"email"`
 	Phone        string                           `json:"phone"`
 	Location     string                           `json:"location"`
 	UserID       uint                             `json:"user_id"`
+	SEO          seo.Fields                       `json:"seo"`
 	SocialMedia  []socialMediaDto.SocialMediaResponse `json:"social_media,omitempty"`
 	User         struct {
 		ID    uint   `json:"id"`
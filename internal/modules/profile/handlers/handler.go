@@ -8,15 +8,12 @@ import (
 	"go-backend/internal/modules/profile/domain/entity"
 	"go-backend/internal/modules/profile/domain/service"
 	"go-backend/internal/modules/profile/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 )
 
-type Response struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
-
 type ProfileHandler struct {
 	service service.ProfileService
 }
@@ -25,25 +22,16 @@ func NewProfileHandler(service service.ProfileService) *ProfileHandler {
 	return &ProfileHandler{service: service}
 }
 
-func formatResponse(status int, message string, data interface{}, err string) Response {
-	return Response{
-		Status:  status,
-		Message: message,
-		Data:    data,
-		Error:   err,
-	}
-}
-
 func (h *ProfileHandler) Create(c *gin.Context) {
 	var req dto.CreateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -55,104 +43,117 @@ func (h *ProfileHandler) Create(c *gin.Context) {
 		Phone:        req.Phone,
 		Location:     req.Location,
 		UserID:       userID.(uint),
+		Handle:       req.Handle,
+		SEO:          req.SEO,
 	}
 
-	response, err := h.service.Create(profile)
+	resp, err := h.service.Create(c.Request.Context(), profile)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to create profile", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, formatResponse(http.StatusCreated, "Profile created successfully", response, ""))
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "Profile created successfully", resp))
 }
 
 func (h *ProfileHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	response, err := h.service.GetByID(uint(id))
+	resp, err := h.service.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Profile not found", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Profile retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Profile retrieved successfully", resp))
 }
 
 func (h *ProfileHandler) GetAll(c *gin.Context) {
-	response, err := h.service.GetAll()
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, meta, err := h.service.GetAll(c.Request.Context(), f, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve profiles", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Profiles retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Profiles retrieved successfully", resp, meta))
 }
 
 func (h *ProfileHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var req dto.UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	response, err := h.service.Update(uint(id), userID.(uint), &req)
+	resp, err := h.service.Update(c.Request.Context(), uint(id), userID.(uint), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to update profile", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Profile updated successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Profile updated successfully", resp))
 }
 
 func (h *ProfileHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	if err := h.service.Delete(uint(id), userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to delete profile", nil, err.Error()))
+	if err := h.service.Delete(c.Request.Context(), uint(id), userID.(uint)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Profile deleted successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Profile deleted successfully", nil))
 }
 
 func (h *ProfileHandler) GetByUserID(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	response, err := h.service.GetByUserID(userID.(uint))
+	resp, err := h.service.GetByUserID(c.Request.Context(), userID.(uint))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve user's profiles", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User's profiles retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User's profiles retrieved successfully", resp))
 }
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS profiles (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    bio text,
+    profile_image text,
+    email text,
+    phone text,
+    location text,
+    user_id bigint NOT NULL CONSTRAINT fk_profiles_user REFERENCES users (id),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_profiles_user_id ON profiles (user_id);
+CREATE INDEX IF NOT EXISTS idx_profiles_deleted_at ON profiles (deleted_at);
@@ -0,0 +1,6 @@
+ALTER TABLE profiles DROP COLUMN IF EXISTS og_image;
+ALTER TABLE profiles DROP COLUMN IF EXISTS canonical_url;
+ALTER TABLE profiles DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE profiles DROP COLUMN IF EXISTS meta_title;
+DROP INDEX IF EXISTS idx_profiles_handle;
+ALTER TABLE profiles DROP COLUMN IF EXISTS handle;
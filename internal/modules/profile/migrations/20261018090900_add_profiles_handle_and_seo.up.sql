@@ -0,0 +1,36 @@
+-- Existing rows get the handle of their name, made like the service makes it
+-- except that accented letters are dropped rather than unaccented. Rows whose
+-- name has no letters or digits get their ID appended, and later rows sharing
+-- a handle are numbered -2, -3, ... with the first number that is free.
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS handle text;
+UPDATE profiles SET handle = trim(both '-' from left(trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')), 30))
+    WHERE handle IS NULL;
+UPDATE profiles SET handle = 'profile-' || id WHERE handle = '';
+DO $$
+DECLARE
+    r record;
+    candidate text;
+    n int;
+BEGIN
+    FOR r IN
+        SELECT t.id, t.handle FROM profiles t
+        WHERE EXISTS (SELECT 1 FROM profiles o WHERE o.handle = t.handle AND o.id < t.id)
+        ORDER BY t.id
+    LOOP
+        n := 2;
+        LOOP
+            candidate := rtrim(left(r.handle, 30 - length('-' || n)), '-') || '-' || n;
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM profiles WHERE handle = candidate);
+            n := n + 1;
+        END LOOP;
+        UPDATE profiles SET handle = candidate WHERE id = r.id;
+    END LOOP;
+END $$;
+ALTER TABLE profiles ALTER COLUMN handle SET NOT NULL;
+-- Deleted rows give up their handle.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_profiles_handle ON profiles (handle) WHERE deleted_at IS NULL;
+
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS meta_title text NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS meta_description text NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS canonical_url text NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS og_image text NOT NULL DEFAULT '';
@@ -15,7 +15,7 @@ func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
 		// Protected routes
 		protected := profiles.Use(middleware.JWTAuth(middleware.AccessToken))
 		{
-			protected.POST("", m.Handler.Create)
+			protected.POST("", middleware.RequireVerifiedEmail(), m.Handler.Create)
 			protected.PUT("/:id", m.Handler.Update)
 			protected.DELETE("/:id", m.Handler.Delete)
 		}
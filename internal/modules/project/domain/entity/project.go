@@ -5,6 +5,8 @@ import (
 
 	imageEntity "go-backend/internal/modules/images/domain/entity"
 	userEntity "go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/seo"
 
 	"gorm.io/gorm"
 )
@@ -12,6 +14,7 @@ import (
 type Project struct {
 	ID          uint                 `json:"id" gorm:"primaryKey"`
 	Name        string               `json:"name" gorm:"not null"`
+	Slug        string               `json:"slug" gorm:"not null"`
 	Description string               `json:"description"`
 	Url         string               `json:"url"`
 	UserID      uint                 `json:"user_id" gorm:"not null"`
@@ -20,4 +23,21 @@ type Project struct {
 	CreatedAt   time.Time            `json:"created_at"`
 	UpdatedAt   time.Time            `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt       `json:"-" gorm:"index"`
+
+	publishing.State
+	SEO seo.Fields `json:"seo" gorm:"embedded"`
+}
+
+// SEOMeta returns the SEO metadata of the project, with the fields its owner
+// left empty derived from its name, description and first image.
+func (p *Project) SEOMeta() seo.Fields {
+	defaults := seo.Fields{
+		MetaTitle:       p.Name,
+		MetaDescription: seo.Description(p.Description),
+		CanonicalURL:    seo.CanonicalURL("projects", p.Slug),
+	}
+	if len(p.Images) > 0 {
+		defaults.OGImage = p.Images[0].URL
+	}
+	return p.SEO.WithDefaults(defaults)
 }
@@ -1,53 +1,89 @@
 package repository
 
 import (
+	"context"
 	"go-backend/internal/modules/project/domain/entity"
+	redirectEntity "go-backend/internal/modules/redirect/domain/entity"
+	redirectRepository "go-backend/internal/modules/redirect/domain/repository"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/publishing"
 	"gorm.io/gorm"
 )
 
 type ProjectRepository interface {
-	Create(project *entity.Project) error
-	GetByID(id uint) (*entity.Project, error)
-	GetAll() ([]entity.Project, error)
-	Update(project *entity.Project) error
-	Delete(id uint) error
-	GetByUserID(userID uint) ([]entity.Project, error)
+	Create(ctx context.Context, project *entity.Project) error
+	GetByID(ctx context.Context, id uint) (*entity.Project, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Project, pagination.Meta, error)
+	Update(ctx context.Context, project *entity.Project) error
+	Delete(ctx context.Context, id uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Project, pagination.Meta, error)
+	GetByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]entity.Project, pagination.Meta, error)
+	SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error)
 }
 
 type projectRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	redirects redirectRepository.RedirectRepository
 }
 
 func NewProjectRepository(db *gorm.DB) ProjectRepository {
-	return &projectRepository{db: db}
+	return &projectRepository{db: db, redirects: redirectRepository.NewRedirectRepository(db)}
 }
 
-func (r *projectRepository) Create(project *entity.Project) error {
-	return r.db.Create(project).Error
+func (r *projectRepository) Create(ctx context.Context, project *entity.Project) error {
+	return r.db.WithContext(ctx).Create(project).Error
 }
 
-func (r *projectRepository) GetByID(id uint) (*entity.Project, error) {
+func (r *projectRepository) GetByID(ctx context.Context, id uint) (*entity.Project, error) {
 	var project entity.Project
-	err := r.db.First(&project, id).Error
+	err := r.db.WithContext(ctx).First(&project, id).Error
 	return &project, err
 }
 
-func (r *projectRepository) GetAll() ([]entity.Project, error) {
-	var projects []entity.Project
-	err := r.db.Find(&projects).Error
-	return projects, err
+func (r *projectRepository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Project, pagination.Meta, error) {
+	return pagination.Find[entity.Project](r.db.WithContext(ctx).Scopes(publishing.Public, f.Scope), params)
 }
 
-func (r *projectRepository) Update(project *entity.Project) error {
-	return r.db.Save(project).Error
+// Update saves project. When its slug changed, the old slug redirects to it.
+func (r *projectRepository) Update(ctx context.Context, project *entity.Project) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var oldSlug string
+		if err := tx.Model(&entity.Project{}).Where("id = ?", project.ID).Pluck("slug", &oldSlug).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(project).Error; err != nil {
+			return err
+		}
+		if oldSlug == "" || oldSlug == project.Slug {
+			return nil
+		}
+		return redirectRepository.NewRedirectRepository(tx).Move(ctx, redirectEntity.ResourceProject, oldSlug, project.Slug, project.ID)
+	})
 }
 
-func (r *projectRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Project{}, id).Error
+func (r *projectRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.Project{}, id).Error
 }
 
-func (r *projectRepository) GetByUserID(userID uint) ([]entity.Project, error) {
-	var projects []entity.Project
-	err := r.db.Where("user_id = ?", userID).Find(&projects).Error
-	return projects, err
-}
\ No newline at end of file
+func (r *projectRepository) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Project, pagination.Meta, error) {
+	return pagination.Find[entity.Project](r.db.WithContext(ctx).Scopes(publishing.Public).Where("user_id = ?", userID), params)
+}
+
+// GetByOwner returns the projects of userID in every status.
+func (r *projectRepository) GetByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]entity.Project, pagination.Meta, error) {
+	return pagination.Find[entity.Project](r.db.WithContext(ctx).Scopes(f.Scope).Where("user_id = ?", userID), params)
+}
+
+// SlugTaken reports whether another project than excludeID has slug, or had
+// it and is redirected from it.
+func (r *projectRepository) SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Project{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	return r.redirects.Taken(ctx, redirectEntity.ResourceProject, slug, excludeID)
+}
@@ -1,20 +1,36 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"time"
+
+	"go-backend/internal/infrastructure/tracing"
 	imageEntity "go-backend/internal/modules/images/domain/entity"
 	"go-backend/internal/modules/project/domain/entity"
 	"go-backend/internal/modules/project/domain/repository"
 	"go-backend/internal/modules/project/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/slug"
 )
 
+// ErrSlugTaken is returned when a project is given a slug that another
+// project has, or had before it was renamed.
+var ErrSlugTaken = apperror.Conflict("slug is already taken")
+
 type ProjectService interface {
-	Create(project *entity.Project) (*dto.CreateProjectResponse, error)
-	GetByID(id uint) (*dto.ProjectResponse, error)
-	GetAll() ([]dto.ProjectResponse, error)
-	Update(id uint, userID uint, req *dto.UpdateProjectRequest) (*dto.UpdateProjectResponse, error)
-	Delete(id, userID uint) error
-	GetByUserID(userID uint) ([]dto.ProjectResponse, error)
+	Create(ctx context.Context, project *entity.Project) (*dto.CreateProjectResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.ProjectResponse, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error)
+	Update(ctx context.Context, id uint, userID uint, req *dto.UpdateProjectRequest) (*dto.UpdateProjectResponse, error)
+	Delete(ctx context.Context, id, userID uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error)
+	GetByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error)
+	Preview(ctx context.Context, id, userID uint) (*dto.ProjectResponse, error)
+	UpdateStatus(ctx context.Context, id, userID uint, req *dto.UpdateStatusRequest) (*dto.ProjectResponse, error)
 }
 
 type projectService struct {
@@ -25,7 +41,32 @@ func NewProjectService(repo repository.ProjectRepository) ProjectService {
 	return &projectService{repo: repo}
 }
 
-func (s *projectService) Create(project *entity.Project) (*dto.CreateProjectResponse, error) {
+func (s *projectService) Create(ctx context.Context, project *entity.Project) (*dto.CreateProjectResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.Create")
+	defer span.End()
+
+	// The requested state is checked as a change from a new draft
+	requested := project.State
+	project.State = publishing.State{}
+	if requested.Status == "" {
+		requested.Status = publishing.Draft
+	}
+	if err := project.Change(requested.Status, requested.PublishedAt, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if project.Slug != "" {
+		if err := s.checkSlug(ctx, project.Slug, 0); err != nil {
+			return nil, err
+		}
+	} else {
+		generated, err := s.uniqueSlug(ctx, project.Name)
+		if err != nil {
+			return nil, err
+		}
+		project.Slug = generated
+	}
+
 	// Create images if provided
 	if len(project.Images) > 0 {
 		for i := range project.Images {
@@ -33,7 +74,7 @@ func (s *projectService) Create(project *entity.Project) (*dto.CreateProjectResp
 		}
 	}
 
-	if err := s.repo.Create(project); err != nil {
+	if err := s.repo.Create(ctx, project); err != nil {
 		return nil, err
 	}
 
@@ -46,109 +87,92 @@ func (s *projectService) Create(project *entity.Project) (*dto.CreateProjectResp
 	return &dto.CreateProjectResponse{
 		ID:          project.ID,
 		Name:        project.Name,
+		Slug:        project.Slug,
 		Description: project.Description,
 		Url:         project.Url,
 		UserID:      project.UserID,
 		ImageURLs:   imageURLs,
+		Status:      project.Status,
+		PublishedAt: project.PublishedAt,
+		SEO:         project.SEOMeta(),
 	}, nil
 }
 
-func (s *projectService) GetByID(id uint) (*dto.ProjectResponse, error) {
-	project, err := s.repo.GetByID(id)
-	if err != nil {
-		return nil, err
-	}
+func (s *projectService) GetByID(ctx context.Context, id uint) (*dto.ProjectResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetByID")
+	defer span.End()
 
-	// Extract image URLs for response
-	imageURLs := make([]string, len(project.Images))
-	for i, img := range project.Images {
-		imageURLs[i] = img.URL
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.NotFoundOr(err, "project not found")
 	}
 
-	resp := &dto.ProjectResponse{
-		ID:          project.ID,
-		Name:        project.Name,
-		Description: project.Description,
-		Url:         project.Url,
-		UserID:      project.UserID,
-		ImageURLs:   imageURLs,
-		User: struct {
-			ID    uint   `json:"id"`
-			Name  string `json:"name"`
-			Email string `json:"email"`
-		}{
-			ID:    project.User.ID,
-			Name:  project.User.Name,
-			Email: project.User.Email,
-		},
+	// Unpublished projects are only shown to their owner, through Preview
+	if !project.IsPublished() {
+		return nil, apperror.NotFound("project not found")
 	}
 
-	return resp, nil
+	resp := toProjectResponse(project)
+	return &resp, nil
 }
 
-func (s *projectService) GetAll() ([]dto.ProjectResponse, error) {
-	projects, err := s.repo.GetAll()
+func (s *projectService) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetAll")
+	defer span.End()
+
+	projects, meta, err := s.repo.GetAll(ctx, f, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.ProjectResponse, len(projects))
-	for i, project := range projects {
-		// Extract image URLs for response
-		imageURLs := make([]string, len(project.Images))
-		for j, img := range project.Images {
-			imageURLs[j] = img.URL
-		}
-
-		response[i] = dto.ProjectResponse{
-			ID:          project.ID,
-			Name:        project.Name,
-			Description: project.Description,
-			Url:         project.Url,
-			UserID:      project.UserID,
-			ImageURLs:   imageURLs,
-			User: struct {
-				ID    uint   `json:"id"`
-				Name  string `json:"name"`
-				Email string `json:"email"`
-			}{
-				ID:    project.User.ID,
-				Name:  project.User.Name,
-				Email: project.User.Email,
-			},
-		}
+	for i := range projects {
+		response[i] = toProjectResponse(&projects[i])
 	}
 
-	return response, nil
+	return response, meta, nil
 }
 
-func (s *projectService) Update(id uint, userID uint, req *dto.UpdateProjectRequest) (*dto.UpdateProjectResponse, error) {
-	project, err := s.repo.GetByID(id)
+func (s *projectService) Update(ctx context.Context, id uint, userID uint, req *dto.UpdateProjectRequest) (*dto.UpdateProjectResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.Update")
+	defer span.End()
+
+	project, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "project not found")
 	}
 
-	if project.UserID != userID {
-		return nil, errors.New("unauthorized: you can only update your own projects")
+	if err := authz.RequireOwner(ctx, userID, project.UserID, "update", "projects"); err != nil {
+		return nil, err
 	}
 
 	project.Name = req.Name
 	project.Description = req.Description
 	project.Url = req.Url
+	if req.Slug != "" && req.Slug != project.Slug {
+		if err := s.checkSlug(ctx, req.Slug, project.ID); err != nil {
+			return nil, err
+		}
+		project.Slug = req.Slug
+	}
+	if req.SEO != nil {
+		project.SEO = *req.SEO
+	}
 
-	// Update images if provided
+	// Update images if provided. They belong to the owner, also when an
+	// admin makes the change.
 	if len(req.ImageURLs) > 0 {
 		images := make([]imageEntity.Images, len(req.ImageURLs))
 		for i, url := range req.ImageURLs {
 			images[i] = imageEntity.Images{
 				URL:       url,
-				UserID:    userID,
+				UserID:    project.UserID,
 			}
 		}
 		project.Images = images
 	}
 
-	if err := s.repo.Update(project); err != nil {
+	if err := s.repo.Update(ctx, project); err != nil {
 		return nil, err
 	}
 
@@ -161,58 +185,162 @@ func (s *projectService) Update(id uint, userID uint, req *dto.UpdateProjectRequ
 	return &dto.UpdateProjectResponse{
 		ID:          project.ID,
 		Name:        project.Name,
+		Slug:        project.Slug,
 		Description: project.Description,
 		Url:         project.Url,
 		UserID:      project.UserID,
 		ImageURLs:   imageURLs,
+		Status:      project.Status,
+		PublishedAt: project.PublishedAt,
+		SEO:         project.SEOMeta(),
 	}, nil
 }
 
-func (s *projectService) Delete(id, userID uint) error {
-	existing, err := s.repo.GetByID(id)
+func (s *projectService) Delete(ctx context.Context, id, userID uint) error {
+	ctx, span := tracing.Start(ctx, "ProjectService.Delete")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		return apperror.NotFoundOr(err, "project not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "delete", "projects"); err != nil {
 		return err
 	}
 
-	if existing.UserID != userID {
-		return errors.New("unauthorized: you can only delete your own projects")
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *projectService) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetByUserID")
+	defer span.End()
+
+	projects, meta, err := s.repo.GetByUserID(ctx, userID, params)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	response := make([]dto.ProjectResponse, len(projects))
+	for i := range projects {
+		response[i] = toProjectResponse(&projects[i])
 	}
 
-	return s.repo.Delete(id)
+	return response, meta, nil
 }
 
-func (s *projectService) GetByUserID(userID uint) ([]dto.ProjectResponse, error) {
-	projects, err := s.repo.GetByUserID(userID)
+// GetByOwner returns the projects of userID in every status, for their
+// owner.
+func (s *projectService) GetByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetByOwner")
+	defer span.End()
+
+	projects, meta, err := s.repo.GetByOwner(ctx, userID, f, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.ProjectResponse, len(projects))
-	for i, project := range projects {
-		// Extract image URLs for response
-		imageURLs := make([]string, len(project.Images))
-		for j, img := range project.Images {
-			imageURLs[j] = img.URL
-		}
+	for i := range projects {
+		response[i] = toProjectResponse(&projects[i])
+	}
 
-		response[i] = dto.ProjectResponse{
-			ID:          project.ID,
-			Name:        project.Name,
-			Description: project.Description,
-			Url:         project.Url,
-			UserID:      project.UserID,
-			ImageURLs:   imageURLs,
-			User: struct {
-				ID    uint   `json:"id"`
-				Name  string `json:"name"`
-				Email string `json:"email"`
-			}{
-				ID:    project.User.ID,
-				Name:  project.User.Name,
-				Email: project.User.Email,
-			},
-		}
+	return response, meta, nil
+}
+
+// Preview returns a project in any status to its owner.
+func (s *projectService) Preview(ctx context.Context, id, userID uint) (*dto.ProjectResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.Preview")
+	defer span.End()
+
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.NotFoundOr(err, "project not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, project.UserID, "preview", "projects"); err != nil {
+		return nil, err
+	}
+
+	resp := toProjectResponse(project)
+	return &resp, nil
+}
+
+func (s *projectService) UpdateStatus(ctx context.Context, id, userID uint, req *dto.UpdateStatusRequest) (*dto.ProjectResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.UpdateStatus")
+	defer span.End()
+
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.NotFoundOr(err, "project not found")
+	}
+
+	if err := authz.RequireOwner(ctx, userID, project.UserID, "publish", "projects"); err != nil {
+		return nil, err
+	}
+
+	if err := project.Change(req.Status, req.PublishedAt, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+
+	resp := toProjectResponse(project)
+	return &resp, nil
+}
+
+// checkSlug returns ErrSlugTaken when another project than id has candidate.
+func (s *projectService) checkSlug(ctx context.Context, candidate string, id uint) error {
+	taken, err := s.repo.SlugTaken(ctx, candidate, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// uniqueSlug returns a slug made from name that no project has, numbered when
+// the plain one is taken.
+func (s *projectService) uniqueSlug(ctx context.Context, name string) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = "project"
 	}
+	return slug.Unique(base, slug.MaxLength, func(candidate string) (bool, error) {
+		return s.repo.SlugTaken(ctx, candidate, 0)
+	})
+}
 
-	return response, nil
-}
\ No newline at end of file
+func toProjectResponse(project *entity.Project) dto.ProjectResponse {
+	// Extract image URLs for response
+	imageURLs := make([]string, len(project.Images))
+	for i, img := range project.Images {
+		imageURLs[i] = img.URL
+	}
+
+	return dto.ProjectResponse{
+		ID:          project.ID,
+		Name:        project.Name,
+		Slug:        project.Slug,
+		Description: project.Description,
+		Url:         project.Url,
+		UserID:      project.UserID,
+		ImageURLs:   imageURLs,
+		Status:      project.Status,
+		PublishedAt: project.PublishedAt,
+		SEO:         project.SEOMeta(),
+		User: struct {
+			ID    uint   `json:"id"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}{
+			ID:    project.User.ID,
+			Name:  project.User.Name,
+			Email: project.User.Email,
+		},
+	}
+}
@@ -1,47 +1,106 @@
 package dto
 
+import (
+	"time"
+
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/seo"
+)
+
+// ListOptions are the sorts of projects lists, newest first by default.
+var ListOptions = pagination.Options{
+	Sortable:    []string{"id", "name", "published_at", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// FilterFields are the fields that projects lists can be filtered by.
+var FilterFields = filter.Fields{
+	"id":         {Column: "id", Type: filter.Integer},
+	"name":       {Column: "name", Type: filter.String},
+	"url":        {Column: "url", Type: filter.String},
+	"user_id":    {Column: "user_id", Type: filter.Integer},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+
+	"status":       {Column: "status", Type: filter.String},
+	"published_at": {Column: "published_at", Type: filter.Time},
+
+	"slug": {Column: "slug", Type: filter.String},
+}
+
 type CreateProjectRequest struct {
-	Name        string   `json:"name" binding:"required"`
-	Description string   `json:"description"`
-	Url         string   `json:"url"`
-	ImageURLs   []string `json:"image_urls,omitempty"`
+	Name        string   `json:"name" binding:"required,max=255"`
+	Description string   `json:"description" binding:"max=5000"`
+	Url         string   `json:"url" binding:"omitempty,httpurl,max=2048"`
+	ImageURLs   []string `json:"image_urls,omitempty" binding:"max=20,dive,httpurl"`
+	// Status is draft unless given. A scheduled project needs PublishedAt.
+	Status      string     `json:"status" binding:"omitempty,publishstatus"`
+	PublishedAt *time.Time `json:"published_at"`
+	// Slug is made from Name unless given.
+	Slug string     `json:"slug" binding:"omitempty,slug,max=80"`
+	SEO  seo.Fields `json:"seo"`
 }
 
 type CreateProjectResponse struct {
-	ID          uint     `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Url         string   `json:"url"`
-	UserID      uint     `json:"user_id"`
-	ImageURLs   []string `json:"image_urls,omitempty"`
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Url         string     `json:"url"`
+	UserID      uint       `json:"user_id"`
+	ImageURLs   []string   `json:"image_urls,omitempty"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	SEO         seo.Fields `json:"seo"`
 }
 
 type UpdateProjectRequest struct {
-	Name        string   `json:"name" binding:"required"`
-	Description string   `json:"description"`
-	Url         string   `json:"url"`
-	ImageURLs   []string `json:"image_urls,omitempty"`
+	Name        string   `json:"name" binding:"required,max=255"`
+	Description string   `json:"description" binding:"max=5000"`
+	Url         string   `json:"url" binding:"omitempty,httpurl,max=2048"`
+	ImageURLs   []string `json:"image_urls,omitempty" binding:"max=20,dive,httpurl"`
+	// Slug renames the project. Its old slug redirects to it.
+	Slug string `json:"slug" binding:"omitempty,slug,max=80"`
+	// SEO replaces the SEO metadata of the project when given.
+	SEO *seo.Fields `json:"seo"`
 }
 
 type UpdateProjectResponse struct {
-	ID          uint     `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Url         string   `json:"url"`
-	UserID      uint     `json:"user_id"`
-	ImageURLs   []string `json:"image_urls,omitempty"`
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Url         string     `json:"url"`
+	UserID      uint       `json:"user_id"`
+	ImageURLs   []string   `json:"image_urls,omitempty"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	SEO         seo.Fields `json:"seo"`
 }
 
 type ProjectResponse struct {
-	ID          uint     `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Url         string   `json:"url"`
-	UserID      uint     `json:"user_id"`
-	ImageURLs   []string `json:"image_urls,omitempty"`
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	Url         string     `json:"url"`
+	UserID      uint       `json:"user_id"`
+	ImageURLs   []string   `json:"image_urls,omitempty"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+	SEO         seo.Fields `json:"seo"`
 	User        struct {
 		ID    uint   `json:"id"`
 		Name  string `json:"name"`
 		Email string `json:"email"`
 	} `json:"user,omitempty"`
 }
+
+// UpdateStatusRequest moves a project through the publishing workflow.
+// PublishedAt is required to schedule the project, and backdates it when it
+// is published.
+type UpdateStatusRequest struct {
+	Status      string     `json:"status" binding:"required,publishstatus"`
+	PublishedAt *time.Time `json:"published_at"`
+}
@@ -10,16 +10,13 @@ import (
 	"go-backend/internal/modules/project/dto"
 
 	"github.com/gin-gonic/gin"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 	"gorm.io/gorm"
 )
 
-type Response struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
-
 type ProjectHandler struct {
 	service service.ProjectService
 	db      *gorm.DB
@@ -32,25 +29,16 @@ func NewProjectHandler(service service.ProjectService, db *gorm.DB) *ProjectHand
 	}
 }
 
-func formatResponse(status int, message string, data interface{}, err string) Response {
-	return Response{
-		Status:  status,
-		Message: message,
-		Data:    data,
-		Error:   err,
-	}
-}
-
 func (h *ProjectHandler) Create(c *gin.Context) {
 	var req dto.CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -60,6 +48,10 @@ func (h *ProjectHandler) Create(c *gin.Context) {
 		Url:         req.Url,
 		UserID:      userID.(uint),
 	}
+	project.Status = req.Status
+	project.PublishedAt = req.PublishedAt
+	project.Slug = req.Slug
+	project.SEO = req.SEO
 
 	// Create images if provided
 	if len(req.ImageURLs) > 0 {
@@ -75,102 +67,175 @@ func (h *ProjectHandler) Create(c *gin.Context) {
 		project.Images = images
 	}
 
-	resp, err := h.service.Create(project)
+	resp, err := h.service.Create(c.Request.Context(), project)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to create project", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, formatResponse(http.StatusCreated, "Project created successfully", resp, ""))
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "Project created successfully", resp))
 }
 
 func (h *ProjectHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	resp, err := h.service.GetByID(uint(id))
+	resp, err := h.service.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Project not found", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Project retrieved successfully", resp, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Project retrieved successfully", resp))
 }
 
 func (h *ProjectHandler) GetAll(c *gin.Context) {
-	response, err := h.service.GetAll()
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve projects", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Projects retrieved successfully", response, ""))
+	resp, meta, err := h.service.GetAll(c.Request.Context(), f, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Projects retrieved successfully", resp, meta))
 }
 
 func (h *ProjectHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var req dto.UpdateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	resp, err := h.service.Update(uint(id), userID.(uint), &req)
+	resp, err := h.service.Update(c.Request.Context(), uint(id), userID.(uint), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to update project", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Project updated successfully", resp, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Project updated successfully", resp))
 }
 
 func (h *ProjectHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	if err := h.service.Delete(uint(id), userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to delete project", nil, err.Error()))
+	if err := h.service.Delete(c.Request.Context(), uint(id), userID.(uint)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Project deleted successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Project deleted successfully", nil))
 }
 
+// GetByUserID lists the projects of the authenticated user in every status.
 func (h *ProjectHandler) GetByUserID(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, meta, err := h.service.GetByOwner(c.Request.Context(), userID.(uint), f, params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "User's projects retrieved successfully", resp, meta))
+}
+
+func (h *ProjectHandler) Preview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid ID format"))
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	resp, err := h.service.Preview(c.Request.Context(), uint(id), userID.(uint))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Project retrieved successfully", resp))
+}
+
+func (h *ProjectHandler) UpdateStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid ID format"))
+		return
+	}
+
+	var req dto.UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	response, err := h.service.GetByUserID(userID.(uint))
+	resp, err := h.service.UpdateStatus(c.Request.Context(), uint(id), userID.(uint), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve user's projects", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User's projects retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Project status updated successfully", resp))
 }
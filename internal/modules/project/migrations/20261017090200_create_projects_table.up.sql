@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    description text,
+    url text,
+    user_id bigint NOT NULL CONSTRAINT fk_projects_user REFERENCES users (id),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at);
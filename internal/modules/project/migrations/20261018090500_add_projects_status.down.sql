@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_projects_status_published_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS published_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS status;
@@ -0,0 +1,8 @@
+-- Existing projects were public, so they are published as of their creation.
+-- New projects start as drafts.
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'published';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS published_at timestamptz;
+UPDATE projects SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+ALTER TABLE projects ALTER COLUMN status SET DEFAULT 'draft';
+-- Serves both the public listings and the scheduler's lookup of due projects.
+CREATE INDEX IF NOT EXISTS idx_projects_status_published_at ON projects (status, published_at);
@@ -0,0 +1,6 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS og_image;
+ALTER TABLE projects DROP COLUMN IF EXISTS canonical_url;
+ALTER TABLE projects DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE projects DROP COLUMN IF EXISTS meta_title;
+DROP INDEX IF EXISTS idx_projects_slug;
+ALTER TABLE projects DROP COLUMN IF EXISTS slug;
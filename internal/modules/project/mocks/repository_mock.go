@@ -2,42 +2,55 @@ package mocks
 
 import (
 	"github.com/stretchr/testify/mock"
+	"context"
 	"go-backend/internal/modules/project/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type MockProjectRepository struct {
 	mock.Mock
 }
 
-func (m *MockProjectRepository) Create(project *entity.Project) error {
-	args := m.Called(project)
+func (m *MockProjectRepository) Create(ctx context.Context, project *entity.Project) error {
+	args := m.Called(ctx, project)
 	return args.Error(0)
 }
 
-func (m *MockProjectRepository) GetByID(id uint) (*entity.Project, error) {
-	args := m.Called(id)
+func (m *MockProjectRepository) GetByID(ctx context.Context, id uint) (*entity.Project, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Project), args.Error(1)
 }
 
-func (m *MockProjectRepository) GetAll() ([]entity.Project, error) {
-	args := m.Called()
-	return args.Get(0).([]entity.Project), args.Error(1)
+func (m *MockProjectRepository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Project, pagination.Meta, error) {
+	args := m.Called(ctx, f, params)
+	return args.Get(0).([]entity.Project), args.Get(1).(pagination.Meta), args.Error(2)
 }
 
-func (m *MockProjectRepository) Update(project *entity.Project) error {
-	args := m.Called(project)
+func (m *MockProjectRepository) Update(ctx context.Context, project *entity.Project) error {
+	args := m.Called(ctx, project)
 	return args.Error(0)
 }
 
-func (m *MockProjectRepository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockProjectRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockProjectRepository) GetByUserID(userID uint) ([]entity.Project, error) {
-	args := m.Called(userID)
-	return args.Get(0).([]entity.Project), args.Error(1)
-}
\ No newline at end of file
+func (m *MockProjectRepository) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Project, pagination.Meta, error) {
+	args := m.Called(ctx, userID, params)
+	return args.Get(0).([]entity.Project), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockProjectRepository) GetByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]entity.Project, pagination.Meta, error) {
+	args := m.Called(ctx, userID, f, params)
+	return args.Get(0).([]entity.Project), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockProjectRepository) SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
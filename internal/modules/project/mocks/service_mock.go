@@ -2,49 +2,73 @@ package mocks
 
 import (
 	"github.com/stretchr/testify/mock"
+	"context"
 	"go-backend/internal/modules/project/domain/entity"
 	"go-backend/internal/modules/project/dto"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type MockProjectService struct {
 	mock.Mock
 }
 
-func (m *MockProjectService) Create(project *entity.Project) (*dto.CreateProjectResponse, error) {
-	args := m.Called(project)
+func (m *MockProjectService) Create(ctx context.Context, project *entity.Project) (*dto.CreateProjectResponse, error) {
+	args := m.Called(ctx, project)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.CreateProjectResponse), args.Error(1)
 }
 
-func (m *MockProjectService) GetByID(id uint) (*dto.ProjectResponse, error) {
-	args := m.Called(id)
+func (m *MockProjectService) GetByID(ctx context.Context, id uint) (*dto.ProjectResponse, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.ProjectResponse), args.Error(1)
 }
 
-func (m *MockProjectService) GetAll() ([]dto.ProjectResponse, error) {
-	args := m.Called()
-	return args.Get(0).([]dto.ProjectResponse), args.Error(1)
+func (m *MockProjectService) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error) {
+	args := m.Called(ctx, f, params)
+	return args.Get(0).([]dto.ProjectResponse), args.Get(1).(pagination.Meta), args.Error(2)
 }
 
-func (m *MockProjectService) Update(id uint, userID uint, req *dto.UpdateProjectRequest) (*dto.UpdateProjectResponse, error) {
-	args := m.Called(id, userID, req)
+func (m *MockProjectService) Update(ctx context.Context, id uint, userID uint, req *dto.UpdateProjectRequest) (*dto.UpdateProjectResponse, error) {
+	args := m.Called(ctx, id, userID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.UpdateProjectResponse), args.Error(1)
 }
 
-func (m *MockProjectService) Delete(id, userID uint) error {
-	args := m.Called(id, userID)
+func (m *MockProjectService) Delete(ctx context.Context, id, userID uint) error {
+	args := m.Called(ctx, id, userID)
 	return args.Error(0)
 }
 
-func (m *MockProjectService) GetByUserID(userID uint) ([]dto.ProjectResponse, error) {
-	args := m.Called(userID)
-	return args.Get(0).([]dto.ProjectResponse), args.Error(1)
-}
\ No newline at end of file
+func (m *MockProjectService) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error) {
+	args := m.Called(ctx, userID, params)
+	return args.Get(0).([]dto.ProjectResponse), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockProjectService) GetByOwner(ctx context.Context, userID uint, f filter.Filter, params pagination.Params) ([]dto.ProjectResponse, pagination.Meta, error) {
+	args := m.Called(ctx, userID, f, params)
+	return args.Get(0).([]dto.ProjectResponse), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockProjectService) Preview(ctx context.Context, id, userID uint) (*dto.ProjectResponse, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectResponse), args.Error(1)
+}
+
+func (m *MockProjectService) UpdateStatus(ctx context.Context, id, userID uint, req *dto.UpdateStatusRequest) (*dto.ProjectResponse, error) {
+	args := m.Called(ctx, id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProjectResponse), args.Error(1)
+}
@@ -4,6 +4,8 @@ import (
 	"go-backend/internal/modules/project/domain/repository"
 	"go-backend/internal/modules/project/domain/service"
 	"go-backend/internal/modules/project/handlers"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/validation"
 	"gorm.io/gorm"
 )
 
@@ -12,6 +14,8 @@ type Module struct {
 }
 
 func NewModule(db *gorm.DB) *Module {
+	validation.RegisterEnum("publishstatus", publishing.Statuses...)
+
 	repo := repository.NewProjectRepository(db)
 	svc := service.NewProjectService(repo)
 	handler := handlers.NewProjectHandler(svc, db)
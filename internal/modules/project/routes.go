@@ -3,6 +3,7 @@ package project
 import (
 	"github.com/gin-gonic/gin"
 	"go-backend/internal/infrastructure/middleware"
+	"go-backend/internal/modules/user/domain/entity"
 )
 
 func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
@@ -12,12 +13,17 @@ func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
 		projects.GET("", m.Handler.GetAll)
 		projects.GET("/:id", m.Handler.GetByID)
 
-		// Protected routes
-		protected := projects.Use(middleware.JWTAuth(middleware.AccessToken))
+		// Protected routes - access token or API key with projects:write
+		protected := projects.Group("", middleware.JWTOrAPIKeyAuth(entity.ScopeProjectsWrite))
 		{
 			protected.POST("", m.Handler.Create)
 			protected.PUT("/:id", m.Handler.Update)
 			protected.DELETE("/:id", m.Handler.Delete)
+
+			// Owner routes, which see projects in every status
+			protected.GET("/mine", m.Handler.GetByUserID)
+			protected.GET("/:id/preview", m.Handler.Preview)
+			protected.PUT("/:id/status", m.Handler.UpdateStatus)
 		}
 	}
 }
\ No newline at end of file
@@ -11,9 +11,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"go-backend/internal/infrastructure/middleware"
 	"go-backend/internal/modules/project/dto"
 	"go-backend/internal/modules/project/handlers"
 	"go-backend/internal/modules/project/mocks"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/validation"
 )
 
 func TestCreateProject(t *testing.T) {
@@ -21,7 +24,10 @@ func TestCreateProject(t *testing.T) {
 	handler := handlers.NewProjectHandler(mockService, nil)
 
 	gin.SetMode(gin.TestMode)
+	validation.Setup()
+	validation.RegisterEnum("publishstatus", publishing.Statuses...)
 	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
 	router.POST("/projects", handler.Create)
 
 	tests := []struct {
@@ -38,7 +44,7 @@ func TestCreateProject(t *testing.T) {
 				Description: "Test Description",
 			},
 			setupMock: func() {
-				mockService.On("Create", mock.AnythingOfType("*entity.Project")).
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("*entity.Project")).
 					Return(&dto.CreateProjectResponse{
 						ID:          1,
 						Name:        "Test Project",
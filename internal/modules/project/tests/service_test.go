@@ -1,7 +1,10 @@
 package tests
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -10,11 +13,14 @@ import (
 	"go-backend/internal/modules/project/domain/service"
 	"go-backend/internal/modules/project/dto"
 	"go-backend/internal/modules/project/mocks"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/publishing"
 )
 
 func TestCreateProjectService(t *testing.T) {
 	mockRepo := new(mocks.MockProjectRepository)
 	svc := service.NewProjectService(mockRepo)
+	mockRepo.On("SlugTaken", mock.Anything, mock.Anything, uint(0)).Return(false, nil)
 
 	tests := []struct {
 		name             string
@@ -30,7 +36,7 @@ func TestCreateProjectService(t *testing.T) {
 				Description: "Test Description",
 			},
 			setupMock: func() {
-				mockRepo.On("Create", mock.AnythingOfType("*entity.Project")).
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Project")).
 					Return(nil)
 			},
 			expectedResponse: &dto.CreateProjectResponse{
@@ -38,6 +44,20 @@ func TestCreateProjectService(t *testing.T) {
 				Name:        "Test Project",
 				Description: "Test Description",
 				UserID:      0,
+				Status:      publishing.Draft,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Published",
+			input: &entity.Project{
+				Name:  "Launched Project",
+				State: publishing.State{Status: publishing.Published},
+			},
+			setupMock: func() {},
+			expectedResponse: &dto.CreateProjectResponse{
+				Name:   "Launched Project",
+				Status: publishing.Published,
 			},
 			expectedError: nil,
 		},
@@ -48,14 +68,70 @@ func TestCreateProjectService(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			resp, err := svc.Create(tt.input)
+			resp, err := svc.Create(context.Background(), tt.input)
 			assert.Equal(t, tt.expectedError, err)
 			if tt.expectedError == nil {
 				assert.NotNil(t, resp)
 				assert.Equal(t, tt.expectedResponse.Name, resp.Name)
 				assert.Equal(t, tt.expectedResponse.Description, resp.Description)
+				assert.Equal(t, tt.expectedResponse.Status, resp.Status)
 			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestProjectService_Publishing(t *testing.T) {
+	mockRepo := new(mocks.MockProjectRepository)
+	svc := service.NewProjectService(mockRepo)
+	project := &entity.Project{ID: 1, Name: "Draft", UserID: 1, State: publishing.State{Status: publishing.Draft}}
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(project, nil)
+
+	// A draft is only shown to its owner
+	_, err := svc.GetByID(context.Background(), 1)
+	assert.Equal(t, http.StatusNotFound, apperror.From(err).Status())
+
+	resp, err := svc.Preview(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, publishing.Draft, resp.Status)
+
+	// Publishing it makes it public
+	mockRepo.On("Update", mock.Anything, project).Return(nil)
+	resp, err = svc.UpdateStatus(context.Background(), 1, 1, &dto.UpdateStatusRequest{Status: publishing.Published})
+	assert.NoError(t, err)
+	assert.Equal(t, publishing.Published, resp.Status)
+	assert.WithinDuration(t, time.Now(), *resp.PublishedAt, time.Minute)
+
+	_, err = svc.GetByID(context.Background(), 1)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProjectService_Slug(t *testing.T) {
+	mockRepo := new(mocks.MockProjectRepository)
+	svc := service.NewProjectService(mockRepo)
+
+	// A taken slug is refused when given, and numbered when generated
+	mockRepo.On("SlugTaken", mock.Anything, "my-project", uint(0)).Return(true, nil)
+	mockRepo.On("SlugTaken", mock.Anything, "my-project-2", uint(0)).Return(false, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Project")).Return(nil)
+
+	_, err := svc.Create(context.Background(), &entity.Project{Name: "Other", Slug: "my-project"})
+	assert.ErrorIs(t, err, service.ErrSlugTaken)
+
+	resp, err := svc.Create(context.Background(), &entity.Project{Name: "My Project"})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-project-2", resp.Slug)
+	assert.Equal(t, "My Project", resp.SEO.MetaTitle)
+
+	// Renaming checks the new slug against the other projects
+	project := &entity.Project{ID: 1, Name: "My Project", Slug: "my-project", UserID: 1}
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(project, nil)
+	mockRepo.On("SlugTaken", mock.Anything, "renamed", uint(1)).Return(false, nil)
+	mockRepo.On("Update", mock.Anything, project).Return(nil)
+
+	updated, err := svc.Update(context.Background(), 1, 1, &dto.UpdateProjectRequest{Name: "My Project", Slug: "renamed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", updated.Slug)
+	mockRepo.AssertExpectations(t)
+}
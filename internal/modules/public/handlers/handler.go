@@ -1,254 +1,433 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"path"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	experienceEntity "go-backend/internal/modules/experience/domain/entity"
+	experienceDTO "go-backend/internal/modules/experience/dto"
 	postEntity "go-backend/internal/modules/post/domain/entity"
+	postDTO "go-backend/internal/modules/post/dto"
 	profileEntity "go-backend/internal/modules/profile/domain/entity"
+	profileDTO "go-backend/internal/modules/profile/dto"
 	projectEntity "go-backend/internal/modules/project/domain/entity"
+	projectDTO "go-backend/internal/modules/project/dto"
+	redirectEntity "go-backend/internal/modules/redirect/domain/entity"
+	redirectRepository "go-backend/internal/modules/redirect/domain/repository"
 	socialMediaEntity "go-backend/internal/modules/socialmedia/domain/entity"
+	socialMediaDTO "go-backend/internal/modules/socialmedia/dto"
 	toolEntity "go-backend/internal/modules/tool/domain/entity"
+	toolDTO "go-backend/internal/modules/tool/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/publishing"
+	"go-backend/internal/pkg/response"
 	"gorm.io/gorm"
 )
 
 type PublicHandler struct {
-	db *gorm.DB
+	db        *gorm.DB
+	redirects redirectRepository.RedirectRepository
 }
 
 func NewPublicHandler(db *gorm.DB) *PublicHandler {
 	return &PublicHandler{
-		db: db,
+		db:        db,
+		redirects: redirectRepository.NewRedirectRepository(db),
 	}
 }
 
-// formatResponse formats the response to a standard format
-func formatResponse(statusCode int, message string, data interface{}, error string) gin.H {
-	return gin.H{
-		"status":  statusCode,
-		"message": message,
-		"data":    data,
-		"error":   error,
+// GetProfiles handles retrieving a page of profiles
+func (h *PublicHandler) GetProfiles(c *gin.Context) {
+	params, err := pagination.Parse(c.Request.URL.Query(), profileDTO.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), profileDTO.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-}
 
-// GetProfiles handles retrieving all profiles
-func (h *PublicHandler) GetProfiles(c *gin.Context) {
-	var profiles []profileEntity.Profile
-	result := h.db.Find(&profiles)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve profiles", nil, result.Error.Error()))
+	profiles, meta, err := pagination.Find[profileEntity.Profile](h.db.WithContext(c.Request.Context()).Scopes(f.Scope), params)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	for i := range profiles {
+		profiles[i].SEO = profiles[i].SEOMeta()
+	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Profiles retrieved successfully", profiles, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Profiles retrieved successfully", profiles, meta))
 }
 
 // GetProfileByID handles retrieving a profile by ID
 func (h *PublicHandler) GetProfileByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var profile profileEntity.Profile
-	result := h.db.First(&profile, id)
+	result := h.db.WithContext(c.Request.Context()).First(&profile, id)
 	if result.Error != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Profile not found", nil, result.Error.Error()))
+		c.Error(apperror.NotFoundOr(result.Error, "Profile not found"))
+		return
+	}
+	profile.SEO = profile.SEOMeta()
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Profile retrieved successfully", profile))
+}
+
+// GetProfileByHandle handles retrieving a profile by handle. Old handles of
+// the profile redirect to its current one.
+func (h *PublicHandler) GetProfileByHandle(c *gin.Context) {
+	profile, ok := h.profileByHandle(c)
+	if !ok {
 		return
 	}
+	profile.SEO = profile.SEOMeta()
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Profile retrieved successfully", profile, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Profile retrieved successfully", profile))
 }
 
-// GetPosts handles retrieving all posts
+// GetPosts handles retrieving a page of posts
 func (h *PublicHandler) GetPosts(c *gin.Context) {
-	var posts []postEntity.Post
-	result := h.db.Find(&posts)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve posts", nil, result.Error.Error()))
+	params, err := pagination.Parse(c.Request.URL.Query(), postDTO.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), postDTO.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	posts, meta, err := pagination.Find[postEntity.Post](h.db.WithContext(c.Request.Context()).Scopes(publishing.Public, f.Scope), params)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	for i := range posts {
+		posts[i].SEO = posts[i].SEOMeta()
+	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Posts retrieved successfully", posts, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Posts retrieved successfully", posts, meta))
 }
 
 // GetPostByID handles retrieving a post by ID
 func (h *PublicHandler) GetPostByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var post postEntity.Post
-	result := h.db.First(&post, id)
+	result := h.db.WithContext(c.Request.Context()).Scopes(publishing.Public).Preload("Images").First(&post, id)
 	if result.Error != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Post not found", nil, result.Error.Error()))
+		c.Error(apperror.NotFoundOr(result.Error, "Post not found"))
+		return
+	}
+	post.SEO = post.SEOMeta()
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Post retrieved successfully", post))
+}
+
+// GetPostBySlug handles retrieving a post by slug. Old slugs of the post
+// redirect to its current one.
+func (h *PublicHandler) GetPostBySlug(c *gin.Context) {
+	db := h.db.WithContext(c.Request.Context())
+
+	var post postEntity.Post
+	err := db.Scopes(publishing.Public).Preload("Images").Where("slug = ?", c.Param("slug")).First(&post).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		h.redirectOr(c, redirectEntity.ResourcePost, db.Model(&postEntity.Post{}).Scopes(publishing.Public), "slug", "Post not found")
+		return
+	}
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	post.SEO = post.SEOMeta()
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Post retrieved successfully", post, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Post retrieved successfully", post))
 }
 
-// GetProjects handles retrieving all projects
+// GetProjects handles retrieving a page of projects
 func (h *PublicHandler) GetProjects(c *gin.Context) {
-	var projects []projectEntity.Project
-	result := h.db.Find(&projects)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve projects", nil, result.Error.Error()))
+	params, err := pagination.Parse(c.Request.URL.Query(), projectDTO.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), projectDTO.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	projects, meta, err := pagination.Find[projectEntity.Project](h.db.WithContext(c.Request.Context()).Scopes(publishing.Public, f.Scope), params)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	for i := range projects {
+		projects[i].SEO = projects[i].SEOMeta()
+	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Projects retrieved successfully", projects, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Projects retrieved successfully", projects, meta))
 }
 
 // GetProjectByID handles retrieving a project by ID
 func (h *PublicHandler) GetProjectByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var project projectEntity.Project
-	result := h.db.First(&project, id)
+	result := h.db.WithContext(c.Request.Context()).Scopes(publishing.Public).Preload("Images").First(&project, id)
 	if result.Error != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Project not found", nil, result.Error.Error()))
+		c.Error(apperror.NotFoundOr(result.Error, "Project not found"))
+		return
+	}
+	project.SEO = project.SEOMeta()
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Project retrieved successfully", project))
+}
+
+// GetProjectBySlug handles retrieving a project by slug. Old slugs of the
+// project redirect to its current one.
+func (h *PublicHandler) GetProjectBySlug(c *gin.Context) {
+	db := h.db.WithContext(c.Request.Context())
+
+	var project projectEntity.Project
+	err := db.Scopes(publishing.Public).Preload("Images").Where("slug = ?", c.Param("slug")).First(&project).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		h.redirectOr(c, redirectEntity.ResourceProject, db.Model(&projectEntity.Project{}).Scopes(publishing.Public), "slug", "Project not found")
+		return
+	}
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	project.SEO = project.SEOMeta()
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Project retrieved successfully", project, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Project retrieved successfully", project))
 }
 
-// GetSocialMedia handles retrieving all social media
+// GetSocialMedia handles retrieving a page of social media
 func (h *PublicHandler) GetSocialMedia(c *gin.Context) {
-	var socialMedia []socialMediaEntity.SocialMedia
-	result := h.db.Find(&socialMedia)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve social media", nil, result.Error.Error()))
+	params, err := pagination.Parse(c.Request.URL.Query(), socialMediaDTO.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), socialMediaDTO.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	socialMedia, meta, err := pagination.Find[socialMediaEntity.SocialMedia](h.db.WithContext(c.Request.Context()).Scopes(f.Scope), params)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Social media retrieved successfully", socialMedia, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Social media retrieved successfully", socialMedia, meta))
 }
 
 // GetSocialMediaByID handles retrieving a social media by ID
 func (h *PublicHandler) GetSocialMediaByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var socialMedia socialMediaEntity.SocialMedia
-	result := h.db.First(&socialMedia, id)
+	result := h.db.WithContext(c.Request.Context()).First(&socialMedia, id)
 	if result.Error != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Social media not found", nil, result.Error.Error()))
+		c.Error(apperror.NotFoundOr(result.Error, "Social media not found"))
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Social media retrieved successfully", socialMedia, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Social media retrieved successfully", socialMedia))
 }
 
-// GetTools handles retrieving all tools
+// GetTools handles retrieving a page of tools
 func (h *PublicHandler) GetTools(c *gin.Context) {
-	var tools []toolEntity.Tool
-	result := h.db.Find(&tools)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve tools", nil, result.Error.Error()))
+	params, err := pagination.Parse(c.Request.URL.Query(), toolDTO.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), toolDTO.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	tools, meta, err := pagination.Find[toolEntity.Tool](h.db.WithContext(c.Request.Context()).Scopes(f.Scope), params)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Tools retrieved successfully", tools, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Tools retrieved successfully", tools, meta))
 }
 
 // GetToolByID handles retrieving a tool by ID
 func (h *PublicHandler) GetToolByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var tool toolEntity.Tool
-	result := h.db.First(&tool, id)
+	result := h.db.WithContext(c.Request.Context()).First(&tool, id)
 	if result.Error != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Tool not found", nil, result.Error.Error()))
+		c.Error(apperror.NotFoundOr(result.Error, "Tool not found"))
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Tool retrieved successfully", tool, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Tool retrieved successfully", tool))
 }
 
-// GetExperiences handles retrieving all experiences
+// GetExperiences handles retrieving a page of experiences
 func (h *PublicHandler) GetExperiences(c *gin.Context) {
-	var experiences []experienceEntity.Experience
-	result := h.db.Find(&experiences)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve experiences", nil, result.Error.Error()))
+	params, err := pagination.Parse(c.Request.URL.Query(), experienceDTO.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), experienceDTO.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	experiences, meta, err := pagination.Find[experienceEntity.Experience](h.db.WithContext(c.Request.Context()).Scopes(f.Scope), params)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Experiences retrieved successfully", experiences, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Experiences retrieved successfully", experiences, meta))
 }
 
 // GetExperienceByID handles retrieving an experience by ID
 func (h *PublicHandler) GetExperienceByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var experience experienceEntity.Experience
-	result := h.db.First(&experience, id)
+	result := h.db.WithContext(c.Request.Context()).First(&experience, id)
 	if result.Error != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Experience not found", nil, result.Error.Error()))
+		c.Error(apperror.NotFoundOr(result.Error, "Experience not found"))
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Experience retrieved successfully", experience, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Experience retrieved successfully", experience))
 }
 
 // GetPortfolio handles retrieving a complete portfolio for a user
 func (h *PublicHandler) GetPortfolio(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid User ID", nil, "Invalid User ID format"))
+		c.Error(apperror.BadRequest("Invalid User ID format"))
 		return
 	}
 
 	// Get user profile
 	var profile profileEntity.Profile
-	result := h.db.Where("user_id = ?", userID).First(&profile)
+	result := h.db.WithContext(c.Request.Context()).Where("user_id = ?", userID).First(&profile)
 	if result.Error != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Profile not found", nil, result.Error.Error()))
+		c.Error(apperror.NotFoundOr(result.Error, "Profile not found"))
+		return
+	}
+
+	h.portfolio(c, &profile)
+}
+
+// GetPortfolioByHandle handles retrieving a complete portfolio by the handle
+// of its profile. Old handles redirect to the current one.
+func (h *PublicHandler) GetPortfolioByHandle(c *gin.Context) {
+	profile, ok := h.profileByHandle(c)
+	if !ok {
 		return
 	}
 
+	h.portfolio(c, profile)
+}
+
+// portfolio responds with the portfolio of the user of profile.
+func (h *PublicHandler) portfolio(c *gin.Context, profile *profileEntity.Profile) {
+	// The queries share the request context so that they appear in its trace.
+	db := h.db.WithContext(c.Request.Context())
+	userID := profile.UserID
+
+	// Each list shows its first page, like the portfolio module does, and
+	// only published posts and projects
+	byUser := db.Where("user_id = ?", userID)
+	publishedByUser := db.Scopes(publishing.Public).Where("user_id = ?", userID)
+
 	// Get user posts
-	var posts []postEntity.Post
-	h.db.Where("user_id = ?", userID).Find(&posts)
+	posts, _, err := pagination.Find[postEntity.Post](publishedByUser, pagination.FirstPage(postDTO.ListOptions))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range posts {
+		posts[i].SEO = posts[i].SEOMeta()
+	}
 
 	// Get user projects
-	var projects []projectEntity.Project
-	h.db.Where("user_id = ?", userID).Find(&projects)
+	projects, _, err := pagination.Find[projectEntity.Project](publishedByUser, pagination.FirstPage(projectDTO.ListOptions))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range projects {
+		projects[i].SEO = projects[i].SEOMeta()
+	}
 
 	// Get user social media
-	var socialMedia []socialMediaEntity.SocialMedia
-	h.db.Where("user_id = ?", userID).Find(&socialMedia)
+	socialMedia, _, err := pagination.Find[socialMediaEntity.SocialMedia](byUser, pagination.FirstPage(socialMediaDTO.ListOptions))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// Get user tools
-	var tools []toolEntity.Tool
-	h.db.Where("user_id = ?", userID).Find(&tools)
+	tools, _, err := pagination.Find[toolEntity.Tool](byUser, pagination.FirstPage(toolDTO.ListOptions))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// Get user experiences
-	var experiences []experienceEntity.Experience
-	h.db.Where("user_id = ?", userID).Find(&experiences)
+	experiences, _, err := pagination.Find[experienceEntity.Experience](byUser, pagination.FirstPage(experienceDTO.ListOptions))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	profile.SEO = profile.SEOMeta()
 
 	// Create portfolio response
 	portfolio := gin.H{
@@ -260,5 +439,53 @@ func (h *PublicHandler) GetPortfolio(c *gin.Context) {
 		"experiences":  experiences,
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Portfolio retrieved successfully", portfolio, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Portfolio retrieved successfully", portfolio))
+}
+
+// profileByHandle returns the profile with the handle of the request. When
+// it has none, it responds with a redirect from an old handle or an error,
+// and reports false.
+func (h *PublicHandler) profileByHandle(c *gin.Context) (*profileEntity.Profile, bool) {
+	db := h.db.WithContext(c.Request.Context())
+
+	var profile profileEntity.Profile
+	err := db.Where("handle = ?", c.Param("handle")).First(&profile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		h.redirectOr(c, redirectEntity.ResourceProfile, db.Model(&profileEntity.Profile{}), "handle", "Profile not found")
+		return nil, false
+	}
+	if err != nil {
+		c.Error(err)
+		return nil, false
+	}
+	return &profile, true
+}
+
+// redirectOr responds to a request for a former slug of resource, read from
+// the param named column, with a permanent redirect to the same URL with the
+// current slug in its place. The current slug is read from column of the
+// target in model. It responds with a not found error when the slug was never
+// used or its record is gone or hidden.
+func (h *PublicHandler) redirectOr(c *gin.Context, resource string, model *gorm.DB, column, notFound string) {
+	redirect, err := h.redirects.Find(c.Request.Context(), resource, c.Param(column))
+	if err != nil {
+		c.Error(apperror.NotFoundOr(err, notFound))
+		return
+	}
+
+	var current []string
+	if err := model.Where("id = ?", redirect.TargetID).Pluck(column, &current).Error; err != nil {
+		c.Error(err)
+		return
+	}
+	if len(current) == 0 {
+		c.Error(apperror.NotFound(notFound))
+		return
+	}
+
+	// The slug is the last segment of the path
+	target := *c.Request.URL
+	target.Path = path.Join(path.Dir(target.Path), current[0])
+	target.RawPath = ""
+	c.Redirect(http.StatusMovedPermanently, target.RequestURI())
 }
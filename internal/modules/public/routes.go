@@ -10,24 +10,28 @@ func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		// Portfolio endpoint - gets everything for a user
 		public.GET("/portfolio/:user_id", m.Handler.GetPortfolio)
+		public.GET("/portfolio/handle/:handle", m.Handler.GetPortfolioByHandle)
 
 		// Individual resource endpoints
 		profiles := public.Group("/profiles")
 		{
 			profiles.GET("", m.Handler.GetProfiles)
 			profiles.GET("/:id", m.Handler.GetProfileByID)
+			profiles.GET("/handle/:handle", m.Handler.GetProfileByHandle)
 		}
 
 		posts := public.Group("/posts")
 		{
 			posts.GET("", m.Handler.GetPosts)
 			posts.GET("/:id", m.Handler.GetPostByID)
+			posts.GET("/slug/:slug", m.Handler.GetPostBySlug)
 		}
 
 		projects := public.Group("/projects")
 		{
 			projects.GET("", m.Handler.GetProjects)
 			projects.GET("/:id", m.Handler.GetProjectByID)
+			projects.GET("/slug/:slug", m.Handler.GetProjectBySlug)
 		}
 
 		socialMedia := public.Group("/social-media")
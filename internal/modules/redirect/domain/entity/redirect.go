@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Resources whose slugs are redirected.
+const (
+	ResourcePost    = "post"
+	ResourceProject = "project"
+	ResourceProfile = "profile"
+)
+
+// Redirect points a former slug of a resource at the record that had it.
+type Redirect struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Resource  string    `json:"resource" gorm:"not null;uniqueIndex:idx_redirects_resource_slug"`
+	Slug      string    `json:"slug" gorm:"not null;uniqueIndex:idx_redirects_resource_slug"`
+	TargetID  uint      `json:"target_id" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-backend/internal/modules/redirect/domain/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RedirectRepository interface {
+	Find(ctx context.Context, resource, slug string) (*entity.Redirect, error)
+	Taken(ctx context.Context, resource, slug string, targetID uint) (bool, error)
+	Move(ctx context.Context, resource, from, to string, targetID uint) error
+}
+
+type redirectRepository struct {
+	db *gorm.DB
+}
+
+func NewRedirectRepository(db *gorm.DB) RedirectRepository {
+	return &redirectRepository{db: db}
+}
+
+func (r *redirectRepository) Find(ctx context.Context, resource, slug string) (*entity.Redirect, error) {
+	var redirect entity.Redirect
+	err := r.db.WithContext(ctx).Where("resource = ? AND slug = ?", resource, slug).First(&redirect).Error
+	if err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// Taken reports whether slug redirects to another record than targetID, so
+// that it may not be given to a new one.
+func (r *redirectRepository) Taken(ctx context.Context, resource, slug string, targetID uint) (bool, error) {
+	var redirect entity.Redirect
+	err := r.db.WithContext(ctx).Select("target_id").Where("resource = ? AND slug = ?", resource, slug).First(&redirect).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return redirect.TargetID != targetID, nil
+}
+
+// Move records that targetID was renamed from one slug to another. The old
+// slug redirects to it, and the new one, which may have redirected to it
+// before, no longer does.
+func (r *redirectRepository) Move(ctx context.Context, resource, from, to string, targetID uint) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Where("resource = ? AND slug = ?", resource, to).Delete(&entity.Redirect{}).Error; err != nil {
+		return err
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "resource"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_id", "created_at"}),
+	}).Create(&entity.Redirect{Resource: resource, Slug: from, TargetID: targetID}).Error
+}
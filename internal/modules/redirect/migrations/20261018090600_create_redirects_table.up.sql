@@ -0,0 +1,10 @@
+-- Old slugs of posts and projects, and old handles of profiles, so that links
+-- to them keep working after they are renamed.
+CREATE TABLE IF NOT EXISTS redirects (
+    id bigserial PRIMARY KEY,
+    resource text NOT NULL,
+    slug text NOT NULL,
+    target_id bigint NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_redirects_resource_slug ON redirects (resource, slug);
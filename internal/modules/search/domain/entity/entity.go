@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+// Types of searchable resources.
+const (
+	TypePost       = "post"
+	TypeProject    = "project"
+	TypeExperience = "experience"
+	TypeTool       = "tool"
+)
+
+// Types lists every searchable type.
+var Types = []string{TypePost, TypeProject, TypeExperience, TypeTool}
+
+// Query is a full-text search.
+type Query struct {
+	Text string
+	// Types limits the results to some types; all types are searched when
+	// it is empty. Facets always count every type.
+	Types []string
+	// UserID limits the search to one user's resources when it is set.
+	UserID uint
+	Limit  int
+	Offset int
+}
+
+// Hit is a resource that matches a query. Title and Snippet hold the
+// matches between the StartSel and StopSel markers of the repository.
+type Hit struct {
+	Type      string
+	ID        uint
+	UserID    uint
+	Title     string
+	Snippet   string
+	Rank      float64
+	CreatedAt time.Time
+}
+
+// Facet counts the matches of one type.
+type Facet struct {
+	Type  string
+	Count int64
+}
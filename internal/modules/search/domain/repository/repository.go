@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-backend/internal/modules/search/domain/entity"
+	"go-backend/internal/pkg/publishing"
+	"gorm.io/gorm"
+)
+
+// StartSel and StopSel mark the matches in the titles and snippets of hits.
+// They are private use characters, which do not occur in text, so that the
+// text can be escaped before the markers are turned into HTML.
+const (
+	StartSel = "\uE000"
+	StopSel  = "\uE001"
+)
+
+var (
+	titleOptions   = fmt.Sprintf("HighlightAll=true, StartSel=%s, StopSel=%s", StartSel, StopSel)
+	snippetOptions = fmt.Sprintf(`MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … ", StartSel=%s, StopSel=%s`, StartSel, StopSel)
+)
+
+// source is a searchable table, aliased as t. Its search_vector column is
+// added by the search_vector migration of its module as a stored generated
+// column, so Postgres keeps it up to date on every insert and update. The
+// vectors and queries use the simple configuration, which does not stem, as
+// content is written in several languages. Title and Body are the
+// expressions that the headlines of its hits are taken from.
+// Published limits the search to published rows, for tables with a
+// publishing status.
+type source struct {
+	Type      string
+	Table     string
+	Title     string
+	Body      string
+	Published bool
+}
+
+var sources = []source{
+	{Type: entity.TypePost, Table: "posts", Title: "t.title", Body: "t.content", Published: true},
+	{Type: entity.TypeProject, Table: "projects", Title: "t.name", Body: "t.description", Published: true},
+	{Type: entity.TypeExperience, Table: "experiences", Title: "t.title", Body: "concat_ws(' · ', t.company, t.description)"},
+	{Type: entity.TypeTool, Table: "tools", Title: "t.name", Body: "t.description"},
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, query entity.Query) ([]entity.Hit, error)
+	Facets(ctx context.Context, query entity.Query) ([]entity.Facet, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search returns the page of hits of query, best matches first. Headlines
+// are only built for the rows of the page, as they are expensive.
+func (r *searchRepository) Search(ctx context.Context, query entity.Query) ([]entity.Hit, error) {
+	var selects []string
+	args := []interface{}{query.Text}
+	for _, src := range sources {
+		if !wanted(query.Types, src.Type) {
+			continue
+		}
+		sql, sqlArgs := matches(src, query.UserID,
+			fmt.Sprintf("t.id, t.user_id, %s AS title, %s AS body, ts_rank(t.search_vector, q.query) AS rank, t.created_at", src.Title, src.Body))
+		selects = append(selects, sql)
+		args = append(args, sqlArgs...)
+	}
+	if len(selects) == 0 {
+		return nil, nil
+	}
+	args = append(args, query.Limit, query.Offset, titleOptions, snippetOptions)
+
+	sql := `WITH q AS (SELECT websearch_to_tsquery('simple', ?) AS query),
+hits AS (
+	` + strings.Join(selects, "\n\tUNION ALL\n\t") + `
+	ORDER BY rank DESC, created_at DESC, type, id
+	LIMIT ? OFFSET ?
+)
+SELECT hits.type, hits.id, hits.user_id,
+	ts_headline('simple', hits.title, q.query, ?) AS title,
+	ts_headline('simple', coalesce(hits.body, ''), q.query, ?) AS snippet,
+	hits.rank, hits.created_at
+FROM hits, q
+ORDER BY hits.rank DESC, hits.created_at DESC, hits.type, hits.id`
+
+	var hits []entity.Hit
+	err := r.db.WithContext(ctx).Raw(sql, args...).Scan(&hits).Error
+	return hits, err
+}
+
+// Facets counts the matches of query for every type, whichever types the
+// query asks for.
+func (r *searchRepository) Facets(ctx context.Context, query entity.Query) ([]entity.Facet, error) {
+	selects := make([]string, len(sources))
+	args := []interface{}{query.Text}
+	for i, src := range sources {
+		var sqlArgs []interface{}
+		selects[i], sqlArgs = matches(src, query.UserID, "count(*) AS count")
+		args = append(args, sqlArgs...)
+	}
+
+	sql := `WITH q AS (SELECT websearch_to_tsquery('simple', ?) AS query)
+` + strings.Join(selects, "\nUNION ALL\n")
+
+	var facets []entity.Facet
+	err := r.db.WithContext(ctx).Raw(sql, args...).Scan(&facets).Error
+	return facets, err
+}
+
+// matches selects columns, after the type, from the rows of src that match
+// the query q.
+func matches(src source, userID uint, columns string) (string, []interface{}) {
+	sql := fmt.Sprintf("(SELECT '%s' AS type, %s FROM %s t, q WHERE t.deleted_at IS NULL AND t.search_vector @@ q.query",
+		src.Type, columns, src.Table)
+	if src.Published {
+		sql += fmt.Sprintf(" AND t.status = '%s'", publishing.Published)
+	}
+	var args []interface{}
+	if userID != 0 {
+		sql += " AND t.user_id = ?"
+		args = append(args, userID)
+	}
+	return sql + ")", args
+}
+
+func wanted(types []string, t string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, want := range types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
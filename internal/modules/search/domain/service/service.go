@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"html"
+	"strconv"
+	"strings"
+
+	"go-backend/internal/infrastructure/tracing"
+	"go-backend/internal/modules/search/domain/entity"
+	"go-backend/internal/modules/search/domain/repository"
+	"go-backend/internal/modules/search/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/pagination"
+)
+
+// Sort is the order of search results, as reported in the meta block.
+const Sort = "relevance"
+
+type SearchService interface {
+	Search(ctx context.Context, req *dto.SearchRequest) (*dto.SearchResponse, pagination.Meta, error)
+}
+
+type searchService struct {
+	repo repository.SearchRepository
+}
+
+func NewSearchService(repo repository.SearchRepository) SearchService {
+	return &searchService{repo: repo}
+}
+
+var highlighter = strings.NewReplacer(repository.StartSel, "<mark>", repository.StopSel, "</mark>")
+
+func (s *searchService) Search(ctx context.Context, req *dto.SearchRequest) (*dto.SearchResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search")
+	defer span.End()
+
+	// Like list pages, search pages are bounded so that offsets cannot
+	// overflow
+	if req.Page > pagination.MaxPage {
+		return nil, pagination.Meta{}, apperror.Validation(apperror.Field("page", "max", strconv.Itoa(pagination.MaxPage)))
+	}
+
+	page, limit := max(req.Page, 1), pagination.DefaultLimit
+	if req.Limit > 0 {
+		limit = min(req.Limit, pagination.MaxLimit)
+	}
+	query := entity.Query{
+		Text:   req.Q,
+		Types:  req.Types,
+		UserID: req.UserID,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}
+
+	facets, err := s.repo.Facets(ctx, query)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	resp := &dto.SearchResponse{
+		Results: make([]dto.HitResponse, 0, limit),
+		Facets:  make(map[string]int64, len(entity.Types)),
+	}
+	var total int64
+	for _, facet := range facets {
+		resp.Facets[facet.Type] = facet.Count
+		if len(query.Types) == 0 || contains(query.Types, facet.Type) {
+			total += facet.Count
+		}
+	}
+
+	meta := pagination.Meta{
+		Limit:      limit,
+		Total:      total,
+		Page:       page,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Sort:       Sort,
+	}
+	if int64(query.Offset) >= total {
+		return resp, meta, nil
+	}
+
+	hits, err := s.repo.Search(ctx, query)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	for _, hit := range hits {
+		resp.Results = append(resp.Results, dto.HitResponse{
+			Type:      hit.Type,
+			ID:        hit.ID,
+			UserID:    hit.UserID,
+			Title:     highlight(hit.Title),
+			Snippet:   highlight(hit.Snippet),
+			Rank:      hit.Rank,
+			CreatedAt: hit.CreatedAt,
+		})
+	}
+	return resp, meta, nil
+}
+
+// highlight escapes text for HTML and marks its matches. The text is
+// escaped first, so that only the marks are markup.
+func highlight(text string) string {
+	return highlighter.Replace(html.EscapeString(text))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
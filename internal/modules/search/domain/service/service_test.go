@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/modules/search/domain/entity"
+	"go-backend/internal/modules/search/domain/repository"
+	"go-backend/internal/modules/search/dto"
+	"go-backend/internal/modules/search/mocks"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/pagination"
+)
+
+var facets = []entity.Facet{
+	{Type: entity.TypePost, Count: 3},
+	{Type: entity.TypeProject, Count: 2},
+	{Type: entity.TypeExperience, Count: 0},
+	{Type: entity.TypeTool, Count: 1},
+}
+
+func TestSearchService_Search(t *testing.T) {
+	mockRepo := new(mocks.MockSearchRepository)
+	svc := NewSearchService(mockRepo)
+
+	query := entity.Query{Text: "go", Limit: pagination.DefaultLimit}
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Facets", mock.Anything, query).Return(facets, nil)
+	mockRepo.On("Search", mock.Anything, query).Return([]entity.Hit{{
+		Type:      entity.TypePost,
+		ID:        7,
+		UserID:    2,
+		Title:     "Learning " + repository.StartSel + "Go" + repository.StopSel,
+		Snippet:   "<script> and " + repository.StartSel + "Go" + repository.StopSel + " & more",
+		Rank:      0.6,
+		CreatedAt: createdAt,
+	}}, nil)
+
+	resp, meta, err := svc.Search(context.Background(), &dto.SearchRequest{Q: "go"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []dto.HitResponse{{
+		Type:      entity.TypePost,
+		ID:        7,
+		UserID:    2,
+		Title:     "Learning <mark>Go</mark>",
+		Snippet:   "&lt;script&gt; and <mark>Go</mark> &amp; more",
+		Rank:      0.6,
+		CreatedAt: createdAt,
+	}}, resp.Results)
+	assert.Equal(t, map[string]int64{"post": 3, "project": 2, "experience": 0, "tool": 1}, resp.Facets)
+	assert.Equal(t, pagination.Meta{Limit: pagination.DefaultLimit, Total: 6, Page: 1, TotalPages: 1, Sort: Sort}, meta)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchService_Search_Scoped(t *testing.T) {
+	mockRepo := new(mocks.MockSearchRepository)
+	svc := NewSearchService(mockRepo)
+
+	query := entity.Query{
+		Text:   "go",
+		Types:  []string{entity.TypePost, entity.TypeTool},
+		UserID: 2,
+		Limit:  pagination.MaxLimit,
+		Offset: pagination.MaxLimit,
+	}
+	mockRepo.On("Facets", mock.Anything, query).Return(facets, nil)
+
+	resp, meta, err := svc.Search(context.Background(), &dto.SearchRequest{
+		Q:      "go",
+		Types:  []string{entity.TypePost, entity.TypeTool},
+		UserID: 2,
+		Page:   2,
+		Limit:  1000,
+	})
+	require.NoError(t, err)
+
+	// Facets count every type, the total only the wanted ones, and a page
+	// past the matches is not searched
+	assert.Empty(t, resp.Results)
+	assert.Len(t, resp.Facets, 4)
+	assert.Equal(t, int64(4), meta.Total)
+	assert.Equal(t, pagination.MaxLimit, meta.Limit)
+	assert.Equal(t, 2, meta.Page)
+	mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+func TestSearchService_Search_PageOutOfRange(t *testing.T) {
+	mockRepo := new(mocks.MockSearchRepository)
+	svc := NewSearchService(mockRepo)
+
+	_, _, err := svc.Search(context.Background(), &dto.SearchRequest{Q: "go", Page: pagination.MaxPage + 1})
+
+	appErr := apperror.From(err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, []apperror.FieldError{apperror.Field("page", "max", "10000")}, appErr.Fields)
+	mockRepo.AssertNotCalled(t, "Facets", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// SearchRequest is the query of a search. Types are given by repeating the
+// type parameter, as in ?q=go&type=post&type=project.
+type SearchRequest struct {
+	Q      string   `form:"q" json:"q" binding:"required,max=200"`
+	Types  []string `form:"type" json:"type" binding:"max=4,dive,searchtype"`
+	UserID uint     `form:"user_id" json:"user_id"`
+	Page   int      `form:"page" json:"page" binding:"omitempty,min=1"`
+	Limit  int      `form:"limit" json:"limit" binding:"omitempty,min=1"`
+}
+
+// HitResponse is a search result. Title and Snippet are HTML, escaped, with
+// the matches wrapped in <mark> elements.
+type HitResponse struct {
+	Type      string    `json:"type"`
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"user_id"`
+	Title     string    `json:"title"`
+	Snippet   string    `json:"snippet"`
+	Rank      float64   `json:"rank"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SearchResponse holds a page of results and the number of matches of each
+// type.
+type SearchResponse struct {
+	Results []HitResponse    `json:"results"`
+	Facets  map[string]int64 `json:"facets"`
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go-backend/internal/modules/search/domain/service"
+	"go-backend/internal/modules/search/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/response"
+)
+
+type SearchHandler struct {
+	service service.SearchService
+}
+
+func NewSearchHandler(service service.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search handles searching posts, projects, experiences and tools
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req dto.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	resp, meta, err := h.service.Search(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Search results retrieved successfully", resp, meta))
+}
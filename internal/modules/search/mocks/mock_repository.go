@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"go-backend/internal/modules/search/domain/entity"
+)
+
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, query entity.Query) ([]entity.Hit, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Hit), args.Error(1)
+}
+
+func (m *MockSearchRepository) Facets(ctx context.Context, query entity.Query) ([]entity.Facet, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Facet), args.Error(1)
+}
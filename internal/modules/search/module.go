@@ -0,0 +1,26 @@
+package search
+
+import (
+	"go-backend/internal/modules/search/domain/entity"
+	"go-backend/internal/modules/search/domain/repository"
+	"go-backend/internal/modules/search/domain/service"
+	"go-backend/internal/modules/search/handlers"
+	"go-backend/internal/pkg/validation"
+	"gorm.io/gorm"
+)
+
+type Module struct {
+	Handler *handlers.SearchHandler
+}
+
+func NewModule(db *gorm.DB) *Module {
+	validation.RegisterEnum("searchtype", entity.Types...)
+
+	repo := repository.NewSearchRepository(db)
+	svc := service.NewSearchService(repo)
+	handler := handlers.NewSearchHandler(svc)
+
+	return &Module{
+		Handler: handler,
+	}
+}
@@ -0,0 +1,10 @@
+package search
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
+	// Public route
+	router.GET("/search", m.Handler.Search)
+}
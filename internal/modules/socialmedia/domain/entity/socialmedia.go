@@ -17,4 +17,10 @@ type SocialMedia struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Platforms lists the platforms a social media link can be for.
+var Platforms = []string{
+	"github", "gitlab", "linkedin", "twitter", "x", "instagram", "facebook",
+	"youtube", "tiktok", "medium", "dribbble", "behance", "stackoverflow", "website",
 }
\ No newline at end of file
@@ -1,18 +1,21 @@
 package repository
 
 import (
+	"context"
 	"go-backend/internal/modules/socialmedia/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 type SocialMediaRepository interface {
-	Create(socialMedia *entity.SocialMedia) error
-	GetByID(id uint) (*entity.SocialMedia, error)
-	GetAll() ([]entity.SocialMedia, error)
-	Update(socialMedia *entity.SocialMedia) error
-	Delete(id uint) error
-	GetByUserID(userID uint) ([]entity.SocialMedia, error)
-	GetByProfileID(profileID uint) ([]entity.SocialMedia, error)
+	Create(ctx context.Context, socialMedia *entity.SocialMedia) error
+	GetByID(ctx context.Context, id uint) (*entity.SocialMedia, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.SocialMedia, pagination.Meta, error)
+	Update(ctx context.Context, socialMedia *entity.SocialMedia) error
+	Delete(ctx context.Context, id uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.SocialMedia, pagination.Meta, error)
+	GetByProfileID(ctx context.Context, profileID uint, params pagination.Params) ([]entity.SocialMedia, pagination.Meta, error)
 }
 
 type socialMediaRepository struct {
@@ -23,38 +26,32 @@ func NewSocialMediaRepository(db *gorm.DB) SocialMediaRepository {
 	return &socialMediaRepository{db: db}
 }
 
-func (r *socialMediaRepository) Create(socialMedia *entity.SocialMedia) error {
-	return r.db.Create(socialMedia).Error
+func (r *socialMediaRepository) Create(ctx context.Context, socialMedia *entity.SocialMedia) error {
+	return r.db.WithContext(ctx).Create(socialMedia).Error
 }
 
-func (r *socialMediaRepository) GetByID(id uint) (*entity.SocialMedia, error) {
+func (r *socialMediaRepository) GetByID(ctx context.Context, id uint) (*entity.SocialMedia, error) {
 	var socialMedia entity.SocialMedia
-	err := r.db.Preload("User").First(&socialMedia, id).Error
+	err := r.db.WithContext(ctx).Preload("User").First(&socialMedia, id).Error
 	return &socialMedia, err
 }
 
-func (r *socialMediaRepository) GetAll() ([]entity.SocialMedia, error) {
-	var socialMedias []entity.SocialMedia
-	err := r.db.Preload("User").Find(&socialMedias).Error
-	return socialMedias, err
+func (r *socialMediaRepository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.SocialMedia, pagination.Meta, error) {
+	return pagination.Find[entity.SocialMedia](r.db.WithContext(ctx).Scopes(f.Scope), params, "User")
 }
 
-func (r *socialMediaRepository) Update(socialMedia *entity.SocialMedia) error {
-	return r.db.Save(socialMedia).Error
+func (r *socialMediaRepository) Update(ctx context.Context, socialMedia *entity.SocialMedia) error {
+	return r.db.WithContext(ctx).Save(socialMedia).Error
 }
 
-func (r *socialMediaRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.SocialMedia{}, id).Error
+func (r *socialMediaRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.SocialMedia{}, id).Error
 }
 
-func (r *socialMediaRepository) GetByUserID(userID uint) ([]entity.SocialMedia, error) {
-	var socialMedias []entity.SocialMedia
-	err := r.db.Preload("User").Where("user_id = ?", userID).Find(&socialMedias).Error
-	return socialMedias, err
+func (r *socialMediaRepository) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.SocialMedia, pagination.Meta, error) {
+	return pagination.Find[entity.SocialMedia](r.db.WithContext(ctx).Where("user_id = ?", userID), params, "User")
 }
 
-func (r *socialMediaRepository) GetByProfileID(profileID uint) ([]entity.SocialMedia, error) {
-	var socialMedias []entity.SocialMedia
-	err := r.db.Preload("User").Where("profile_id = ?", profileID).Find(&socialMedias).Error
-	return socialMedias, err
+func (r *socialMediaRepository) GetByProfileID(ctx context.Context, profileID uint, params pagination.Params) ([]entity.SocialMedia, pagination.Meta, error) {
+	return pagination.Find[entity.SocialMedia](r.db.WithContext(ctx).Where("profile_id = ?", profileID), params, "User")
 }
\ No newline at end of file
@@ -1,20 +1,25 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"go-backend/internal/infrastructure/tracing"
 	"go-backend/internal/modules/socialmedia/domain/entity"
 	"go-backend/internal/modules/socialmedia/domain/repository"
 	"go-backend/internal/modules/socialmedia/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type SocialMediaService interface {
-	Create(socialMedia *entity.SocialMedia) (*dto.CreateSocialMediaResponse, error)
-	GetByID(id uint) (*dto.SocialMediaResponse, error)
-	GetAll() ([]dto.SocialMediaResponse, error)
-	Update(id uint, userID uint, req *dto.UpdateSocialMediaRequest) (*dto.UpdateSocialMediaResponse, error)
-	Delete(id, userID uint) error
-	GetByUserID(userID uint) ([]dto.SocialMediaResponse, error)
-	GetByProfileID(profileID uint) ([]dto.SocialMediaResponse, error)
+	Create(ctx context.Context, socialMedia *entity.SocialMedia) (*dto.CreateSocialMediaResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.SocialMediaResponse, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.SocialMediaResponse, pagination.Meta, error)
+	Update(ctx context.Context, id uint, userID uint, req *dto.UpdateSocialMediaRequest) (*dto.UpdateSocialMediaResponse, error)
+	Delete(ctx context.Context, id, userID uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.SocialMediaResponse, pagination.Meta, error)
+	GetByProfileID(ctx context.Context, profileID uint, params pagination.Params) ([]dto.SocialMediaResponse, pagination.Meta, error)
 }
 
 type socialMediaService struct {
@@ -25,8 +30,11 @@ func NewSocialMediaService(repo repository.SocialMediaRepository) SocialMediaSer
 	return &socialMediaService{repo: repo}
 }
 
-func (s *socialMediaService) Create(socialMedia *entity.SocialMedia) (*dto.CreateSocialMediaResponse, error) {
-	if err := s.repo.Create(socialMedia); err != nil {
+func (s *socialMediaService) Create(ctx context.Context, socialMedia *entity.SocialMedia) (*dto.CreateSocialMediaResponse, error) {
+	ctx, span := tracing.Start(ctx, "SocialMediaService.Create")
+	defer span.End()
+
+	if err := s.repo.Create(ctx, socialMedia); err != nil {
 		return nil, err
 	}
 
@@ -39,10 +47,13 @@ func (s *socialMediaService) Create(socialMedia *entity.SocialMedia) (*dto.Creat
 	}, nil
 }
 
-func (s *socialMediaService) GetByID(id uint) (*dto.SocialMediaResponse, error) {
-	socialMedia, err := s.repo.GetByID(id)
+func (s *socialMediaService) GetByID(ctx context.Context, id uint) (*dto.SocialMediaResponse, error) {
+	ctx, span := tracing.Start(ctx, "SocialMediaService.GetByID")
+	defer span.End()
+
+	socialMedia, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "social media account not found")
 	}
 
 	return &dto.SocialMediaResponse{
@@ -63,10 +74,13 @@ func (s *socialMediaService) GetByID(id uint) (*dto.SocialMediaResponse, error)
 	}, nil
 }
 
-func (s *socialMediaService) GetAll() ([]dto.SocialMediaResponse, error) {
-	socialMedias, err := s.repo.GetAll()
+func (s *socialMediaService) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.SocialMediaResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "SocialMediaService.GetAll")
+	defer span.End()
+
+	socialMedias, meta, err := s.repo.GetAll(ctx, f, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.SocialMediaResponse, len(socialMedias))
@@ -89,23 +103,26 @@ func (s *socialMediaService) GetAll() ([]dto.SocialMediaResponse, error) {
 		}
 	}
 
-	return response, nil
+	return response, meta, nil
 }
 
-func (s *socialMediaService) Update(id uint, userID uint, req *dto.UpdateSocialMediaRequest) (*dto.UpdateSocialMediaResponse, error) {
-	existing, err := s.repo.GetByID(id)
+func (s *socialMediaService) Update(ctx context.Context, id uint, userID uint, req *dto.UpdateSocialMediaRequest) (*dto.UpdateSocialMediaResponse, error) {
+	ctx, span := tracing.Start(ctx, "SocialMediaService.Update")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "social media account not found")
 	}
 
-	if existing.UserID != userID {
-		return nil, errors.New("unauthorized: you can only update your own social media")
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "update", "social media"); err != nil {
+		return nil, err
 	}
 
 	existing.Platform = req.Platform
 	existing.Url = req.Url
 
-	if err := s.repo.Update(existing); err != nil {
+	if err := s.repo.Update(ctx, existing); err != nil {
 		return nil, err
 	}
 
@@ -118,23 +135,29 @@ func (s *socialMediaService) Update(id uint, userID uint, req *dto.UpdateSocialM
 	}, nil
 }
 
-func (s *socialMediaService) Delete(id, userID uint) error {
-	existing, err := s.repo.GetByID(id)
+func (s *socialMediaService) Delete(ctx context.Context, id, userID uint) error {
+	ctx, span := tracing.Start(ctx, "SocialMediaService.Delete")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return apperror.NotFoundOr(err, "social media account not found")
 	}
 
-	if existing.UserID != userID {
-		return errors.New("unauthorized: you can only delete your own social media")
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "delete", "social media"); err != nil {
+		return err
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(ctx, id)
 }
 
-func (s *socialMediaService) GetByUserID(userID uint) ([]dto.SocialMediaResponse, error) {
-	socialMedias, err := s.repo.GetByUserID(userID)
+func (s *socialMediaService) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.SocialMediaResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "SocialMediaService.GetByUserID")
+	defer span.End()
+
+	socialMedias, meta, err := s.repo.GetByUserID(ctx, userID, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.SocialMediaResponse, len(socialMedias))
@@ -157,13 +180,16 @@ func (s *socialMediaService) GetByUserID(userID uint) ([]dto.SocialMediaResponse
 		}
 	}
 
-	return response, nil
+	return response, meta, nil
 }
 
-func (s *socialMediaService) GetByProfileID(profileID uint) ([]dto.SocialMediaResponse, error) {
-	socialMedias, err := s.repo.GetByProfileID(profileID)
+func (s *socialMediaService) GetByProfileID(ctx context.Context, profileID uint, params pagination.Params) ([]dto.SocialMediaResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "SocialMediaService.GetByProfileID")
+	defer span.End()
+
+	socialMedias, meta, err := s.repo.GetByProfileID(ctx, profileID, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.SocialMediaResponse, len(socialMedias))
@@ -186,5 +212,5 @@ func (s *socialMediaService) GetByProfileID(profileID uint) ([]dto.SocialMediaRe
 		}
 	}
 
-	return response, nil
+	return response, meta, nil
 }
\ No newline at end of file
@@ -1,8 +1,29 @@
 package dto
 
+import (
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+)
+
+// ListOptions are the sorts of social media links lists, newest first by default.
+var ListOptions = pagination.Options{
+	Sortable:    []string{"id", "platform", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// FilterFields are the fields that social media links lists can be filtered by.
+var FilterFields = filter.Fields{
+	"id":         {Column: "id", Type: filter.Integer},
+	"platform":   {Column: "platform", Type: filter.String},
+	"profile_id": {Column: "profile_id", Type: filter.Integer},
+	"user_id":    {Column: "user_id", Type: filter.Integer},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+}
+
 type CreateSocialMediaRequest struct {
-	Platform  string `json:"platform" binding:"required"`
-	Url       string `json:"url" binding:"required"`
+	Platform  string `json:"platform" binding:"required,platform"`
+	Url       string `json:"url" binding:"required,httpurl,max=2048"`
 	ProfileID uint   `json:"profile_id" binding:"required"`
 }
 
@@ -15,8 +36,8 @@ type CreateSocialMediaResponse struct {
 }
 
 type UpdateSocialMediaRequest struct {
-	Platform string `json:"platform" binding:"required"`
-	Url      string `json:"url" binding:"required"`
+	Platform string `json:"platform" binding:"required,platform"`
+	Url      string `json:"url" binding:"required,httpurl,max=2048"`
 }
 
 type UpdateSocialMediaResponse struct {
@@ -8,15 +8,12 @@ import (
 	"go-backend/internal/modules/socialmedia/domain/entity"
 	"go-backend/internal/modules/socialmedia/domain/service"
 	"go-backend/internal/modules/socialmedia/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 )
 
-type Response struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
-
 type SocialMediaHandler struct {
 	service service.SocialMediaService
 }
@@ -25,25 +22,16 @@ func NewSocialMediaHandler(service service.SocialMediaService) *SocialMediaHandl
 	return &SocialMediaHandler{service: service}
 }
 
-func formatResponse(status int, message string, data interface{}, err string) Response {
-	return Response{
-		Status:  status,
-		Message: message,
-		Data:    data,
-		Error:   err,
-	}
-}
-
 func (h *SocialMediaHandler) Create(c *gin.Context) {
 	var req dto.CreateSocialMediaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
@@ -54,118 +42,141 @@ func (h *SocialMediaHandler) Create(c *gin.Context) {
 		UserID:    userID.(uint),
 	}
 
-	response, err := h.service.Create(socialMedia)
+	resp, err := h.service.Create(c.Request.Context(), socialMedia)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to create social media", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, formatResponse(http.StatusCreated, "Social media created successfully", response, ""))
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "Social media created successfully", resp))
 }
 
 func (h *SocialMediaHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	response, err := h.service.GetByID(uint(id))
+	resp, err := h.service.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "Social media not found", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Social media retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Social media retrieved successfully", resp))
 }
 
 func (h *SocialMediaHandler) GetAll(c *gin.Context) {
-	response, err := h.service.GetAll()
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	f, err := filter.Parse(c.Request.URL.Query(), dto.FilterFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, meta, err := h.service.GetAll(c.Request.Context(), f, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve social media", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Social media retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Social media retrieved successfully", resp, meta))
 }
 
 func (h *SocialMediaHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	var req dto.UpdateSocialMediaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	response, err := h.service.Update(uint(id), userID.(uint), &req)
+	resp, err := h.service.Update(c.Request.Context(), uint(id), userID.(uint), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to update social media", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Social media updated successfully", response, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Social media updated successfully", resp))
 }
 
 func (h *SocialMediaHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	if err := h.service.Delete(uint(id), userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to delete social media", nil, err.Error()))
+	if err := h.service.Delete(c.Request.Context(), uint(id), userID.(uint)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Social media deleted successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Social media deleted successfully", nil))
 }
 
 func (h *SocialMediaHandler) GetByUserID(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	response, err := h.service.GetByUserID(userID.(uint))
+	resp, meta, err := h.service.GetByUserID(c.Request.Context(), userID.(uint), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve user's social media", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User's social media retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "User's social media retrieved successfully", resp, meta))
 }
 
 func (h *SocialMediaHandler) GetByProfileID(c *gin.Context) {
 	profileID, err := strconv.ParseUint(c.Param("profile_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid Profile ID", nil, "Invalid Profile ID format"))
+		c.Error(apperror.BadRequest("Invalid Profile ID format"))
+		return
+	}
+
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	response, err := h.service.GetByProfileID(uint(profileID))
+	resp, meta, err := h.service.GetByProfileID(c.Request.Context(), uint(profileID), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve profile's social media", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Profile's social media retrieved successfully", response, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Profile's social media retrieved successfully", resp, meta))
 }
\ No newline at end of file
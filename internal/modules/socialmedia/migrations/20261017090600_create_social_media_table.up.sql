@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS social_media (
+    id bigserial PRIMARY KEY,
+    platform text NOT NULL,
+    url text NOT NULL,
+    profile_id bigint NOT NULL CONSTRAINT fk_profiles_social_media REFERENCES profiles (id),
+    user_id bigint NOT NULL CONSTRAINT fk_social_media_user REFERENCES users (id),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_social_media_deleted_at ON social_media (deleted_at);
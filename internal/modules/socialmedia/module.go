@@ -1,9 +1,11 @@
 package socialmedia
 
 import (
+	"go-backend/internal/modules/socialmedia/domain/entity"
 	"go-backend/internal/modules/socialmedia/domain/repository"
 	"go-backend/internal/modules/socialmedia/domain/service"
 	"go-backend/internal/modules/socialmedia/handlers"
+	"go-backend/internal/pkg/validation"
 	"gorm.io/gorm"
 )
 
@@ -12,6 +14,8 @@ type Module struct {
 }
 
 func NewModule(db *gorm.DB) *Module {
+	validation.RegisterEnum("platform", entity.Platforms...)
+
 	repo := repository.NewSocialMediaRepository(db)
 	svc := service.NewSocialMediaService(repo)
 	handler := handlers.NewSocialMediaHandler(svc)
@@ -1,17 +1,20 @@
 package repository
 
 import (
+	"context"
 	"go-backend/internal/modules/tool/domain/entity"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 type ToolRepository interface {
-	Create(tool *entity.Tool) error
-	GetByID(id uint) (*entity.Tool, error)
-	GetAll() ([]entity.Tool, error)
-	Update(tool *entity.Tool) error
-	Delete(id uint) error
-	GetByUserID(userID uint) ([]entity.Tool, error)
+	Create(ctx context.Context, tool *entity.Tool) error
+	GetByID(ctx context.Context, id uint) (*entity.Tool, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Tool, pagination.Meta, error)
+	Update(ctx context.Context, tool *entity.Tool) error
+	Delete(ctx context.Context, id uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Tool, pagination.Meta, error)
 }
 
 type toolRepository struct {
@@ -22,32 +25,28 @@ func NewToolRepository(db *gorm.DB) ToolRepository {
 	return &toolRepository{db: db}
 }
 
-func (r *toolRepository) Create(tool *entity.Tool) error {
-	return r.db.Create(tool).Error
+func (r *toolRepository) Create(ctx context.Context, tool *entity.Tool) error {
+	return r.db.WithContext(ctx).Create(tool).Error
 }
 
-func (r *toolRepository) GetByID(id uint) (*entity.Tool, error) {
+func (r *toolRepository) GetByID(ctx context.Context, id uint) (*entity.Tool, error) {
 	var tool entity.Tool
-	err := r.db.Preload("User").First(&tool, id).Error
+	err := r.db.WithContext(ctx).Preload("User").First(&tool, id).Error
 	return &tool, err
 }
 
-func (r *toolRepository) GetAll() ([]entity.Tool, error) {
-	var tools []entity.Tool
-	err := r.db.Preload("User").Find(&tools).Error
-	return tools, err
+func (r *toolRepository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]entity.Tool, pagination.Meta, error) {
+	return pagination.Find[entity.Tool](r.db.WithContext(ctx).Scopes(f.Scope), params, "User")
 }
 
-func (r *toolRepository) Update(tool *entity.Tool) error {
-	return r.db.Save(tool).Error
+func (r *toolRepository) Update(ctx context.Context, tool *entity.Tool) error {
+	return r.db.WithContext(ctx).Save(tool).Error
 }
 
-func (r *toolRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.Tool{}, id).Error
+func (r *toolRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.Tool{}, id).Error
 }
 
-func (r *toolRepository) GetByUserID(userID uint) ([]entity.Tool, error) {
-	var tools []entity.Tool
-	err := r.db.Preload("User").Where("user_id = ?", userID).Find(&tools).Error
-	return tools, err
+func (r *toolRepository) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]entity.Tool, pagination.Meta, error) {
+	return pagination.Find[entity.Tool](r.db.WithContext(ctx).Where("user_id = ?", userID), params, "User")
 }
\ No newline at end of file
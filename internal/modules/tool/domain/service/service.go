@@ -1,19 +1,24 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"go-backend/internal/infrastructure/tracing"
 	"go-backend/internal/modules/tool/domain/entity"
 	"go-backend/internal/modules/tool/domain/repository"
 	"go-backend/internal/modules/tool/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
 )
 
 type ToolService interface {
-	Create(tool *entity.Tool) (*dto.CreateToolResponse, error)
-	GetByID(id uint) (*dto.ToolResponse, error)
-	GetAll() ([]dto.ToolResponse, error)
-	Update(id uint, userID uint, req *dto.UpdateToolRequest) (*dto.UpdateToolResponse, error)
-	Delete(id, userID uint) error
-	GetByUserID(userID uint) ([]dto.ToolResponse, error)
+	Create(ctx context.Context, tool *entity.Tool) (*dto.CreateToolResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.ToolResponse, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.ToolResponse, pagination.Meta, error)
+	Update(ctx context.Context, id uint, userID uint, req *dto.UpdateToolRequest) (*dto.UpdateToolResponse, error)
+	Delete(ctx context.Context, id, userID uint) error
+	GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.ToolResponse, pagination.Meta, error)
 }
 
 type toolService struct {
@@ -24,8 +29,11 @@ func NewToolService(repo repository.ToolRepository) ToolService {
 	return &toolService{repo: repo}
 }
 
-func (s *toolService) Create(tool *entity.Tool) (*dto.CreateToolResponse, error) {
-	if err := s.repo.Create(tool); err != nil {
+func (s *toolService) Create(ctx context.Context, tool *entity.Tool) (*dto.CreateToolResponse, error) {
+	ctx, span := tracing.Start(ctx, "ToolService.Create")
+	defer span.End()
+
+	if err := s.repo.Create(ctx, tool); err != nil {
 		return nil, err
 	}
 
@@ -39,10 +47,13 @@ func (s *toolService) Create(tool *entity.Tool) (*dto.CreateToolResponse, error)
 	}, nil
 }
 
-func (s *toolService) GetByID(id uint) (*dto.ToolResponse, error) {
-	tool, err := s.repo.GetByID(id)
+func (s *toolService) GetByID(ctx context.Context, id uint) (*dto.ToolResponse, error) {
+	ctx, span := tracing.Start(ctx, "ToolService.GetByID")
+	defer span.End()
+
+	tool, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "tool not found")
 	}
 
 	resp := &dto.ToolResponse{
@@ -66,10 +77,13 @@ func (s *toolService) GetByID(id uint) (*dto.ToolResponse, error) {
 	return resp, nil
 }
 
-func (s *toolService) GetAll() ([]dto.ToolResponse, error) {
-	tools, err := s.repo.GetAll()
+func (s *toolService) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]dto.ToolResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ToolService.GetAll")
+	defer span.End()
+
+	tools, meta, err := s.repo.GetAll(ctx, f, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.ToolResponse, len(tools))
@@ -93,17 +107,20 @@ func (s *toolService) GetAll() ([]dto.ToolResponse, error) {
 		}
 	}
 
-	return response, nil
+	return response, meta, nil
 }
 
-func (s *toolService) Update(id uint, userID uint, req *dto.UpdateToolRequest) (*dto.UpdateToolResponse, error) {
-	tool, err := s.repo.GetByID(id)
+func (s *toolService) Update(ctx context.Context, id uint, userID uint, req *dto.UpdateToolRequest) (*dto.UpdateToolResponse, error) {
+	ctx, span := tracing.Start(ctx, "ToolService.Update")
+	defer span.End()
+
+	tool, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "tool not found")
 	}
 
-	if tool.UserID != userID {
-		return nil, errors.New("unauthorized: you can only update your own tools")
+	if err := authz.RequireOwner(ctx, userID, tool.UserID, "update", "tools"); err != nil {
+		return nil, err
 	}
 
 	tool.Name = req.Name
@@ -111,7 +128,7 @@ func (s *toolService) Update(id uint, userID uint, req *dto.UpdateToolRequest) (
 	tool.Category = req.Category
 	tool.Description = req.Description
 
-	if err := s.repo.Update(tool); err != nil {
+	if err := s.repo.Update(ctx, tool); err != nil {
 		return nil, err
 	}
 
@@ -125,23 +142,29 @@ func (s *toolService) Update(id uint, userID uint, req *dto.UpdateToolRequest) (
 	}, nil
 }
 
-func (s *toolService) Delete(id, userID uint) error {
-	existing, err := s.repo.GetByID(id)
+func (s *toolService) Delete(ctx context.Context, id, userID uint) error {
+	ctx, span := tracing.Start(ctx, "ToolService.Delete")
+	defer span.End()
+
+	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return apperror.NotFoundOr(err, "tool not found")
 	}
 
-	if existing.UserID != userID {
-		return errors.New("unauthorized: you can only delete your own tools")
+	if err := authz.RequireOwner(ctx, userID, existing.UserID, "delete", "tools"); err != nil {
+		return err
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(ctx, id)
 }
 
-func (s *toolService) GetByUserID(userID uint) ([]dto.ToolResponse, error) {
-	tools, err := s.repo.GetByUserID(userID)
+func (s *toolService) GetByUserID(ctx context.Context, userID uint, params pagination.Params) ([]dto.ToolResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "ToolService.GetByUserID")
+	defer span.End()
+
+	tools, meta, err := s.repo.GetByUserID(ctx, userID, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]dto.ToolResponse, len(tools))
@@ -165,5 +188,5 @@ func (s *toolService) GetByUserID(userID uint) ([]dto.ToolResponse, error) {
 		}
 	}
 
-	return response, nil
+	return response, meta, nil
 }
\ No newline at end of file
@@ -1,10 +1,31 @@
 package dto
 
+import (
+	"go-backend/internal/pkg/filter"
+	"go-backend/internal/pkg/pagination"
+)
+
+// ListOptions are the sorts of tools lists, newest first by default.
+var ListOptions = pagination.Options{
+	Sortable:    []string{"id", "name", "category", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// FilterFields are the fields that tools lists can be filtered by.
+var FilterFields = filter.Fields{
+	"id":         {Column: "id", Type: filter.Integer},
+	"name":       {Column: "name", Type: filter.String},
+	"category":   {Column: "category", Type: filter.String},
+	"user_id":    {Column: "user_id", Type: filter.Integer},
+	"created_at": {Column: "created_at", Type: filter.Time},
+	"updated_at": {Column: "updated_at", Type: filter.Time},
+}
+
 type CreateToolRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Icon        string `json:"icon"`
-	Category    string `json:"category" binding:"required"`
-	Description string `json:"description"`
+	Name        string `json:"name" binding:"required,max=255"`
+	Icon        string `json:"icon" binding:"max=255"`
+	Category    string `json:"category" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=5000"`
 }
 
 type CreateToolResponse struct {
@@ -17,10 +38,10 @@ type CreateToolResponse struct {
 }
 
 type UpdateToolRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Icon        string `json:"icon"`
-	Category    string `json:"category" binding:"required"`
-	Description string `json:"description"`
+	Name        string `json:"name" binding:"required,max=255"`
+	Icon        string `json:"icon" binding:"max=255"`
+	Category    string `json:"category" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=5000"`
 }
 
 type UpdateToolResponse struct {
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tools (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    icon text,
+    category text NOT NULL,
+    description text,
+    user_id bigint NOT NULL CONSTRAINT fk_tools_user REFERENCES users (id),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_tools_deleted_at ON tools (deleted_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tools_search_vector;
+ALTER TABLE tools DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// APIKeyScope limits what an API key may do on behalf of its owner.
+type APIKeyScope string
+
+const (
+	// ScopeRead grants read access. Every key has it.
+	ScopeRead          APIKeyScope = "read"
+	ScopeProjectsWrite APIKeyScope = "projects:write"
+	ScopePostsWrite    APIKeyScope = "posts:write"
+)
+
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case ScopeRead, ScopeProjectsWrite, ScopePostsWrite:
+		return true
+	}
+	return false
+}
+
+// APIKey is a long lived credential for machine access. Only the SHA-256 hash
+// of the key is stored; Prefix is kept so users can recognise their keys.
+type APIKey struct {
+	ID         uint          `json:"id" gorm:"primaryKey"`
+	UserID     uint          `json:"user_id" gorm:"not null;index"`
+	User       User          `json:"-" gorm:"foreignKey:UserID"`
+	Name       string        `json:"name" gorm:"not null"`
+	Prefix     string        `json:"prefix" gorm:"not null;size:16"`
+	KeyHash    string        `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes     []APIKeyScope `json:"scopes" gorm:"serializer:json;not null"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+func (k *APIKey) IsActive() bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || time.Now().Before(*k.ExpiresAt)
+}
+
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	if scope == ScopeRead {
+		return true
+	}
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIKey returns the hex encoded SHA-256 digest stored in APIKey.KeyHash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+)
+
+// AuditEvent names the security relevant actions that are recorded.
+type AuditEventType string
+
+const (
+	AuditAccountLocked   AuditEventType = "account_locked"
+	AuditAccountUnlocked AuditEventType = "account_unlocked"
+)
+
+// AuditEvent records a security relevant action. UserID is the affected
+// account and ActorID the user who performed the action, if any.
+type AuditEvent struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    *uint          `json:"user_id,omitempty" gorm:"index"`
+	ActorID   *uint          `json:"actor_id,omitempty"`
+	Event     AuditEventType `json:"event" gorm:"type:varchar(64);not null;index"`
+	IPAddress string         `json:"ip_address"`
+	Details   string         `json:"details"`
+	CreatedAt time.Time      `json:"created_at" gorm:"index"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+)
+
+// TokenPurpose scopes a OneTimeToken to a single flow so that, for example, a
+// password reset token cannot be redeemed anywhere else.
+type TokenPurpose string
+
+const (
+	PurposePasswordReset     TokenPurpose = "password_reset"
+	PurposeEmailVerification TokenPurpose = "email_verification"
+)
+
+// OneTimeToken is a single-use, expiring token sent to a user by email. Only
+// the SHA-256 hash of the token is stored.
+type OneTimeToken struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	UserID    uint         `json:"user_id" gorm:"not null;index"`
+	User      User         `json:"-" gorm:"foreignKey:UserID"`
+	Purpose   TokenPurpose `json:"purpose" gorm:"type:varchar(32);not null;index"`
+	TokenHash string       `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time    `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time   `json:"used_at,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+func (t *OneTimeToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
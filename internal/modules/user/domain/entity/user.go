@@ -7,39 +7,73 @@ import (
 	"gorm.io/gorm"
 )
 
+// Role determines which routes a user may call. Every user has exactly one.
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleEditor, RoleViewer:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Name      string         `json:"name" gorm:"not null"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string         `json:"-" gorm:"not null"`
-	Token     *string        `json:"token,omitempty" gorm:"unique"`
-	ExpiresAt *time.Time     `json:"expires_at,omitempty"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Name            string         `json:"name" gorm:"not null"`
+	Email           string         `json:"email" gorm:"uniqueIndex;not null"`
+	Password        string         `json:"-" gorm:"not null"`
+	Role            Role           `json:"role" gorm:"type:varchar(20);not null;default:'viewer'"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at,omitempty"`
+	MFASecret       string         `json:"-"`
+	MFAEnabledAt    *time.Time     `json:"mfa_enabled_at,omitempty"`
+	MFALastStep     int64          `json:"-" gorm:"not null;default:0"`
+	FailedLogins    int            `json:"-" gorm:"not null;default:0"`
+	LockedUntil     *time.Time     `json:"locked_until,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) IsMFAEnabled() bool {
+	return u.MFAEnabledAt != nil
+}
+
+// IsLocked reports whether logins are refused until LockedUntil.
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 func (u *User) BeforeSave(tx *gorm.DB) error {
 	if u.Password != "" {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+		hashedPassword, err := HashPassword(u.Password)
 		if err != nil {
 			return err
 		}
-		u.Password = string(hashedPassword)
+		u.Password = hashedPassword
 	}
 	return nil
 }
 
-func (u *User) ComparePassword(password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
-}
-
-func (u *User) SetToken(token string, expiresAt time.Time) {
-	u.Token = &token
-	u.ExpiresAt = &expiresAt
+// HashPassword returns the bcrypt hash stored in User.Password.
+func HashPassword(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
 }
 
-func (u *User) ClearToken() {
-	u.Token = nil
-	u.ExpiresAt = nil
+func (u *User) ComparePassword(password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
\ No newline at end of file
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+// UserIdentity links a user to an account at an external identity provider.
+// Subject is the provider's stable ID for that account.
+type UserIdentity struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	User      User      `json:"-" gorm:"foreignKey:UserID"`
+	Provider  string    `json:"provider" gorm:"type:varchar(32);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string    `json:"subject" gorm:"not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OAuthState is a pending OAuth login. It is keyed by the hash of the state
+// parameter and holds the PKCE code verifier until the provider redirects back.
+type OAuthState struct {
+	StateHash    string `gorm:"primaryKey;size:64"`
+	Provider     string `gorm:"type:varchar(32);not null"`
+	CodeVerifier string `gorm:"not null"`
+	Device       string
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
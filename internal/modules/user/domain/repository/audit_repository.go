@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"go-backend/internal/modules/user/domain/entity"
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *entity.AuditEvent) error
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(ctx context.Context, event *entity.AuditEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-backend/internal/modules/user/domain/entity"
+	"gorm.io/gorm"
+)
+
+type OneTimeTokenRepository interface {
+	Create(ctx context.Context, token *entity.OneTimeToken) error
+	GetByHash(ctx context.Context, purpose entity.TokenPurpose, tokenHash string) (*entity.OneTimeToken, error)
+	// MarkUsed consumes the token. It reports false when the token had already
+	// been used, so two concurrent redemptions cannot both succeed.
+	MarkUsed(ctx context.Context, id uint) (bool, error)
+	// InvalidateByUserID consumes every outstanding token of the given purpose
+	// for the user, so only the most recently issued one can be redeemed.
+	InvalidateByUserID(ctx context.Context, userID uint, purpose entity.TokenPurpose) error
+	// IssuedSince reports whether a token of the given purpose was created for
+	// the user after since.
+	IssuedSince(ctx context.Context, userID uint, purpose entity.TokenPurpose, since time.Time) (bool, error)
+}
+
+type oneTimeTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewOneTimeTokenRepository(db *gorm.DB) OneTimeTokenRepository {
+	return &oneTimeTokenRepository{db: db}
+}
+
+func (r *oneTimeTokenRepository) Create(ctx context.Context, token *entity.OneTimeToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *oneTimeTokenRepository) GetByHash(ctx context.Context, purpose entity.TokenPurpose, tokenHash string) (*entity.OneTimeToken, error) {
+	var token entity.OneTimeToken
+	err := r.db.WithContext(ctx).Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *oneTimeTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.OneTimeToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *oneTimeTokenRepository) InvalidateByUserID(ctx context.Context, userID uint, purpose entity.TokenPurpose) error {
+	return r.db.WithContext(ctx).Model(&entity.OneTimeToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
+
+func (r *oneTimeTokenRepository) IssuedSince(ctx context.Context, userID uint, purpose entity.TokenPurpose, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.OneTimeToken{}).
+		Where("user_id = ? AND purpose = ? AND created_at > ?", userID, purpose, since).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -1,17 +1,34 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	Create(user *entity.User) error
-	GetByID(id uint) (*entity.User, error)
-	GetByEmail(email string) (*entity.User, error)
-	Update(user *entity.User) error
-	Delete(id uint) error
-	List(page, limit int) ([]*entity.User, error)
+	Create(ctx context.Context, user *entity.User) error
+	GetByID(ctx context.Context, id uint) (*entity.User, error)
+	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	Update(ctx context.Context, user *entity.User) error
+	UpdateRole(ctx context.Context, id uint, role entity.Role) error
+	UpdatePassword(ctx context.Context, id uint, password string) error
+	MarkEmailVerified(ctx context.Context, id uint) error
+	SetMFASecret(ctx context.Context, id uint, secret string) error
+	EnableMFA(ctx context.Context, id uint) error
+	DisableMFA(ctx context.Context, id uint) error
+	// UseMFAStep records step as the last accepted TOTP step. It reports false
+	// if the same or a later step was already used, which rejects replays.
+	UseMFAStep(ctx context.Context, id uint, step int64) (bool, error)
+	RecordFailedLogin(ctx context.Context, id uint) error
+	Lock(ctx context.Context, id uint, until time.Time) error
+	// ResetFailedLogins clears the failed login counter and any lockout.
+	ResetFailedLogins(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, params pagination.Params) ([]*entity.User, pagination.Meta, error)
 }
 
 type userRepository struct {
@@ -22,42 +39,115 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) Create(user *entity.User) error {
-	return r.db.Create(user).Error
+func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
 }
 
-func (r *userRepository) GetByID(id uint) (*entity.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*entity.User, error) {
 	var user entity.User
-	err := r.db.First(&user, id).Error
+	err := r.db.WithContext(ctx).First(&user, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepository) GetByEmail(email string) (*entity.User, error) {
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepository) Update(user *entity.User) error {
+func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 	// Only update non-password fields to avoid rehashing
-	return r.db.Model(user).Select("name", "email", "token", "expires_at", "updated_at").Updates(user).Error
+	return r.db.WithContext(ctx).Model(user).Select("name", "email", "updated_at").Updates(user).Error
 }
 
-func (r *userRepository) Delete(id uint) error {
-	return r.db.Delete(&entity.User{}, id).Error
+func (r *userRepository) UpdateRole(ctx context.Context, id uint, role entity.Role) error {
+	result := r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumn("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
-func (r *userRepository) List(page, limit int) ([]*entity.User, error) {
-	var users []*entity.User
-	err := r.db.Offset((page - 1) * limit).Limit(limit).Find(&users).Error
+// UpdatePassword hashes password and stores it. It bypasses the BeforeSave
+// hook, which would otherwise hash the value a second time.
+func (r *userRepository) UpdatePassword(ctx context.Context, id uint, password string) error {
+	hashedPassword, err := entity.HashPassword(password)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumn("password", hashedPassword).Error
+}
+
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		UpdateColumn("email_verified_at", time.Now()).Error
+}
+
+func (r *userRepository) SetMFASecret(ctx context.Context, id uint, secret string) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumn("mfa_secret", secret).Error
+}
+
+func (r *userRepository) EnableMFA(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumn("mfa_enabled_at", time.Now()).Error
+}
+
+func (r *userRepository) DisableMFA(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"mfa_secret":     "",
+		"mfa_enabled_at": nil,
+		"mfa_last_step":  0,
+	}).Error
+}
+
+func (r *userRepository) UseMFAStep(ctx context.Context, id uint, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND mfa_last_step < ?", id, step).
+		UpdateColumn("mfa_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *userRepository) RecordFailedLogin(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).
+		UpdateColumn("failed_logins", gorm.Expr("failed_logins + 1")).Error
+}
+
+func (r *userRepository) Lock(ctx context.Context, id uint, until time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumn("locked_until", until).Error
+}
+
+func (r *userRepository) ResetFailedLogins(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"failed_logins": 0,
+		"locked_until":  nil,
+	}).Error
+}
+
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.User{}, id).Error
+}
+
+func (r *userRepository) List(ctx context.Context, params pagination.Params) ([]*entity.User, pagination.Meta, error) {
+	users, meta, err := pagination.Find[entity.User](r.db.WithContext(ctx), params)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	result := make([]*entity.User, len(users))
+	for i := range users {
+		result[i] = &users[i]
 	}
-	return users, nil
+	return result, meta, nil
 }
\ No newline at end of file
@@ -1,12 +1,14 @@
 package repository
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go-backend/internal/infrastructure/database"
 	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -28,7 +30,7 @@ func TestUserRepository_Create(t *testing.T) {
 		Password: "password123",
 	}
 
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 	assert.NoError(t, err)
 	assert.NotZero(t, user.ID)
 	assert.False(t, user.CreatedAt.IsZero())
@@ -48,11 +50,11 @@ func TestUserRepository_GetByID(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 	assert.NoError(t, err)
 
 	// Get the user by ID
-	found, err := repo.GetByID(user.ID)
+	found, err := repo.GetByID(context.Background(), user.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, found)
 	assert.Equal(t, user.ID, found.ID)
@@ -67,7 +69,7 @@ func TestUserRepository_GetByID_NotFound(t *testing.T) {
 
 	repo := NewUserRepository(db)
 
-	found, err := repo.GetByID(999)
+	found, err := repo.GetByID(context.Background(), 999)
 	assert.Error(t, err)
 	assert.Nil(t, found)
 }
@@ -85,11 +87,11 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 	assert.NoError(t, err)
 
 	// Get the user by email
-	found, err := repo.GetByEmail(user.Email)
+	found, err := repo.GetByEmail(context.Background(), user.Email)
 	assert.NoError(t, err)
 	assert.NotNil(t, found)
 	assert.Equal(t, user.ID, found.ID)
@@ -104,7 +106,7 @@ func TestUserRepository_GetByEmail_NotFound(t *testing.T) {
 
 	repo := NewUserRepository(db)
 
-	found, err := repo.GetByEmail("nonexistent@example.com")
+	found, err := repo.GetByEmail(context.Background(), "nonexistent@example.com")
 	assert.Error(t, err)
 	assert.Nil(t, found)
 }
@@ -122,18 +124,18 @@ func TestUserRepository_Update(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 	assert.NoError(t, err)
 
 	// Update the user
 	oldUpdatedAt := user.UpdatedAt
 	time.Sleep(time.Millisecond) // Ensure time difference
 	user.Name = "Updated User"
-	err = repo.Update(user)
+	err = repo.Update(context.Background(), user)
 	assert.NoError(t, err)
 
 	// Verify the update
-	found, err := repo.GetByID(user.ID)
+	found, err := repo.GetByID(context.Background(), user.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated User", found.Name)
 	assert.True(t, found.UpdatedAt.After(oldUpdatedAt))
@@ -152,15 +154,15 @@ func TestUserRepository_Delete(t *testing.T) {
 		Email:    "test@example.com",
 		Password: "password123",
 	}
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 	assert.NoError(t, err)
 
 	// Delete the user
-	err = repo.Delete(user.ID)
+	err = repo.Delete(context.Background(), user.ID)
 	assert.NoError(t, err)
 
 	// Verify the deletion
-	found, err := repo.GetByID(user.ID)
+	found, err := repo.GetByID(context.Background(), user.ID)
 	assert.Error(t, err)
 	assert.Nil(t, found)
 }
@@ -180,17 +182,21 @@ func TestUserRepository_List(t *testing.T) {
 	}
 
 	for _, user := range users {
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 		assert.NoError(t, err)
 	}
 
+	byID := pagination.Sort{Field: "id"}
+
 	// Test listing with pagination
-	found, err := repo.List(1, 2)
+	found, meta, err := repo.List(context.Background(), pagination.Params{Page: 1, Limit: 2, Sort: byID})
 	assert.NoError(t, err)
 	assert.Len(t, found, 2)
+	assert.Equal(t, int64(len(users)), meta.Total)
+	assert.NotEmpty(t, meta.NextCursor)
 
 	// Test listing all users
-	found, err = repo.List(1, 10)
+	found, _, err = repo.List(context.Background(), pagination.Params{Page: 1, Limit: 10, Sort: byID})
 	assert.NoError(t, err)
 	assert.Len(t, found, len(users))
 
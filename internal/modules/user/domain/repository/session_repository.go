@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-backend/internal/modules/user/domain/entity"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	GetByID(ctx context.Context, id string) (*entity.Session, error)
+	ListActiveByUserID(ctx context.Context, userID uint) ([]entity.Session, error)
+	Update(ctx context.Context, session *entity.Session) error
+	Touch(ctx context.Context, id string, ipAddress string, seenAt time.Time) error
+	Revoke(ctx context.Context, id string) error
+	// RevokeByUserID revokes every active session of the user except the one
+	// with ID exceptID. Pass an empty exceptID to revoke all of them.
+	RevokeByUserID(ctx context.Context, userID uint, exceptID string) ([]string, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id string) (*entity.Session, error) {
+	var session entity.Session
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) ListActiveByUserID(ctx context.Context, userID uint) ([]entity.Session, error) {
+	var sessions []entity.Session
+	err := r.db.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session *entity.Session) error {
+	return r.db.WithContext(ctx).Save(session).Error
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id string, ipAddress string, seenAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.Session{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"last_seen_at": seenAt,
+			"ip_address":   ipAddress,
+		}).Error
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Model(&entity.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *sessionRepository) RevokeByUserID(ctx context.Context, userID uint, exceptID string) ([]string, error) {
+	query := r.db.WithContext(ctx).Model(&entity.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if exceptID != "" {
+		query = query.Where("id <> ?", exceptID)
+	}
+
+	var ids []string
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	err := r.db.WithContext(ctx).Model(&entity.Session{}).
+		Where("id IN ?", ids).
+		Update("revoked_at", time.Now()).Error
+	return ids, err
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"go-backend/internal/infrastructure/logging"
+	"go-backend/internal/infrastructure/mailer"
+	"go-backend/internal/infrastructure/tracing"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/domain/repository"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"gorm.io/gorm"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 24 * time.Hour
+	// linkEmailInterval is how long a user has to wait between emails with
+	// a link of the same purpose, so that the endpoints sending them cannot
+	// be used to flood an inbox.
+	linkEmailInterval = time.Minute
+)
+
+var (
+	ErrInvalidResetToken        = apperror.BadRequest("invalid or expired reset token")
+	ErrInvalidVerificationToken = apperror.BadRequest("invalid or expired verification token")
+	ErrRegistrationDisabled     = apperror.Forbidden("registration is disabled")
+	ErrEmailTaken               = apperror.Conflict("email is already registered")
+)
+
+// AccountService covers the account flows that are driven by links sent by
+// email rather than by an authenticated session.
+type AccountService interface {
+	Register(ctx context.Context, req *dto.RegisterRequest) (*dto.UserResponse, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error
+	RequestPasswordReset(ctx context.Context, req *dto.ResetPasswordRequest) error
+	ConfirmPasswordReset(ctx context.Context, req *dto.ConfirmResetPasswordRequest) error
+}
+
+type AccountConfig struct {
+	// RegistrationEnabled opens POST /auth/register to the public.
+	RegistrationEnabled bool
+	// EmailVerificationURL and PasswordResetURL are the pages linked from the
+	// emails. The token is appended in the "token" query parameter.
+	EmailVerificationURL string
+	PasswordResetURL     string
+}
+
+type accountService struct {
+	repo             repository.UserRepository
+	tokenRepo        repository.OneTimeTokenRepository
+	sessionRepo      repository.SessionRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	sender           mailer.Sender
+	config           AccountConfig
+}
+
+func NewAccountService(
+	repo repository.UserRepository,
+	tokenRepo repository.OneTimeTokenRepository,
+	sessionRepo repository.SessionRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	sender mailer.Sender,
+	config AccountConfig,
+) AccountService {
+	return &accountService{
+		repo:             repo,
+		tokenRepo:        tokenRepo,
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sender:           sender,
+		config:           config,
+	}
+}
+
+// Register creates an unverified viewer account and emails a verification link.
+// The account is kept if the email cannot be sent; the user can ask for
+// another link with ResendVerification.
+func (s *accountService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "AccountService.Register")
+	defer span.End()
+
+	if !s.config.RegistrationEnabled {
+		return nil, ErrRegistrationDisabled
+	}
+
+	if _, err := s.repo.GetByEmail(ctx, req.Email); err == nil {
+		return nil, ErrEmailTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user := &entity.User{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
+		Role:     entity.RoleViewer,
+	}
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	logger := logging.FromContext(ctx)
+	logger.InfoContext(ctx, "user registered", slog.Uint64("user_id", uint64(user.ID)))
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		logger.ErrorContext(ctx, "verification email failed", slog.Uint64("user_id", uint64(user.ID)), slog.Any("error", err))
+	}
+
+	return toUserResponse(user), nil
+}
+
+// ResendVerification emails a new verification link to an unverified user,
+// invalidating the earlier one. Like RequestPasswordReset, it does not report
+// unknown or already verified addresses, nor requests made within
+// linkEmailInterval of the last link.
+func (s *accountService) ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error {
+	ctx, span := tracing.Start(ctx, "AccountService.ResendVerification")
+	defer span.End()
+
+	user, err := s.repo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	recent, err := s.tokenRepo.IssuedSince(ctx, user.ID, entity.PurposeEmailVerification, time.Now().Add(-linkEmailInterval))
+	if err != nil {
+		return err
+	}
+	if recent {
+		return nil
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+// sendVerification issues a verification token for user and emails the link.
+func (s *accountService) sendVerification(ctx context.Context, user *entity.User) error {
+	token, err := s.issueToken(ctx, user.ID, entity.PurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link, err := withToken(s.config.EmailVerificationURL, token)
+	if err != nil {
+		return err
+	}
+
+	return s.sender.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below within %d hours:\n\n%s\n\nIf you did not create an account, you can ignore this email.",
+			user.Name, int(emailVerificationTTL.Hours()), link,
+		),
+	})
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	ctx, span := tracing.Start(ctx, "AccountService.VerifyEmail")
+	defer span.End()
+
+	stored, err := s.redeemToken(ctx, entity.PurposeEmailVerification, token)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+
+	return s.repo.MarkEmailVerified(ctx, stored.UserID)
+}
+
+// RequestPasswordReset emails a reset link to the user. Unknown addresses are
+// not reported so the endpoint cannot be used to discover accounts, and
+// neither are requests made within linkEmailInterval of the last link.
+func (s *accountService) RequestPasswordReset(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "AccountService.RequestPasswordReset")
+	defer span.End()
+
+	user, err := s.repo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	recent, err := s.tokenRepo.IssuedSince(ctx, user.ID, entity.PurposePasswordReset, time.Now().Add(-linkEmailInterval))
+	if err != nil {
+		return err
+	}
+	if recent {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, entity.PurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	link, err := withToken(s.config.PasswordResetURL, token)
+	if err != nil {
+		return err
+	}
+
+	return s.sender.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Use the link below within %d minutes:\n\n%s\n\nIf you did not request this, you can ignore this email.",
+			user.Name, int(passwordResetTTL.Minutes()), link,
+		),
+	})
+}
+
+// ConfirmPasswordReset sets a new password and logs the user out everywhere.
+func (s *accountService) ConfirmPasswordReset(ctx context.Context, req *dto.ConfirmResetPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "AccountService.ConfirmPasswordReset")
+	defer span.End()
+
+	stored, err := s.redeemToken(ctx, entity.PurposePasswordReset, req.Token)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.repo.UpdatePassword(ctx, stored.UserID, req.Password); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "password reset", slog.Uint64("user_id", uint64(stored.UserID)))
+
+	if _, err := s.sessionRepo.RevokeByUserID(ctx, stored.UserID, ""); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeByUserID(ctx, stored.UserID)
+}
+
+// issueToken invalidates the user's outstanding tokens for purpose and creates
+// a new one. It returns the raw token; only its hash is stored.
+func (s *accountService) issueToken(ctx context.Context, userID uint, purpose entity.TokenPurpose, ttl time.Duration) (string, error) {
+	if err := s.tokenRepo.InvalidateByUserID(ctx, userID, purpose); err != nil {
+		return "", err
+	}
+
+	token, err := randomID()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.Create(ctx, &entity.OneTimeToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// redeemToken looks up and consumes a token. Any failure is reported as an
+// invalid token.
+func (s *accountService) redeemToken(ctx context.Context, purpose entity.TokenPurpose, token string) (*entity.OneTimeToken, error) {
+	stored, err := s.tokenRepo.GetByHash(ctx, purpose, hashToken(token))
+	if err != nil || !stored.IsUsable() {
+		return nil, errors.New("token not usable")
+	}
+
+	used, err := s.tokenRepo.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, errors.New("token already used")
+	}
+
+	return stored, nil
+}
+
+// withToken appends token to rawURL as the "token" query parameter.
+func withToken(rawURL, token string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
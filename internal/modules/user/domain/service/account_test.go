@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-backend/internal/infrastructure/mailer"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/modules/user/mocks"
+	"gorm.io/gorm"
+)
+
+type accountMocks struct {
+	repo        *mocks.MockUserRepository
+	tokenRepo   *mocks.MockOneTimeTokenRepository
+	sessionRepo *mocks.MockSessionRepository
+	refreshRepo *mocks.MockRefreshTokenRepository
+	sender      *mocks.MockSender
+}
+
+func newTestAccountService() (AccountService, *accountMocks) {
+	return newTestAccountServiceWithConfig(AccountConfig{
+		RegistrationEnabled:  true,
+		EmailVerificationURL: "http://localhost:8080/api/auth/verify",
+		PasswordResetURL:     "http://localhost:3000/reset-password",
+	})
+}
+
+func newTestAccountServiceWithConfig(config AccountConfig) (AccountService, *accountMocks) {
+	m := &accountMocks{
+		repo:        new(mocks.MockUserRepository),
+		tokenRepo:   new(mocks.MockOneTimeTokenRepository),
+		sessionRepo: new(mocks.MockSessionRepository),
+		refreshRepo: new(mocks.MockRefreshTokenRepository),
+		sender:      new(mocks.MockSender),
+	}
+	svc := NewAccountService(m.repo, m.tokenRepo, m.sessionRepo, m.refreshRepo, m.sender, config)
+	return svc, m
+}
+
+func TestAccountService_Register(t *testing.T) {
+	req := &dto.RegisterRequest{Name: "New User", Email: "new@example.com", Password: "password123"}
+
+	t.Run("creates an unverified viewer and sends a verification link", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.repo.On("GetByEmail", mock.Anything, "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+		m.repo.On("Create", mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Role == entity.RoleViewer && user.EmailVerifiedAt == nil
+		})).Return(nil)
+		m.tokenRepo.On("InvalidateByUserID", mock.Anything, mock.Anything, entity.PurposeEmailVerification).Return(nil)
+		m.tokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(token *entity.OneTimeToken) bool {
+			return token.Purpose == entity.PurposeEmailVerification
+		})).Return(nil)
+		m.sender.On("Send", mock.MatchedBy(func(msg mailer.Message) bool {
+			return msg.To == "new@example.com" && strings.Contains(msg.Body, "/api/auth/verify?token=")
+		})).Return(nil)
+
+		user, err := svc.Register(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Nil(t, user.EmailVerifiedAt)
+		m.repo.AssertExpectations(t)
+		m.tokenRepo.AssertExpectations(t)
+		m.sender.AssertExpectations(t)
+	})
+
+	t.Run("keeps the account when the email cannot be sent", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.repo.On("GetByEmail", mock.Anything, "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+		m.repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+		m.tokenRepo.On("InvalidateByUserID", mock.Anything, mock.Anything, entity.PurposeEmailVerification).Return(nil)
+		m.tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.OneTimeToken")).Return(nil)
+		m.sender.On("Send", mock.AnythingOfType("mailer.Message")).Return(errors.New("smtp unavailable"))
+
+		user, err := svc.Register(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", user.Email)
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabled by config", func(t *testing.T) {
+		svc, m := newTestAccountServiceWithConfig(AccountConfig{})
+
+		_, err := svc.Register(context.Background(), req)
+		assert.ErrorIs(t, err, ErrRegistrationDisabled)
+		m.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("email already registered", func(t *testing.T) {
+		svc, m := newTestAccountService()
+		m.repo.On("GetByEmail", mock.Anything, "new@example.com").Return(&entity.User{ID: 1}, nil)
+
+		_, err := svc.Register(context.Background(), req)
+		assert.ErrorIs(t, err, ErrEmailTaken)
+		m.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	t.Run("marks the email verified", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.tokenRepo.On("GetByHash", mock.Anything, entity.PurposeEmailVerification, hashToken("verify-token")).Return(&entity.OneTimeToken{
+			ID:        7,
+			UserID:    3,
+			Purpose:   entity.PurposeEmailVerification,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		m.tokenRepo.On("MarkUsed", mock.Anything, uint(7)).Return(true, nil)
+		m.repo.On("MarkEmailVerified", mock.Anything, uint(3)).Return(nil)
+
+		err := svc.VerifyEmail(context.Background(), "verify-token")
+		assert.NoError(t, err)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a password reset token", func(t *testing.T) {
+		svc, m := newTestAccountService()
+		m.tokenRepo.On("GetByHash", mock.Anything, entity.PurposeEmailVerification, hashToken("reset-token")).Return(nil, gorm.ErrRecordNotFound)
+
+		err := svc.VerifyEmail(context.Background(), "reset-token")
+		assert.ErrorIs(t, err, ErrInvalidVerificationToken)
+		m.repo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountService_ResendVerification(t *testing.T) {
+	req := &dto.ResendVerificationRequest{Email: "new@example.com"}
+
+	t.Run("sends a new link to an unverified user", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.repo.On("GetByEmail", mock.Anything, "new@example.com").Return(&entity.User{ID: 3, Email: "new@example.com"}, nil)
+		m.tokenRepo.On("IssuedSince", mock.Anything, uint(3), entity.PurposeEmailVerification, mock.AnythingOfType("time.Time")).Return(false, nil)
+		m.tokenRepo.On("InvalidateByUserID", mock.Anything, uint(3), entity.PurposeEmailVerification).Return(nil)
+		m.tokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.OneTimeToken")).Return(nil)
+		m.sender.On("Send", mock.MatchedBy(func(msg mailer.Message) bool {
+			return msg.To == "new@example.com" && strings.Contains(msg.Body, "/api/auth/verify?token=")
+		})).Return(nil)
+
+		assert.NoError(t, svc.ResendVerification(context.Background(), req))
+		m.tokenRepo.AssertExpectations(t)
+		m.sender.AssertExpectations(t)
+	})
+
+	t.Run("waits between links", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.repo.On("GetByEmail", mock.Anything, "new@example.com").Return(&entity.User{ID: 3, Email: "new@example.com"}, nil)
+		m.tokenRepo.On("IssuedSince", mock.Anything, uint(3), entity.PurposeEmailVerification, mock.AnythingOfType("time.Time")).Return(true, nil)
+
+		assert.NoError(t, svc.ResendVerification(context.Background(), req))
+		m.sender.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("ignores verified and unknown emails", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		verifiedAt := time.Now()
+		m.repo.On("GetByEmail", mock.Anything, "new@example.com").Return(&entity.User{ID: 3, EmailVerifiedAt: &verifiedAt}, nil)
+		m.repo.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		assert.NoError(t, svc.ResendVerification(context.Background(), req))
+		assert.NoError(t, svc.ResendVerification(context.Background(), &dto.ResendVerificationRequest{Email: "nobody@example.com"}))
+		m.sender.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestAccountService_RequestPasswordReset(t *testing.T) {
+	t.Run("sends a link with a token whose hash is stored", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		var storedHash string
+		m.repo.On("GetByEmail", mock.Anything, "test@example.com").Return(&entity.User{ID: 1, Name: "Test", Email: "test@example.com"}, nil)
+		m.tokenRepo.On("IssuedSince", mock.Anything, uint(1), entity.PurposePasswordReset, mock.AnythingOfType("time.Time")).Return(false, nil)
+		m.tokenRepo.On("InvalidateByUserID", mock.Anything, uint(1), entity.PurposePasswordReset).Return(nil)
+		m.tokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(token *entity.OneTimeToken) bool {
+			storedHash = token.TokenHash
+			return token.UserID == 1 && token.Purpose == entity.PurposePasswordReset && token.ExpiresAt.After(time.Now())
+		})).Return(nil)
+		m.sender.On("Send", mock.MatchedBy(func(msg mailer.Message) bool {
+			_, token, found := strings.Cut(msg.Body, "reset-password?token=")
+			token, _, _ = strings.Cut(token, "\n")
+			return found && msg.To == "test@example.com" && hashToken(token) == storedHash
+		})).Return(nil)
+
+		err := svc.RequestPasswordReset(context.Background(), &dto.ResetPasswordRequest{Email: "test@example.com"})
+		assert.NoError(t, err)
+		m.tokenRepo.AssertExpectations(t)
+		m.sender.AssertExpectations(t)
+	})
+
+	t.Run("waits between links", func(t *testing.T) {
+		svc, m := newTestAccountService()
+		m.repo.On("GetByEmail", mock.Anything, "test@example.com").Return(&entity.User{ID: 1, Email: "test@example.com"}, nil)
+		m.tokenRepo.On("IssuedSince", mock.Anything, uint(1), entity.PurposePasswordReset, mock.AnythingOfType("time.Time")).Return(true, nil)
+
+		err := svc.RequestPasswordReset(context.Background(), &dto.ResetPasswordRequest{Email: "test@example.com"})
+		assert.NoError(t, err)
+		m.tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.sender.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("unknown email is not reported", func(t *testing.T) {
+		svc, m := newTestAccountService()
+		m.repo.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		err := svc.RequestPasswordReset(context.Background(), &dto.ResetPasswordRequest{Email: "nobody@example.com"})
+		assert.NoError(t, err)
+		m.sender.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
+
+func TestAccountService_ConfirmPasswordReset(t *testing.T) {
+	t.Run("sets the password and revokes sessions", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.tokenRepo.On("GetByHash", mock.Anything, entity.PurposePasswordReset, hashToken("reset-token")).Return(&entity.OneTimeToken{
+			ID:        5,
+			UserID:    1,
+			Purpose:   entity.PurposePasswordReset,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		m.tokenRepo.On("MarkUsed", mock.Anything, uint(5)).Return(true, nil)
+		m.repo.On("UpdatePassword", mock.Anything, uint(1), "newpassword").Return(nil)
+		m.sessionRepo.On("RevokeByUserID", mock.Anything, uint(1), "").Return([]string{"session-1"}, nil)
+		m.refreshRepo.On("RevokeByUserID", mock.Anything, uint(1)).Return(nil)
+
+		err := svc.ConfirmPasswordReset(context.Background(), &dto.ConfirmResetPasswordRequest{Token: "reset-token", Password: "newpassword"})
+		assert.NoError(t, err)
+		m.repo.AssertExpectations(t)
+		m.sessionRepo.AssertExpectations(t)
+		m.refreshRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.tokenRepo.On("GetByHash", mock.Anything, entity.PurposePasswordReset, hashToken("reset-token")).Return(&entity.OneTimeToken{
+			ID:        5,
+			UserID:    1,
+			Purpose:   entity.PurposePasswordReset,
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		err := svc.ConfirmPasswordReset(context.Background(), &dto.ConfirmResetPasswordRequest{Token: "reset-token", Password: "newpassword"})
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+		m.repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a token redeemed concurrently", func(t *testing.T) {
+		svc, m := newTestAccountService()
+
+		m.tokenRepo.On("GetByHash", mock.Anything, entity.PurposePasswordReset, hashToken("reset-token")).Return(&entity.OneTimeToken{
+			ID:        5,
+			UserID:    1,
+			Purpose:   entity.PurposePasswordReset,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		m.tokenRepo.On("MarkUsed", mock.Anything, uint(5)).Return(false, nil)
+
+		err := svc.ConfirmPasswordReset(context.Background(), &dto.ConfirmResetPasswordRequest{Token: "reset-token", Password: "newpassword"})
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+		m.repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/modules/user/mocks"
+	"gorm.io/gorm"
+)
+
+func TestAPIKeyService_Create(t *testing.T) {
+	t.Run("stores only the hash and shows the key once", func(t *testing.T) {
+		mockRepo := new(mocks.MockAPIKeyRepository)
+		svc := NewAPIKeyService(mockRepo)
+
+		var stored *entity.APIKey
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.APIKey")).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*entity.APIKey)
+		}).Return(nil)
+
+		resp, err := svc.Create(context.Background(), 1, &dto.CreateAPIKeyRequest{Name: "CI", Scopes: []string{"projects:write"}})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(resp.Key, apiKeyPrefix))
+		assert.Equal(t, resp.Key[:apiKeyDisplayLength], resp.Prefix)
+		assert.Equal(t, []string{"projects:write"}, resp.Scopes)
+		assert.Equal(t, entity.HashAPIKey(resp.Key), stored.KeyHash)
+		assert.NotContains(t, stored.KeyHash, resp.Key)
+	})
+
+	t.Run("defaults to read-only", func(t *testing.T) {
+		mockRepo := new(mocks.MockAPIKeyRepository)
+		svc := NewAPIKeyService(mockRepo)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.APIKey")).Return(nil)
+
+		resp, err := svc.Create(context.Background(), 1, &dto.CreateAPIKeyRequest{Name: "Reader"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"read"}, resp.Scopes)
+	})
+
+	t.Run("rejects unknown scopes and past expiry", func(t *testing.T) {
+		svc := NewAPIKeyService(new(mocks.MockAPIKeyRepository))
+
+		_, err := svc.Create(context.Background(), 1, &dto.CreateAPIKeyRequest{Name: "CI", Scopes: []string{"users:write"}})
+		assert.ErrorIs(t, err, ErrInvalidAPIKeyScope)
+
+		past := time.Now().Add(-time.Minute)
+		_, err = svc.Create(context.Background(), 1, &dto.CreateAPIKeyRequest{Name: "CI", ExpiresAt: &past})
+		assert.ErrorIs(t, err, ErrInvalidAPIKeyExpiry)
+	})
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	mockRepo := new(mocks.MockAPIKeyRepository)
+	svc := NewAPIKeyService(mockRepo)
+	mockRepo.On("Revoke", mock.Anything, uint(1), uint(5)).Return(nil)
+	mockRepo.On("Revoke", mock.Anything, uint(1), uint(6)).Return(gorm.ErrRecordNotFound)
+
+	assert.NoError(t, svc.Revoke(context.Background(), 1, 5))
+	assert.ErrorIs(t, svc.Revoke(context.Background(), 1, 6), ErrAPIKeyNotFound)
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	key := &entity.APIKey{Scopes: []entity.APIKeyScope{entity.ScopePostsWrite}}
+
+	assert.True(t, key.HasScope(entity.ScopeRead))
+	assert.True(t, key.HasScope(entity.ScopePostsWrite))
+	assert.False(t, key.HasScope(entity.ScopeProjectsWrite))
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"go-backend/internal/infrastructure/logging"
+	"go-backend/internal/infrastructure/tracing"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/pkg/apperror"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrTooManyAttempts is wrapped by TooManyAttemptsError.
+var ErrTooManyAttempts = apperror.TooManyRequests("too many failed login attempts")
+
+// TooManyAttemptsError is returned while an account or IP address is throttled
+// or locked out. RetryAfter is how long the caller has to wait.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+// Unwrap returns ErrTooManyAttempts, which also makes the error a 429
+// apperror.Error.
+func (e *TooManyAttemptsError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// LockoutConfig sets the throttling of failed logins per account and per IP
+// address. A zero policy is replaced by its default.
+type LockoutConfig struct {
+	Account ThrottlePolicy
+	IP      ThrottlePolicy
+}
+
+func (c LockoutConfig) withDefaults() LockoutConfig {
+	if c.Account.MaxAttempts == 0 {
+		c.Account = ThrottlePolicy{
+			FreeAttempts:    3,
+			BaseDelay:       time.Second,
+			MaxAttempts:     10,
+			LockoutDuration: 15 * time.Minute,
+			Window:          time.Hour,
+		}
+	}
+	if c.IP.MaxAttempts == 0 {
+		// An IP address may be shared by many users, so it gets more room.
+		c.IP = ThrottlePolicy{
+			FreeAttempts:    10,
+			BaseDelay:       time.Second,
+			MaxAttempts:     50,
+			LockoutDuration: 15 * time.Minute,
+			Window:          time.Hour,
+		}
+	}
+	return c
+}
+
+var (
+	dummyPasswordOnce sync.Once
+	dummyPasswordHash []byte
+)
+
+// compareDummyPassword runs a bcrypt comparison that always fails.
+func compareDummyPassword(password string) {
+	dummyPasswordOnce.Do(func() {
+		dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+}
+
+// Throttle keys are derived from the submitted email rather than the user ID,
+// so unknown emails are throttled exactly like registered ones.
+func accountThrottleKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s *userService) checkLoginThrottle(email, ipAddress string, now time.Time) error {
+	wait := s.accountThrottle.wait(accountThrottleKey(email), now)
+	if ipWait := s.ipThrottle.wait(ipAddress, now); ipWait > wait {
+		wait = ipWait
+	}
+	if wait > 0 {
+		return &TooManyAttemptsError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// loginFailed records a failed attempt and locks the account once the account
+// policy says so. user is nil when the email is unknown. It returns
+// ErrInvalidCredentials unless recording the failure fails.
+func (s *userService) loginFailed(ctx context.Context, user *entity.User, email, ipAddress string, now time.Time) error {
+	s.ipThrottle.fail(ipAddress, now)
+	locked := s.accountThrottle.fail(accountThrottleKey(email), now)
+
+	log := logging.FromContext(ctx)
+	if user == nil {
+		log.InfoContext(ctx, "login failed for unknown email", slog.String("ip_address", ipAddress))
+		return ErrInvalidCredentials
+	}
+	log.InfoContext(ctx, "login failed",
+		slog.Uint64("user_id", uint64(user.ID)),
+		slog.String("ip_address", ipAddress),
+	)
+
+	if err := s.repo.RecordFailedLogin(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if locked {
+		until := now.Add(s.accountThrottle.policy.LockoutDuration)
+		if err := s.repo.Lock(ctx, user.ID, until); err != nil {
+			return err
+		}
+		log.WarnContext(ctx, "account locked",
+			slog.Uint64("user_id", uint64(user.ID)),
+			slog.Time("until", until),
+		)
+		if err := s.auditRepo.Create(ctx, &entity.AuditEvent{
+			UserID:    &user.ID,
+			Event:     entity.AuditAccountLocked,
+			IPAddress: ipAddress,
+			Details:   fmt.Sprintf("locked until %s after repeated failed logins", until.UTC().Format(time.RFC3339)),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return ErrInvalidCredentials
+}
+
+// loginRefused answers a login to a locked account like a wrong password.
+// The lockout is persisted, so it outlives the in-memory throttle across
+// restarts and instances; a 429 from it alone would tell registered emails
+// from unknown ones, which only ever get the throttle's.
+func (s *userService) loginRefused(email, password, ipAddress string, now time.Time) error {
+	compareDummyPassword(password)
+	s.ipThrottle.fail(ipAddress, now)
+	s.accountThrottle.fail(accountThrottleKey(email), now)
+	return ErrInvalidCredentials
+}
+
+// loginSucceeded clears the failure counters of a fully authenticated user.
+// The IP address keeps its counter, since other accounts may be attacked from it.
+func (s *userService) loginSucceeded(ctx context.Context, user *entity.User) error {
+	s.accountThrottle.reset(accountThrottleKey(user.Email))
+	if user.FailedLogins == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	return s.repo.ResetFailedLogins(ctx, user.ID)
+}
+
+// Unlock lifts a lockout before it expires and clears the failure counters.
+func (s *userService) Unlock(ctx context.Context, id, actorID uint) error {
+	ctx, span := tracing.Start(ctx, "UserService.Unlock")
+	defer span.End()
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.ResetFailedLogins(ctx, user.ID); err != nil {
+		return err
+	}
+	s.accountThrottle.reset(accountThrottleKey(user.Email))
+
+	return s.auditRepo.Create(ctx, &entity.AuditEvent{
+		UserID:  &user.ID,
+		ActorID: &actorID,
+		Event:   entity.AuditAccountUnlocked,
+	})
+}
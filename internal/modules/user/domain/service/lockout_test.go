@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/modules/user/mocks"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func newTestLockoutService(lockout LockoutConfig) (UserService, *mocks.MockUserRepository, *mocks.MockAuditRepository) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockAuditRepo := new(mocks.MockAuditRepository)
+	svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), mockAuditRepo, new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{Lockout: lockout})
+	return svc, mockRepo, mockAuditRepo
+}
+
+func TestUserService_Login_UnknownEmailLooksLikeWrongPassword(t *testing.T) {
+	svc, mockRepo, _ := newTestLockoutService(LockoutConfig{})
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	mockRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(&entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}, nil)
+	mockRepo.On("RecordFailedLogin", mock.Anything, uint(1)).Return(nil)
+
+	_, unknownErr := svc.Login(context.Background(), &dto.LoginRequest{Email: "unknown@example.com", Password: "password123"})
+	_, wrongErr := svc.Login(context.Background(), &dto.LoginRequest{Email: "test@example.com", Password: "wrong"})
+
+	assert.ErrorIs(t, unknownErr, ErrInvalidCredentials)
+	assert.Equal(t, unknownErr, wrongErr)
+}
+
+func TestUserService_Login_Lockout(t *testing.T) {
+	lockout := LockoutConfig{
+		Account: ThrottlePolicy{FreeAttempts: 3, MaxAttempts: 3, LockoutDuration: time.Minute, Window: time.Hour},
+	}
+
+	t.Run("locks the account and audits it", func(t *testing.T) {
+		svc, mockRepo, mockAuditRepo := newTestLockoutService(lockout)
+		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+		user := &entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}
+
+		mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(user, nil)
+		mockRepo.On("RecordFailedLogin", mock.Anything, user.ID).Return(nil)
+		mockRepo.On("Lock", mock.Anything, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *entity.AuditEvent) bool {
+			return e.Event == entity.AuditAccountLocked && *e.UserID == user.ID && e.IPAddress == "10.0.0.1"
+		})).Return(nil)
+
+		for i := 0; i < 3; i++ {
+			_, err := svc.Login(context.Background(), &dto.LoginRequest{Email: "test@example.com", Password: "wrong", IPAddress: "10.0.0.1"})
+			assert.ErrorIs(t, err, ErrInvalidCredentials)
+		}
+
+		// Even the right password is refused while locked, and the email
+		// is matched case-insensitively.
+		_, err := svc.Login(context.Background(), &dto.LoginRequest{Email: "Test@Example.com", Password: "password123", IPAddress: "10.0.0.1"})
+		assert.ErrorIs(t, err, ErrTooManyAttempts)
+		var tooMany *TooManyAttemptsError
+		assert.ErrorAs(t, err, &tooMany)
+		assert.InDelta(t, time.Minute.Seconds(), tooMany.RetryAfter.Seconds(), 1)
+
+		mockRepo.AssertNumberOfCalls(t, "RecordFailedLogin", 3)
+		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown emails are throttled too", func(t *testing.T) {
+		svc, mockRepo, mockAuditRepo := newTestLockoutService(lockout)
+		mockRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		for i := 0; i < 3; i++ {
+			_, err := svc.Login(context.Background(), &dto.LoginRequest{Email: "unknown@example.com", Password: "wrong"})
+			assert.ErrorIs(t, err, ErrInvalidCredentials)
+		}
+
+		_, err := svc.Login(context.Background(), &dto.LoginRequest{Email: "unknown@example.com", Password: "wrong"})
+		assert.ErrorIs(t, err, ErrTooManyAttempts)
+		mockAuditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("persisted lockout is honoured like a wrong password", func(t *testing.T) {
+		svc, mockRepo, _ := newTestLockoutService(lockout)
+		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+		until := time.Now().Add(10 * time.Minute)
+		mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(&entity.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), LockedUntil: &until}, nil)
+		mockRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		_, lockedErr := svc.Login(context.Background(), &dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+		_, unknownErr := svc.Login(context.Background(), &dto.LoginRequest{Email: "unknown@example.com", Password: "password123"})
+		assert.ErrorIs(t, lockedErr, ErrInvalidCredentials)
+		assert.Equal(t, unknownErr, lockedErr, "a locked account looks like an unknown email")
+		mockRepo.AssertNotCalled(t, "RecordFailedLogin", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_Unlock(t *testing.T) {
+	svc, mockRepo, mockAuditRepo := newTestLockoutService(LockoutConfig{})
+	until := time.Now().Add(10 * time.Minute)
+	mockRepo.On("GetByID", mock.Anything, uint(2)).Return(&entity.User{ID: 2, Email: "test@example.com", LockedUntil: &until}, nil)
+	mockRepo.On("ResetFailedLogins", mock.Anything, uint(2)).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *entity.AuditEvent) bool {
+		return e.Event == entity.AuditAccountUnlocked && *e.UserID == 2 && *e.ActorID == 1
+	})).Return(nil)
+
+	err := svc.Unlock(context.Background(), 2, 1)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+}
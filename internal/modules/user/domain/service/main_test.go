@@ -0,0 +1,13 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	"go-backend/internal/infrastructure/jwtkeys"
+)
+
+func TestMain(m *testing.M) {
+	jwtkeys.SetDefault(jwtkeys.NewHMAC([]byte("test_secret")))
+	os.Exit(m.Run())
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go-backend/internal/infrastructure/tracing"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/totp"
+)
+
+const (
+	recoveryCodeCount = 10
+	// totpSkew accepts codes from one period before or after the current one
+	// to tolerate clock drift on the user's device.
+	totpSkew = 1
+)
+
+var (
+	ErrInvalidMFAToken      = apperror.Unauthorized("invalid or expired MFA token")
+	ErrInvalidMFACode       = apperror.Unauthorized("invalid MFA code")
+	ErrMFAAlreadyEnabled    = apperror.Conflict("MFA is already enabled")
+	ErrMFANotEnrolling      = apperror.Conflict("MFA enrollment has not been started")
+	ErrMFANotEnabled        = apperror.Conflict("MFA is not enabled")
+	ErrMFARequired          = apperror.Forbidden("MFA is required for this account")
+	ErrMFAEnrollmentPending = apperror.Conflict("MFA enrollment is required before logging in")
+)
+
+type MFAConfig struct {
+	// Issuer is the account label shown in authenticator apps.
+	Issuer string
+	// RequiredForAdmins forces admins to enroll before they can log in and
+	// prevents them from disabling MFA.
+	RequiredForAdmins bool
+}
+
+func (c MFAConfig) requiredFor(user *entity.User) bool {
+	return c.RequiredForAdmins && user.Role == entity.RoleAdmin
+}
+
+func (c MFAConfig) issuer() string {
+	if c.Issuer == "" {
+		return "Go Backend"
+	}
+	return c.Issuer
+}
+
+// VerifyMFA completes a login for a user with MFA enabled.
+func (s *userService) VerifyMFA(ctx context.Context, req *dto.MFALoginRequest) (*dto.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyMFA")
+	defer span.End()
+
+	user, claims, err := s.parseMFAChallenge(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsMFAEnabled() {
+		return nil, ErrMFAEnrollmentPending
+	}
+
+	// MFA codes are guessable too, so failures count towards the same
+	// lockout as wrong passwords.
+	now := time.Now()
+	if err := s.checkLoginThrottle(user.Email, req.IPAddress, now); err != nil {
+		return nil, err
+	}
+	if user.IsLocked(now) {
+		return nil, &TooManyAttemptsError{RetryAfter: user.LockedUntil.Sub(now)}
+	}
+
+	if err := s.verifyMFACode(ctx, user, req.Code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			if failErr := s.loginFailed(ctx, user, user.Email, req.IPAddress, now); !errors.Is(failErr, ErrInvalidCredentials) {
+				return nil, failErr
+			}
+		}
+		return nil, err
+	}
+
+	if err := s.loginSucceeded(ctx, user); err != nil {
+		return nil, err
+	}
+
+	device, _ := claims["device"].(string)
+	return s.startSession(ctx, user, device, req.IPAddress, req.UserAgent)
+}
+
+// BeginLoginMFAEnrollment starts enrollment for a user who must enroll before
+// their login can complete.
+func (s *userService) BeginLoginMFAEnrollment(ctx context.Context, req *dto.MFATokenRequest) (*dto.MFAEnrollmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.BeginLoginMFAEnrollment")
+	defer span.End()
+
+	user, _, err := s.parseMFAChallenge(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.beginMFAEnrollment(ctx, user)
+}
+
+// ConfirmLoginMFAEnrollment enables MFA with the first code from the
+// authenticator and completes the login. The response carries the recovery
+// codes, which are only shown this once.
+func (s *userService) ConfirmLoginMFAEnrollment(ctx context.Context, req *dto.MFALoginRequest) (*dto.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ConfirmLoginMFAEnrollment")
+	defer span.End()
+
+	user, claims, err := s.parseMFAChallenge(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.confirmMFAEnrollment(ctx, user, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loginSucceeded(ctx, user); err != nil {
+		return nil, err
+	}
+
+	device, _ := claims["device"].(string)
+	resp, err := s.startSession(ctx, user, device, req.IPAddress, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = codes
+	return resp, nil
+}
+
+func (s *userService) BeginMFAEnrollment(ctx context.Context, userID uint) (*dto.MFAEnrollmentResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.BeginMFAEnrollment")
+	defer span.End()
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.beginMFAEnrollment(ctx, user)
+}
+
+func (s *userService) ConfirmMFAEnrollment(ctx context.Context, userID uint, code string) (*dto.RecoveryCodesResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ConfirmMFAEnrollment")
+	defer span.End()
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.confirmMFAEnrollment(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA turns MFA off after checking a current code or a recovery code.
+func (s *userService) DisableMFA(ctx context.Context, userID uint, code string) error {
+	ctx, span := tracing.Start(ctx, "UserService.DisableMFA")
+	defer span.End()
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsMFAEnabled() {
+		return ErrMFANotEnabled
+	}
+	if s.mfaConfig.requiredFor(user) {
+		return ErrMFARequired
+	}
+
+	if err := s.verifyMFACode(ctx, user, code); err != nil {
+		return err
+	}
+
+	if err := s.recoveryCodeRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	return s.repo.DisableMFA(ctx, user.ID)
+}
+
+// beginMFAEnrollment stores a new pending secret. It replaces any earlier
+// pending secret, so restarting enrollment is always possible.
+func (s *userService) beginMFAEnrollment(ctx context.Context, user *entity.User) (*dto.MFAEnrollmentResponse, error) {
+	if user.IsMFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetMFASecret(ctx, user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrollmentResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.mfaConfig.issuer(), user.Email, secret),
+	}, nil
+}
+
+func (s *userService) confirmMFAEnrollment(ctx context.Context, user *entity.User, code string) ([]string, error) {
+	if user.IsMFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFASecret == "" {
+		return nil, ErrMFANotEnrolling
+	}
+
+	if err := s.verifyTOTP(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recoveryCodeRepo.Replace(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+	if err := s.repo.EnableMFA(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// verifyMFACode accepts either a TOTP code or an unused recovery code.
+func (s *userService) verifyMFACode(ctx context.Context, user *entity.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(ctx, user, code)
+	}
+
+	used, err := s.recoveryCodeRepo.Use(ctx, user.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (s *userService) verifyTOTP(ctx context.Context, user *entity.User, code string) error {
+	step, ok := totp.Validate(user.MFASecret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	fresh, err := s.repo.UseMFAStep(ctx, user.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// issueMFAChallenge returns a short-lived token that proves the password was
+// correct. It can only be exchanged at the MFA login endpoints.
+func (s *userService) issueMFAChallenge(user *entity.User, device string) (*dto.LoginResponse, error) {
+	now := time.Now()
+	token, err := signToken(jwt.MapClaims{
+		"user_id":    user.ID,
+		"token_type": mfaTokenType,
+		"device":     device,
+		"iat":        now.Unix(),
+		"exp":        now.Add(mfaTokenTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		UserID:                user.ID,
+		MFARequired:           user.IsMFAEnabled(),
+		MFAEnrollmentRequired: !user.IsMFAEnabled(),
+		MFAToken:              token,
+		ExpiresIn:             int64(mfaTokenTTL.Seconds()),
+		Status:                "mfa_required",
+	}, nil
+}
+
+func (s *userService) parseMFAChallenge(ctx context.Context, token string) (*entity.User, jwt.MapClaims, error) {
+	claims, err := parseToken(token, mfaTokenType)
+	if err != nil {
+		return nil, nil, ErrInvalidMFAToken
+	}
+
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, nil, ErrInvalidMFAToken
+	}
+
+	user, err := s.repo.GetByID(ctx, uint(userID))
+	if err != nil {
+		return nil, nil, ErrInvalidMFAToken
+	}
+	return user, claims, nil
+}
+
+// generateRecoveryCodes returns codes formatted as "xxxxx-xxxxx" together with
+// the hashes to store.
+func generateRecoveryCodes() ([]string, []string, error) {
+	const alphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		for j := range b {
+			b[j] = alphabet[int(b[j])%len(alphabet)]
+		}
+		codes[i] = string(b[:5]) + "-" + string(b[5:])
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/modules/user/mocks"
+	"go-backend/internal/pkg/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type mfaMocks struct {
+	repo         *mocks.MockUserRepository
+	refreshRepo  *mocks.MockRefreshTokenRepository
+	sessionRepo  *mocks.MockSessionRepository
+	recoveryRepo *mocks.MockRecoveryCodeRepository
+	auditRepo    *mocks.MockAuditRepository
+}
+
+func newTestMFAService(config MFAConfig) (UserService, *mfaMocks) {
+	m := &mfaMocks{
+		repo:         new(mocks.MockUserRepository),
+		refreshRepo:  new(mocks.MockRefreshTokenRepository),
+		sessionRepo:  new(mocks.MockSessionRepository),
+		recoveryRepo: new(mocks.MockRecoveryCodeRepository),
+		auditRepo:    new(mocks.MockAuditRepository),
+	}
+	return NewUserService(m.repo, m.refreshRepo, m.sessionRepo, m.recoveryRepo, m.auditRepo, new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{MFA: config}), m
+}
+
+func newMFAUser(t *testing.T, role entity.Role, enabled bool) *entity.User {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	user := &entity.User{
+		ID:        1,
+		Email:     "test@example.com",
+		Password:  string(hashedPassword),
+		Role:      role,
+		MFASecret: secret,
+	}
+	if enabled {
+		enabledAt := time.Now()
+		user.MFAEnabledAt = &enabledAt
+	}
+	return user
+}
+
+func TestUserService_Login_MFA(t *testing.T) {
+	t.Run("returns a challenge instead of tokens when MFA is enabled", func(t *testing.T) {
+		svc, m := newTestMFAService(MFAConfig{})
+		m.repo.On("GetByEmail", mock.Anything, "test@example.com").Return(newMFAUser(t, entity.RoleViewer, true), nil)
+
+		resp, err := svc.Login(context.Background(), &dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+		assert.NoError(t, err)
+		assert.True(t, resp.MFARequired)
+		assert.NotEmpty(t, resp.MFAToken)
+		assert.Empty(t, resp.AccessToken)
+		m.sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("forces admins to enroll when configured", func(t *testing.T) {
+		svc, m := newTestMFAService(MFAConfig{RequiredForAdmins: true})
+		m.repo.On("GetByEmail", mock.Anything, "test@example.com").Return(newMFAUser(t, entity.RoleAdmin, false), nil)
+
+		resp, err := svc.Login(context.Background(), &dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+		assert.NoError(t, err)
+		assert.True(t, resp.MFAEnrollmentRequired)
+		assert.NotEmpty(t, resp.MFAToken)
+		assert.Empty(t, resp.AccessToken)
+	})
+}
+
+func TestUserService_VerifyMFA(t *testing.T) {
+	login := func(t *testing.T, svc UserService, m *mfaMocks, user *entity.User) string {
+		m.repo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+		m.repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+		resp, err := svc.Login(context.Background(), &dto.LoginRequest{Email: user.Email, Password: "password123"})
+		assert.NoError(t, err)
+		return resp.MFAToken
+	}
+
+	t.Run("valid TOTP code starts a session", func(t *testing.T) {
+		svc, m := newTestMFAService(MFAConfig{})
+		user := newMFAUser(t, entity.RoleViewer, true)
+		mfaToken := login(t, svc, m, user)
+
+		now := time.Now()
+		code, err := totp.GenerateCode(user.MFASecret, now)
+		assert.NoError(t, err)
+		m.repo.On("UseMFAStep", mock.Anything, user.ID, totp.Step(now)).Return(true, nil)
+		m.sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil)
+		m.refreshRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+
+		resp, err := svc.VerifyMFA(context.Background(), &dto.MFALoginRequest{MFAToken: mfaToken, Code: code})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		m.sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("replayed TOTP code is rejected", func(t *testing.T) {
+		svc, m := newTestMFAService(MFAConfig{})
+		user := newMFAUser(t, entity.RoleViewer, true)
+		mfaToken := login(t, svc, m, user)
+
+		now := time.Now()
+		code, err := totp.GenerateCode(user.MFASecret, now)
+		assert.NoError(t, err)
+		m.repo.On("UseMFAStep", mock.Anything, user.ID, totp.Step(now)).Return(false, nil)
+		m.repo.On("RecordFailedLogin", mock.Anything, user.ID).Return(nil)
+
+		resp, err := svc.VerifyMFA(context.Background(), &dto.MFALoginRequest{MFAToken: mfaToken, Code: code})
+		assert.ErrorIs(t, err, ErrInvalidMFACode)
+		assert.Nil(t, resp)
+		m.sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.repo.AssertCalled(t, "RecordFailedLogin", mock.Anything, user.ID)
+	})
+
+	t.Run("recovery code starts a session", func(t *testing.T) {
+		svc, m := newTestMFAService(MFAConfig{})
+		user := newMFAUser(t, entity.RoleViewer, true)
+		mfaToken := login(t, svc, m, user)
+
+		m.recoveryRepo.On("Use", mock.Anything, user.ID, hashToken("abcdefghij")).Return(true, nil)
+		m.sessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Session")).Return(nil)
+		m.refreshRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+
+		resp, err := svc.VerifyMFA(context.Background(), &dto.MFALoginRequest{MFAToken: mfaToken, Code: "ABCDE-FGHIJ"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		m.recoveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("access token is not accepted as MFA token", func(t *testing.T) {
+		svc, _ := newTestMFAService(MFAConfig{})
+
+		resp, err := svc.VerifyMFA(context.Background(), &dto.MFALoginRequest{MFAToken: "not-a-token", Code: "123456"})
+		assert.ErrorIs(t, err, ErrInvalidMFAToken)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestUserService_ConfirmMFAEnrollment(t *testing.T) {
+	svc, m := newTestMFAService(MFAConfig{})
+	user := newMFAUser(t, entity.RoleViewer, false)
+
+	now := time.Now()
+	code, err := totp.GenerateCode(user.MFASecret, now)
+	assert.NoError(t, err)
+	m.repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	m.repo.On("UseMFAStep", mock.Anything, user.ID, totp.Step(now)).Return(true, nil)
+	m.recoveryRepo.On("Replace", mock.Anything, user.ID, mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == recoveryCodeCount
+	})).Return(nil)
+	m.repo.On("EnableMFA", mock.Anything, user.ID).Return(nil)
+
+	resp, err := svc.ConfirmMFAEnrollment(context.Background(), user.ID, code)
+	assert.NoError(t, err)
+	assert.Len(t, resp.RecoveryCodes, recoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, resp.RecoveryCodes[0])
+	m.repo.AssertExpectations(t)
+	m.recoveryRepo.AssertExpectations(t)
+}
+
+func TestUserService_DisableMFA(t *testing.T) {
+	t.Run("admins cannot disable MFA when it is required", func(t *testing.T) {
+		svc, m := newTestMFAService(MFAConfig{RequiredForAdmins: true})
+		user := newMFAUser(t, entity.RoleAdmin, true)
+		m.repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+		err := svc.DisableMFA(context.Background(), user.ID, "123456")
+		assert.ErrorIs(t, err, ErrMFARequired)
+		m.repo.AssertNotCalled(t, "DisableMFA", mock.Anything, mock.Anything)
+	})
+
+	t.Run("valid code disables MFA", func(t *testing.T) {
+		svc, m := newTestMFAService(MFAConfig{RequiredForAdmins: true})
+		user := newMFAUser(t, entity.RoleViewer, true)
+
+		now := time.Now()
+		code, err := totp.GenerateCode(user.MFASecret, now)
+		assert.NoError(t, err)
+		m.repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+		m.repo.On("UseMFAStep", mock.Anything, user.ID, totp.Step(now)).Return(true, nil)
+		m.recoveryRepo.On("DeleteByUserID", mock.Anything, user.ID).Return(nil)
+		m.repo.On("DisableMFA", mock.Anything, user.ID).Return(nil)
+
+		err = svc.DisableMFA(context.Background(), user.ID, code)
+		assert.NoError(t, err)
+		m.repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-backend/internal/infrastructure/oauth"
+	"go-backend/internal/infrastructure/tracing"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"gorm.io/gorm"
+)
+
+const (
+	// oauthStateTTL is how long the user has to complete the login at the
+	// provider.
+	oauthStateTTL = 10 * time.Minute
+	// oauthTimeout bounds the requests made to the provider.
+	oauthTimeout = 10 * time.Second
+)
+
+var (
+	ErrUnknownOAuthProvider      = apperror.NotFound("unknown OAuth provider")
+	ErrInvalidOAuthState         = apperror.BadRequest("invalid or expired OAuth state")
+	ErrOAuthEmailNotVerified     = apperror.Forbidden("the provider has not verified this email address")
+	ErrOAuthRegistrationDisabled = apperror.Forbidden("no account exists for this email and registration is disabled")
+	ErrOAuthAccountNotVerified   = apperror.Forbidden("an account with this email exists but its email is not verified; verify it before logging in with a provider")
+)
+
+type OAuthConfig struct {
+	// Providers are keyed by the name used in the login URL.
+	Providers map[string]oauth.Provider
+	// RegistrationEnabled creates an account on first login when no user
+	// has the provider's verified email.
+	RegistrationEnabled bool
+}
+
+// BeginOAuthLogin stores a new state and PKCE verifier and returns the URL
+// of the provider's consent page.
+func (s *userService) BeginOAuthLogin(ctx context.Context, providerName, device string) (string, error) {
+	ctx, span := tracing.Start(ctx, "UserService.BeginOAuthLogin")
+	defer span.End()
+
+	provider, ok := s.oauthConfig.Providers[providerName]
+	if !ok {
+		return "", ErrUnknownOAuthProvider
+	}
+
+	state, err := randomID()
+	if err != nil {
+		return "", err
+	}
+	verifier := oauth.NewVerifier()
+
+	now := time.Now()
+	// Abandoned logins are cleaned up here rather than by a background job
+	if err := s.oauthStateRepo.DeleteExpired(ctx, now); err != nil {
+		return "", err
+	}
+	if err := s.oauthStateRepo.Create(ctx, &entity.OAuthState{
+		StateHash:    hashToken(state),
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Device:       device,
+		ExpiresAt:    now.Add(oauthStateTTL),
+	}); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oauthTimeout)
+	defer cancel()
+	return provider.AuthCodeURL(ctx, state, verifier)
+}
+
+// CompleteOAuthLogin handles the provider's redirect. The identity is matched
+// to a user by a previous login with the same provider account, then by
+// verified email, and the user is created if registration is enabled. The
+// login then continues like a password login, including MFA.
+func (s *userService) CompleteOAuthLogin(ctx context.Context, providerName string, req *dto.OAuthCallbackRequest) (*dto.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CompleteOAuthLogin")
+	defer span.End()
+
+	provider, ok := s.oauthConfig.Providers[providerName]
+	if !ok {
+		return nil, ErrUnknownOAuthProvider
+	}
+
+	state, err := s.oauthStateRepo.Consume(ctx, hashToken(req.State))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidOAuthState
+		}
+		return nil, err
+	}
+	if state.Provider != providerName || time.Now().After(state.ExpiresAt) {
+		return nil, ErrInvalidOAuthState
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oauthTimeout)
+	defer cancel()
+	identity, err := provider.Exchange(ctx, req.Code, state.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userForIdentity(ctx, providerName, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsMFAEnabled() || s.mfaConfig.requiredFor(user) {
+		return s.issueMFAChallenge(user, state.Device)
+	}
+
+	return s.startSession(ctx, user, state.Device, req.IPAddress, req.UserAgent)
+}
+
+func (s *userService) userForIdentity(ctx context.Context, providerName string, identity *oauth.Identity) (*entity.User, error) {
+	link, err := s.identityRepo.GetByProviderSubject(ctx, providerName, identity.Subject)
+	if err == nil {
+		return s.repo.GetByID(ctx, link.UserID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Linking by email is only safe if the provider vouches for it
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, ErrOAuthEmailNotVerified
+	}
+
+	user, err := s.repo.GetByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		// Anyone can register an email they do not own. Linking would let the
+		// provider's user in while the registrant keeps the password, sessions
+		// and API keys of the account.
+		if !user.IsEmailVerified() {
+			return nil, ErrOAuthAccountNotVerified
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if user, err = s.registerOAuthUser(ctx, identity); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, &entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) registerOAuthUser(ctx context.Context, identity *oauth.Identity) (*entity.User, error) {
+	if !s.oauthConfig.RegistrationEnabled {
+		return nil, ErrOAuthRegistrationDisabled
+	}
+
+	// The account has no usable password until the user resets it
+	password, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	verifiedAt := time.Now()
+	user := &entity.User{
+		Name:            name,
+		Email:           identity.Email,
+		Password:        password,
+		Role:            entity.RoleViewer,
+		EmailVerifiedAt: &verifiedAt,
+	}
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
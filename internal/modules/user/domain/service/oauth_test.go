@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/infrastructure/oauth"
+	"go-backend/internal/infrastructure/oauth/oauthtest"
+	"go-backend/internal/modules/user/domain/entity"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/modules/user/mocks"
+	"gorm.io/gorm"
+)
+
+type oauthMocks struct {
+	repo          *mocks.MockUserRepository
+	refreshRepo   *mocks.MockRefreshTokenRepository
+	sessionRepo   *mocks.MockSessionRepository
+	identityRepo  *mocks.MockUserIdentityRepository
+	stateRepo     *mocks.MockOAuthStateRepository
+	stub          *oauthtest.Server
+	pendingStates map[string]*entity.OAuthState
+}
+
+func newTestOAuthService(t *testing.T, registrationEnabled bool) (UserService, *oauthMocks) {
+	m := &oauthMocks{
+		repo:          new(mocks.MockUserRepository),
+		refreshRepo:   new(mocks.MockRefreshTokenRepository),
+		sessionRepo:   new(mocks.MockSessionRepository),
+		identityRepo:  new(mocks.MockUserIdentityRepository),
+		stateRepo:     new(mocks.MockOAuthStateRepository),
+		stub:          oauthtest.NewServer(t, "client-1"),
+		pendingStates: make(map[string]*entity.OAuthState),
+	}
+
+	m.stateRepo.On("DeleteExpired", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+	m.stateRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.OAuthState")).Run(func(args mock.Arguments) {
+		state := args.Get(1).(*entity.OAuthState)
+		m.pendingStates[state.StateHash] = state
+	}).Return(nil)
+
+	provider := oauth.NewOIDCProvider(m.stub.URL, oauth.Config{ClientID: "client-1", RedirectURL: "http://localhost/callback"})
+	svc := NewUserService(m.repo, m.refreshRepo, m.sessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), m.identityRepo, m.stateRepo, Config{
+		OAuth: OAuthConfig{
+			Providers:           map[string]oauth.Provider{"stub": provider},
+			RegistrationEnabled: registrationEnabled,
+		},
+	})
+	return svc, m
+}
+
+// login runs the browser side of the flow and returns the callback request.
+func (m *oauthMocks) login(t *testing.T, svc UserService, user oauthtest.User) *dto.OAuthCallbackRequest {
+	authURL, err := svc.BeginOAuthLogin(context.Background(), "stub", "Laptop")
+	require.NoError(t, err)
+
+	code, state := m.stub.Authorize(authURL, user)
+	stateHash := hashToken(state)
+	m.stateRepo.On("Consume", mock.Anything, stateHash).Return(m.pendingStates[stateHash], nil).Once()
+
+	return &dto.OAuthCallbackRequest{Code: code, State: state, IPAddress: "127.0.0.1"}
+}
+
+func (m *oauthMocks) expectSession() {
+	m.sessionRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+		return s.Device == "Laptop"
+	})).Return(nil)
+	m.refreshRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+}
+
+var stubUser = oauthtest.User{Subject: "sub-1", Email: "dev@example.com", EmailVerified: true, Name: "Dev"}
+
+func TestUserService_OAuthLogin(t *testing.T) {
+	t.Run("returning user is found by identity", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, false)
+		req := m.login(t, svc, stubUser)
+
+		m.identityRepo.On("GetByProviderSubject", mock.Anything, "stub", "sub-1").Return(&entity.UserIdentity{UserID: 7}, nil)
+		m.repo.On("GetByID", mock.Anything, uint(7)).Return(&entity.User{ID: 7, Email: "other@example.com"}, nil)
+		m.expectSession()
+
+		resp, err := svc.CompleteOAuthLogin(context.Background(), "stub", req)
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), resp.UserID)
+		assert.NotEmpty(t, resp.AccessToken)
+		m.identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("existing user is linked by verified email", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, false)
+		req := m.login(t, svc, stubUser)
+
+		m.identityRepo.On("GetByProviderSubject", mock.Anything, "stub", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+		verifiedAt := time.Now()
+		m.repo.On("GetByEmail", mock.Anything, "dev@example.com").Return(&entity.User{ID: 3, Email: "dev@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+		m.identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(i *entity.UserIdentity) bool {
+			return i.UserID == 3 && i.Provider == "stub" && i.Subject == "sub-1"
+		})).Return(nil)
+		m.expectSession()
+
+		resp, err := svc.CompleteOAuthLogin(context.Background(), "stub", req)
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), resp.UserID)
+		m.identityRepo.AssertExpectations(t)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("existing user with an unverified email is not linked", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, true)
+		req := m.login(t, svc, stubUser)
+
+		m.identityRepo.On("GetByProviderSubject", mock.Anything, "stub", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+		m.repo.On("GetByEmail", mock.Anything, "dev@example.com").Return(&entity.User{ID: 3, Email: "dev@example.com"}, nil)
+
+		_, err := svc.CompleteOAuthLogin(context.Background(), "stub", req)
+		assert.ErrorIs(t, err, ErrOAuthAccountNotVerified)
+		m.identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.sessionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("new user is registered when enabled", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, true)
+		req := m.login(t, svc, stubUser)
+
+		m.identityRepo.On("GetByProviderSubject", mock.Anything, "stub", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+		m.repo.On("GetByEmail", mock.Anything, "dev@example.com").Return(nil, gorm.ErrRecordNotFound)
+		m.repo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+			return u.Email == "dev@example.com" && u.Name == "Dev" && u.Role == entity.RoleViewer && u.IsEmailVerified()
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.User).ID = 9
+		}).Return(nil)
+		m.identityRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.UserIdentity")).Return(nil)
+		m.expectSession()
+
+		resp, err := svc.CompleteOAuthLogin(context.Background(), "stub", req)
+		require.NoError(t, err)
+		assert.Equal(t, uint(9), resp.UserID)
+	})
+
+	t.Run("new user is rejected when registration is disabled", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, false)
+		req := m.login(t, svc, stubUser)
+
+		m.identityRepo.On("GetByProviderSubject", mock.Anything, "stub", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+		m.repo.On("GetByEmail", mock.Anything, "dev@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CompleteOAuthLogin(context.Background(), "stub", req)
+		assert.ErrorIs(t, err, ErrOAuthRegistrationDisabled)
+	})
+
+	t.Run("unverified email is not linked", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, true)
+		unverified := stubUser
+		unverified.EmailVerified = false
+		req := m.login(t, svc, unverified)
+
+		m.identityRepo.On("GetByProviderSubject", mock.Anything, "stub", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CompleteOAuthLogin(context.Background(), "stub", req)
+		assert.ErrorIs(t, err, ErrOAuthEmailNotVerified)
+		m.repo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown or reused state is rejected", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, true)
+		m.stateRepo.On("Consume", mock.Anything, hashToken("bogus")).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CompleteOAuthLogin(context.Background(), "stub", &dto.OAuthCallbackRequest{Code: "code", State: "bogus"})
+		assert.ErrorIs(t, err, ErrInvalidOAuthState)
+	})
+
+	t.Run("state from another provider is rejected", func(t *testing.T) {
+		svc, m := newTestOAuthService(t, true)
+		req := m.login(t, svc, stubUser)
+		m.pendingStates[hashToken(req.State)].Provider = "github"
+
+		_, err := svc.CompleteOAuthLogin(context.Background(), "stub", req)
+		assert.ErrorIs(t, err, ErrInvalidOAuthState)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		svc, _ := newTestOAuthService(t, true)
+
+		_, err := svc.BeginOAuthLogin(context.Background(), "myspace", "")
+		assert.ErrorIs(t, err, ErrUnknownOAuthProvider)
+	})
+}
@@ -1,106 +1,178 @@
 package service
 
 import (
-	"os"
+	"context"
+	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"go-backend/internal/infrastructure/logging"
+	"go-backend/internal/infrastructure/tracing"
 	"go-backend/internal/modules/user/domain/entity"
 	"go-backend/internal/modules/user/domain/repository"
 	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSessionNotFound = apperror.NotFound("session not found")
+	ErrInvalidRole     = apperror.BadRequest("invalid role")
+	// ErrInvalidCredentials is returned for both unknown emails and wrong
+	// passwords so that callers cannot tell them apart.
+	ErrInvalidCredentials = apperror.Unauthorized("invalid email or password")
 )
 
 type UserService interface {
-	Create(req *dto.CreateUserRequest) (*dto.UserResponse, error)
-	GetByID(id uint) (*dto.UserResponse, error)
-	GetByEmail(email string) (*dto.UserResponse, error)
-	List(page, limit int) ([]*dto.UserResponse, error)
-	Update(id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error)
-	Delete(id uint) error
-	Login(req *dto.LoginRequest) (*dto.LoginResponse, error)
-	Logout(userID uint) error
+	Create(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.UserResponse, error)
+	GetByEmail(ctx context.Context, email string) (*dto.UserResponse, error)
+	List(ctx context.Context, params pagination.Params) ([]*dto.UserResponse, pagination.Meta, error)
+	Update(ctx context.Context, id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error)
+	UpdateRole(ctx context.Context, id uint, role string) (*dto.UserResponse, error)
+	Delete(ctx context.Context, id uint) error
+	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
+	Refresh(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.LoginResponse, error)
+	Logout(ctx context.Context, userID uint, sessionID string) error
+	ListSessions(ctx context.Context, userID uint, currentSessionID string) ([]*dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID uint, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID uint, currentSessionID string) error
+	Unlock(ctx context.Context, id, actorID uint) error
+	BeginOAuthLogin(ctx context.Context, provider, device string) (string, error)
+	CompleteOAuthLogin(ctx context.Context, provider string, req *dto.OAuthCallbackRequest) (*dto.LoginResponse, error)
+
+	VerifyMFA(ctx context.Context, req *dto.MFALoginRequest) (*dto.LoginResponse, error)
+	BeginLoginMFAEnrollment(ctx context.Context, req *dto.MFATokenRequest) (*dto.MFAEnrollmentResponse, error)
+	ConfirmLoginMFAEnrollment(ctx context.Context, req *dto.MFALoginRequest) (*dto.LoginResponse, error)
+	BeginMFAEnrollment(ctx context.Context, userID uint) (*dto.MFAEnrollmentResponse, error)
+	ConfirmMFAEnrollment(ctx context.Context, userID uint, code string) (*dto.RecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, userID uint, code string) error
 }
 
 type userService struct {
-	repo repository.UserRepository
+	repo             repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	sessionRepo      repository.SessionRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	auditRepo        repository.AuditRepository
+	identityRepo     repository.UserIdentityRepository
+	oauthStateRepo   repository.OAuthStateRepository
+	mfaConfig        MFAConfig
+	oauthConfig      OAuthConfig
+	accountThrottle  *loginThrottle
+	ipThrottle       *loginThrottle
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
-	return &userService{repo: repo}
+// Config holds the tunables of UserService. Zero values fall back to defaults.
+type Config struct {
+	MFA     MFAConfig
+	Lockout LockoutConfig
+	OAuth   OAuthConfig
 }
 
-func (s *userService) Create(req *dto.CreateUserRequest) (*dto.UserResponse, error) {
+func NewUserService(
+	repo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	auditRepo repository.AuditRepository,
+	identityRepo repository.UserIdentityRepository,
+	oauthStateRepo repository.OAuthStateRepository,
+	config Config,
+) UserService {
+	lockout := config.Lockout.withDefaults()
+	return &userService{
+		repo:             repo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRepo:      sessionRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		auditRepo:        auditRepo,
+		identityRepo:     identityRepo,
+		oauthStateRepo:   oauthStateRepo,
+		mfaConfig:        config.MFA,
+		oauthConfig:      config.OAuth,
+		accountThrottle:  newLoginThrottle(lockout.Account),
+		ipThrottle:       newLoginThrottle(lockout.IP),
+	}
+}
+
+func (s *userService) Create(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Create")
+	defer span.End()
+
+	role := entity.RoleViewer
+	if req.Role != "" {
+		role = entity.Role(req.Role)
+		if !role.IsValid() {
+			return nil, ErrInvalidRole
+		}
+	}
+
+	// Accounts created by an admin do not need to verify their email
+	verifiedAt := time.Now()
 	user := &entity.User{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: req.Password,
+		Name:            req.Name,
+		Email:           req.Email,
+		Password:        req.Password,
+		Role:            role,
+		EmailVerifiedAt: &verifiedAt,
 	}
 
-	if err := s.repo.Create(user); err != nil {
+	if err := s.repo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
-func (s *userService) GetByID(id uint) (*dto.UserResponse, error) {
-	user, err := s.repo.GetByID(id)
+func (s *userService) GetByID(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByID")
+	defer span.End()
+
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, apperror.NotFoundOr(err, "user not found")
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
-func (s *userService) GetByEmail(email string) (*dto.UserResponse, error) {
-	user, err := s.repo.GetByEmail(email)
+func (s *userService) GetByEmail(ctx context.Context, email string) (*dto.UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByEmail")
+	defer span.End()
+
+	user, err := s.repo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
-func (s *userService) List(page, limit int) ([]*dto.UserResponse, error) {
-	users, err := s.repo.List(page, limit)
+func (s *userService) List(ctx context.Context, params pagination.Params) ([]*dto.UserResponse, pagination.Meta, error) {
+	ctx, span := tracing.Start(ctx, "UserService.List")
+	defer span.End()
+
+	users, meta, err := s.repo.List(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 
 	response := make([]*dto.UserResponse, len(users))
 	for i, user := range users {
-		response[i] = &dto.UserResponse{
-			ID:        user.ID,
-			Name:      user.Name,
-			Email:     user.Email,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		}
+		response[i] = toUserResponse(user)
 	}
 
-	return response, nil
+	return response, meta, nil
 }
 
-func (s *userService) Update(id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
-	user, err := s.repo.GetByID(id)
+func (s *userService) Update(ctx context.Context, id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	defer span.End()
+
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -110,64 +182,323 @@ func (s *userService) Update(id uint, req *dto.UpdateUserRequest) (*dto.UserResp
 		user.Password = req.Password
 	}
 
-	if err := s.repo.Update(user); err != nil {
+	if err := s.repo.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
-func (s *userService) Delete(id uint) error {
-	return s.repo.Delete(id)
-}
+func (s *userService) UpdateRole(ctx context.Context, id uint, role string) (*dto.UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateRole")
+	defer span.End()
 
-func (s *userService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
-	user, err := s.repo.GetByEmail(req.Email)
+	newRole := entity.Role(role)
+	if !newRole.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	if err := s.repo.UpdateRole(ctx, id, newRole); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	return toUserResponse(user), nil
+}
+
+func (s *userService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "UserService.Delete")
+	defer span.End()
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *userService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer span.End()
+
+	now := time.Now()
+	if err := s.checkLoginThrottle(req.Email, req.IPAddress, now); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		// Spend the same time as a password check so that response times
+		// do not reveal which emails are registered.
+		compareDummyPassword(req.Password)
+		return nil, s.loginFailed(ctx, nil, req.Email, req.IPAddress, now)
+	}
+
+	if user.IsLocked(now) {
+		return nil, s.loginRefused(req.Email, req.Password, req.IPAddress, now)
+	}
+
 	if err := user.ComparePassword(req.Password); err != nil {
+		return nil, s.loginFailed(ctx, user, req.Email, req.IPAddress, now)
+	}
+
+	// The password alone is not enough when MFA is on, or when the user must
+	// enroll first; hand out a challenge token instead of a session.
+	if user.IsMFAEnabled() || s.mfaConfig.requiredFor(user) {
+		return s.issueMFAChallenge(user, req.Device)
+	}
+
+	if err := s.loginSucceeded(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(ctx, user, req.Device, req.IPAddress, req.UserAgent)
+}
+
+// startSession creates a session for a fully authenticated user and issues
+// its first token pair.
+func (s *userService) startSession(ctx context.Context, user *entity.User, device, ipAddress, userAgent string) (*dto.LoginResponse, error) {
+	sessionID, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &entity.Session{
+		ID:         sessionID,
+		UserID:     user.ID,
+		Device:     device,
+		IPAddress:  ipAddress,
+		UserAgent:  userAgent,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(refreshTokenTTL),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "session started",
+		slog.Uint64("user_id", uint64(user.ID)),
+		slog.String("session_id", session.ID),
+	)
+
+	return s.issueTokens(ctx, user, session)
+}
+
+func (s *userService) Refresh(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Refresh")
+	defer span.End()
+
+	if _, err := parseToken(req.RefreshToken, refreshTokenType); err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	stored, err := s.refreshTokenRepo.GetByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if stored.IsRevoked() {
+		// A token that was already rotated is being replayed, so assume it
+		// leaked and end the session it belongs to.
+		logging.FromContext(ctx).WarnContext(ctx, "refresh token reused, revoking session",
+			slog.Uint64("user_id", uint64(stored.UserID)),
+			slog.String("session_id", stored.SessionID),
+		)
+		if err := s.revokeSession(ctx, stored.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if stored.IsExpired() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, stored.SessionID)
+	if err != nil || !session.IsActive() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	revoked, err := s.refreshTokenRepo.Revoke(ctx, stored.ID)
+	if err != nil {
 		return nil, err
 	}
+	if !revoked {
+		// Another request rotated this token between our read and write.
+		if err := s.revokeSession(ctx, stored.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
 
-	// Generate JWT token
-	expiresAt := time.Now().Add(24 * time.Hour)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     expiresAt.Unix(),
+	user, err := s.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Every successful refresh extends the session by another refresh TTL.
+	now := time.Now()
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(refreshTokenTTL)
+	if req.IPAddress != "" {
+		session.IPAddress = req.IPAddress
+	}
+	if req.UserAgent != "" {
+		session.UserAgent = req.UserAgent
+	}
+	if err := s.sessionRepo.Update(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, session)
+}
+
+func (s *userService) Logout(ctx context.Context, userID uint, sessionID string) error {
+	ctx, span := tracing.Start(ctx, "UserService.Logout")
+	defer span.End()
+
+	return s.RevokeSession(ctx, userID, sessionID)
+}
+
+func (s *userService) ListSessions(ctx context.Context, userID uint, currentSessionID string) ([]*dto.SessionResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListSessions")
+	defer span.End()
+
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		response[i] = &dto.SessionResponse{
+			ID:         session.ID,
+			Device:     session.Device,
+			IPAddress:  session.IPAddress,
+			UserAgent:  session.UserAgent,
+			Current:    session.ID == currentSessionID,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *userService) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	ctx, span := tracing.Start(ctx, "UserService.RevokeSession")
+	defer span.End()
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.UserID != userID || !session.IsActive() {
+		return ErrSessionNotFound
+	}
+
+	return s.revokeSession(ctx, session.ID)
+}
+
+func (s *userService) RevokeOtherSessions(ctx context.Context, userID uint, currentSessionID string) error {
+	ctx, span := tracing.Start(ctx, "UserService.RevokeOtherSessions")
+	defer span.End()
+
+	ids, err := s.sessionRepo.RevokeByUserID(ctx, userID, currentSessionID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.refreshTokenRepo.RevokeBySessionID(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toUserResponse(user *entity.User) *dto.UserResponse {
+	var lockedUntil *time.Time
+	if user.IsLocked(time.Now()) {
+		lockedUntil = user.LockedUntil
+	}
+
+	return &dto.UserResponse{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Role:            string(user.Role),
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		LockedUntil:     lockedUntil,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+}
+
+// issueTokens creates a new access/refresh token pair bound to session. Both
+// tokens carry the session ID in their "sid" claim.
+func (s *userService) issueTokens(ctx context.Context, user *entity.User, session *entity.Session) (*dto.LoginResponse, error) {
+	now := time.Now()
+
+	accessToken, err := signToken(jwt.MapClaims{
+		"user_id":    user.ID,
+		"sid":        session.ID,
+		"role":       string(user.Role),
+		"token_type": accessTokenType,
+		"iat":        now.Unix(),
+		"exp":        now.Add(accessTokenTTL).Unix(),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	jti, err := randomID()
+	if err != nil {
+		return nil, err
+	}
 
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	refreshExpiresAt := now.Add(refreshTokenTTL)
+	refreshToken, err := signToken(jwt.MapClaims{
+		"user_id":    user.ID,
+		"sid":        session.ID,
+		"token_type": refreshTokenType,
+		"jti":        jti,
+		"iat":        now.Unix(),
+		"exp":        refreshExpiresAt.Unix(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update user with token
-	user.SetToken(tokenString, expiresAt)
-	if err := s.repo.Update(user); err != nil {
+	if err := s.refreshTokenRepo.Create(ctx, &entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		SessionID: session.ID,
+		ExpiresAt: refreshExpiresAt,
+	}); err != nil {
 		return nil, err
 	}
 
 	return &dto.LoginResponse{
-		Token:  tokenString,
-		UserID: user.ID,
-		Status: "success",
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(accessTokenTTL.Seconds()),
+		RefreshExpiresIn: int64(refreshTokenTTL.Seconds()),
+		UserID:           user.ID,
+		SessionID:        session.ID,
+		Status:           "success",
 	}, nil
 }
 
-func (s *userService) Logout(userID uint) error {
-	user, err := s.repo.GetByID(userID)
-	if err != nil {
+// revokeSession ends a session and revokes every refresh token issued for it.
+// Access tokens bound to the session stop working on their next request.
+func (s *userService) revokeSession(ctx context.Context, sessionID string) error {
+	if err := s.sessionRepo.Revoke(ctx, sessionID); err != nil {
 		return err
 	}
 
-	user.ClearToken()
-	return s.repo.Update(user)
-}
\ No newline at end of file
+	return s.refreshTokenRepo.RevokeBySessionID(ctx, sessionID)
+}
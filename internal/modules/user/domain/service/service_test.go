@@ -1,15 +1,19 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
 	"go-backend/internal/modules/user/domain/entity"
 	"go-backend/internal/modules/user/dto"
 	"go-backend/internal/modules/user/mocks"
+	"go-backend/internal/pkg/pagination"
 )
 
 var (
@@ -18,7 +22,7 @@ var (
 
 func TestUserService_Create(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
+	svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
 
 	tests := []struct {
 		name    string
@@ -34,7 +38,7 @@ func TestUserService_Create(t *testing.T) {
 				Password: "password123",
 			},
 			mockFn: func() {
-				mockRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(nil)
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -43,13 +47,56 @@ func TestUserService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			user, err := svc.Create(tt.input)
+			user, err := svc.Create(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
 			}
 			assert.NoError(t, err)
 			assert.NotNil(t, user)
+			assert.Equal(t, string(entity.RoleViewer), user.Role)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_UpdateRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		mockFn  func(*mocks.MockUserRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			role: "editor",
+			mockFn: func(repo *mocks.MockUserRepository) {
+				repo.On("UpdateRole", mock.Anything, uint(2), entity.RoleEditor).Return(nil)
+				repo.On("GetByID", mock.Anything, uint(2)).Return(&entity.User{ID: 2, Role: entity.RoleEditor}, nil)
+			},
+		},
+		{
+			name:    "unknown role",
+			role:    "owner",
+			mockFn:  func(repo *mocks.MockUserRepository) {},
+			wantErr: ErrInvalidRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockUserRepository)
+			svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+			tt.mockFn(mockRepo)
+
+			user, err := svc.UpdateRole(context.Background(), 2, tt.role)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.role, user.Role)
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -57,7 +104,7 @@ func TestUserService_Create(t *testing.T) {
 
 func TestUserService_GetByID(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
+	svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
 
 	tests := []struct {
 		name    string
@@ -69,7 +116,7 @@ func TestUserService_GetByID(t *testing.T) {
 			name: "success",
 			id:   1,
 			mockFn: func() {
-				mockRepo.On("GetByID", uint(1)).Return(&entity.User{
+				mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&entity.User{
 					ID:    1,
 					Name:  "Test User",
 					Email: "test@example.com",
@@ -81,7 +128,7 @@ func TestUserService_GetByID(t *testing.T) {
 			name: "not found",
 			id:   2,
 			mockFn: func() {
-				mockRepo.On("GetByID", uint(2)).Return(nil, ErrRecordNotFound)
+				mockRepo.On("GetByID", mock.Anything, uint(2)).Return(nil, ErrRecordNotFound)
 			},
 			wantErr: true,
 		},
@@ -90,7 +137,7 @@ func TestUserService_GetByID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			user, err := svc.GetByID(tt.id)
+			user, err := svc.GetByID(context.Background(), tt.id)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -104,7 +151,7 @@ func TestUserService_GetByID(t *testing.T) {
 
 func TestUserService_GetByEmail(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
+	svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
 
 	tests := []struct {
 		name    string
@@ -116,7 +163,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 			name:  "success",
 			email: "test@example.com",
 			mockFn: func() {
-				mockRepo.On("GetByEmail", "test@example.com").Return(&entity.User{
+				mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(&entity.User{
 					ID:    1,
 					Name:  "Test User",
 					Email: "test@example.com",
@@ -128,7 +175,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 			name:  "not found",
 			email: "notfound@example.com",
 			mockFn: func() {
-				mockRepo.On("GetByEmail", "notfound@example.com").Return(nil, ErrRecordNotFound)
+				mockRepo.On("GetByEmail", mock.Anything, "notfound@example.com").Return(nil, ErrRecordNotFound)
 			},
 			wantErr: true,
 		},
@@ -137,7 +184,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			user, err := svc.GetByEmail(tt.email)
+			user, err := svc.GetByEmail(context.Background(), tt.email)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -151,7 +198,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 
 func TestUserService_Update(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
+	svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("newpassword123"), bcrypt.DefaultCost)
 
@@ -170,13 +217,13 @@ func TestUserService_Update(t *testing.T) {
 				Password: "newpassword123",
 			},
 			mockFn: func() {
-				mockRepo.On("GetByID", uint(1)).Return(&entity.User{
+				mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&entity.User{
 					ID:       1,
 					Name:     "Test User",
 					Email:    "test@example.com",
 					Password: string(hashedPassword),
 				}, nil)
-				mockRepo.On("Update", mock.AnythingOfType("*entity.User")).Return(nil)
+				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -185,7 +232,7 @@ func TestUserService_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			user, err := svc.Update(tt.id, tt.input)
+			user, err := svc.Update(context.Background(), tt.id, tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -199,7 +246,7 @@ func TestUserService_Update(t *testing.T) {
 
 func TestUserService_Delete(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
+	svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
 
 	tests := []struct {
 		name    string
@@ -211,7 +258,7 @@ func TestUserService_Delete(t *testing.T) {
 			name: "success",
 			id:   1,
 			mockFn: func() {
-				mockRepo.On("Delete", uint(1)).Return(nil)
+				mockRepo.On("Delete", mock.Anything, uint(1)).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -220,7 +267,7 @@ func TestUserService_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			err := svc.Delete(tt.id)
+			err := svc.Delete(context.Background(), tt.id)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -233,7 +280,8 @@ func TestUserService_Delete(t *testing.T) {
 
 func TestUserService_List(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
+	svc := NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+	params := pagination.Params{Page: 1, Limit: 10, Sort: pagination.Sort{Field: "id"}}
 
 	tests := []struct {
 		name    string
@@ -243,7 +291,7 @@ func TestUserService_List(t *testing.T) {
 		{
 			name: "success",
 			mockFn: func() {
-				mockRepo.On("List", 1, 10).Return([]*entity.User{
+				mockRepo.On("List", mock.Anything, params).Return([]*entity.User{
 					{
 						ID:    1,
 						Name:  "User 1",
@@ -254,7 +302,7 @@ func TestUserService_List(t *testing.T) {
 						Name:  "User 2",
 						Email: "user2@example.com",
 					},
-				}, nil)
+				}, pagination.Meta{Limit: 10, Total: 2, Page: 1, TotalPages: 1, Sort: "id"}, nil)
 			},
 			wantErr: false,
 		},
@@ -263,7 +311,7 @@ func TestUserService_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			users, err := svc.List(1, 10)
+			users, meta, err := svc.List(context.Background(), params)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -271,6 +319,7 @@ func TestUserService_List(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, users)
 			assert.Len(t, users, 2)
+			assert.Equal(t, int64(2), meta.Total)
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -278,7 +327,9 @@ func TestUserService_List(t *testing.T) {
 
 func TestUserService_Login(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
+	mockRefreshRepo := new(mocks.MockRefreshTokenRepository)
+	mockSessionRepo := new(mocks.MockSessionRepository)
+	svc := NewUserService(mockRepo, mockRefreshRepo, mockSessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 
@@ -291,16 +342,23 @@ func TestUserService_Login(t *testing.T) {
 		{
 			name: "success",
 			input: &dto.LoginRequest{
-				Email:    "test@example.com",
-				Password: "password123",
+				Email:     "test@example.com",
+				Password:  "password123",
+				Device:    "Laptop",
+				IPAddress: "127.0.0.1",
+				UserAgent: "test-agent",
 			},
 			mockFn: func() {
-				mockRepo.On("GetByEmail", "test@example.com").Return(&entity.User{
+				mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(&entity.User{
 					ID:       1,
 					Email:    "test@example.com",
 					Password: string(hashedPassword),
+					Role:     entity.RoleEditor,
 				}, nil)
-				mockRepo.On("Update", mock.AnythingOfType("*entity.User")).Return(nil)
+				mockSessionRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+					return s.UserID == 1 && s.Device == "Laptop" && s.IPAddress == "127.0.0.1" && s.UserAgent == "test-agent"
+				})).Return(nil)
+				mockRefreshRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -311,11 +369,12 @@ func TestUserService_Login(t *testing.T) {
 				Password: "wrongpassword",
 			},
 			mockFn: func() {
-				mockRepo.On("GetByEmail", "test@example.com").Return(&entity.User{
+				mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(&entity.User{
 					ID:       1,
 					Email:    "test@example.com",
 					Password: string(hashedPassword),
 				}, nil)
+				mockRepo.On("RecordFailedLogin", mock.Anything, uint(1)).Return(nil)
 			},
 			wantErr: true,
 		},
@@ -324,53 +383,232 @@ func TestUserService_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			resp, err := svc.Login(tt.input)
+			resp, err := svc.Login(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
 			}
 			assert.NoError(t, err)
 			assert.NotNil(t, resp)
-			assert.NotEmpty(t, resp.Token)
+			assert.NotEmpty(t, resp.AccessToken)
+			assert.NotEmpty(t, resp.RefreshToken)
+			assert.NotEmpty(t, resp.SessionID)
+			claims, err := parseToken(resp.AccessToken, accessTokenType)
+			assert.NoError(t, err)
+			assert.Equal(t, string(entity.RoleEditor), claims["role"])
 			mockRepo.AssertExpectations(t)
+			mockRefreshRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func TestUserService_Logout(t *testing.T) {
-	mockRepo := new(mocks.MockUserRepository)
-	svc := NewUserService(mockRepo)
-
 	tests := []struct {
-		name    string
-		userID  uint
-		mockFn  func()
-		wantErr bool
+		name      string
+		userID    uint
+		sessionID string
+		mockFn    func(*mocks.MockRefreshTokenRepository, *mocks.MockSessionRepository)
+		wantErr   error
 	}{
 		{
-			name:   "success",
-			userID: 1,
-			mockFn: func() {
-				mockRepo.On("GetByID", uint(1)).Return(&entity.User{
-					ID:    1,
-					Email: "test@example.com",
+			name:      "revokes only the current session",
+			userID:    1,
+			sessionID: "session-1",
+			mockFn: func(refreshRepo *mocks.MockRefreshTokenRepository, sessionRepo *mocks.MockSessionRepository) {
+				sessionRepo.On("GetByID", mock.Anything, "session-1").Return(&entity.Session{
+					ID:        "session-1",
+					UserID:    1,
+					ExpiresAt: time.Now().Add(time.Hour),
 				}, nil)
-				mockRepo.On("Update", mock.AnythingOfType("*entity.User")).Return(nil)
+				sessionRepo.On("Revoke", mock.Anything, "session-1").Return(nil)
+				refreshRepo.On("RevokeBySessionID", mock.Anything, "session-1").Return(nil)
 			},
-			wantErr: false,
+		},
+		{
+			name:      "session of another user",
+			userID:    1,
+			sessionID: "session-2",
+			mockFn: func(refreshRepo *mocks.MockRefreshTokenRepository, sessionRepo *mocks.MockSessionRepository) {
+				sessionRepo.On("GetByID", mock.Anything, "session-2").Return(&entity.Session{
+					ID:        "session-2",
+					UserID:    2,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+			},
+			wantErr: ErrSessionNotFound,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockFn()
-			err := svc.Logout(tt.userID)
-			if tt.wantErr {
-				assert.Error(t, err)
+			mockRefreshRepo := new(mocks.MockRefreshTokenRepository)
+			mockSessionRepo := new(mocks.MockSessionRepository)
+			svc := NewUserService(new(mocks.MockUserRepository), mockRefreshRepo, mockSessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+			tt.mockFn(mockRefreshRepo, mockSessionRepo)
+
+			err := svc.Logout(context.Background(), tt.userID, tt.sessionID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockSessionRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
 				return
 			}
 			assert.NoError(t, err)
-			mockRepo.AssertExpectations(t)
+			mockRefreshRepo.AssertNotCalled(t, "RevokeByUserID", mock.Anything, mock.Anything)
+			mockRefreshRepo.AssertExpectations(t)
+			mockSessionRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestUserService_ListSessions(t *testing.T) {
+	mockSessionRepo := new(mocks.MockSessionRepository)
+	svc := NewUserService(new(mocks.MockUserRepository), new(mocks.MockRefreshTokenRepository), mockSessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+
+	mockSessionRepo.On("ListActiveByUserID", mock.Anything, uint(1)).Return([]entity.Session{
+		{ID: "session-1", UserID: 1, Device: "Laptop"},
+		{ID: "session-2", UserID: 1, Device: "Phone"},
+	}, nil)
+
+	sessions, err := svc.ListSessions(context.Background(), 1, "session-2")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestUserService_RevokeOtherSessions(t *testing.T) {
+	mockRefreshRepo := new(mocks.MockRefreshTokenRepository)
+	mockSessionRepo := new(mocks.MockSessionRepository)
+	svc := NewUserService(new(mocks.MockUserRepository), mockRefreshRepo, mockSessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+
+	mockSessionRepo.On("RevokeByUserID", mock.Anything, uint(1), "session-1").Return([]string{"session-2", "session-3"}, nil)
+	mockRefreshRepo.On("RevokeBySessionID", mock.Anything, "session-2").Return(nil)
+	mockRefreshRepo.On("RevokeBySessionID", mock.Anything, "session-3").Return(nil)
+
+	err := svc.RevokeOtherSessions(context.Background(), 1, "session-1")
+	assert.NoError(t, err)
+	mockRefreshRepo.AssertExpectations(t)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestUserService_Refresh(t *testing.T) {
+	user := &entity.User{ID: 1, Email: "test@example.com"}
+
+	newRefreshToken := func(t *testing.T) string {
+		token, err := signToken(jwt.MapClaims{
+			"user_id":    user.ID,
+			"sid":        "session-1",
+			"token_type": refreshTokenType,
+			"exp":        time.Now().Add(time.Hour).Unix(),
+		})
+		assert.NoError(t, err)
+		return token
+	}
+
+	t.Run("rotates a valid token", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		mockRefreshRepo := new(mocks.MockRefreshTokenRepository)
+		mockSessionRepo := new(mocks.MockSessionRepository)
+		svc := NewUserService(mockRepo, mockRefreshRepo, mockSessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+
+		token := newRefreshToken(t)
+		mockRefreshRepo.On("GetByHash", mock.Anything, hashToken(token)).Return(&entity.RefreshToken{
+			ID:        10,
+			UserID:    user.ID,
+			SessionID: "session-1",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mockSessionRepo.On("GetByID", mock.Anything, "session-1").Return(&entity.Session{
+			ID:        "session-1",
+			UserID:    user.ID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mockRefreshRepo.On("Revoke", mock.Anything, uint(10)).Return(true, nil)
+		mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+		mockSessionRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+			return s.ExpiresAt.After(time.Now().Add(refreshTokenTTL - time.Minute))
+		})).Return(nil)
+		mockRefreshRepo.On("Create", mock.Anything, mock.MatchedBy(func(rt *entity.RefreshToken) bool {
+			return rt.SessionID == "session-1" && rt.UserID == user.ID
+		})).Return(nil)
+
+		resp, err := svc.Refresh(context.Background(), &dto.RefreshTokenRequest{RefreshToken: token})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.NotEqual(t, token, resp.RefreshToken)
+		assert.Equal(t, "session-1", resp.SessionID)
+		mockRepo.AssertExpectations(t)
+		mockRefreshRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("revokes the session when a rotated token is replayed", func(t *testing.T) {
+		mockRefreshRepo := new(mocks.MockRefreshTokenRepository)
+		mockSessionRepo := new(mocks.MockSessionRepository)
+		svc := NewUserService(new(mocks.MockUserRepository), mockRefreshRepo, mockSessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+
+		token := newRefreshToken(t)
+		revokedAt := time.Now().Add(-time.Minute)
+		mockRefreshRepo.On("GetByHash", mock.Anything, hashToken(token)).Return(&entity.RefreshToken{
+			ID:        10,
+			UserID:    user.ID,
+			SessionID: "session-1",
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}, nil)
+		mockSessionRepo.On("Revoke", mock.Anything, "session-1").Return(nil)
+		mockRefreshRepo.On("RevokeBySessionID", mock.Anything, "session-1").Return(nil)
+
+		resp, err := svc.Refresh(context.Background(), &dto.RefreshTokenRequest{RefreshToken: token})
+		assert.ErrorIs(t, err, ErrRefreshTokenReused)
+		assert.Nil(t, resp)
+		mockRefreshRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockRefreshRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a token whose session was revoked", func(t *testing.T) {
+		mockRefreshRepo := new(mocks.MockRefreshTokenRepository)
+		mockSessionRepo := new(mocks.MockSessionRepository)
+		svc := NewUserService(new(mocks.MockUserRepository), mockRefreshRepo, mockSessionRepo, new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+
+		token := newRefreshToken(t)
+		revokedAt := time.Now().Add(-time.Minute)
+		mockRefreshRepo.On("GetByHash", mock.Anything, hashToken(token)).Return(&entity.RefreshToken{
+			ID:        10,
+			UserID:    user.ID,
+			SessionID: "session-1",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mockSessionRepo.On("GetByID", mock.Anything, "session-1").Return(&entity.Session{
+			ID:        "session-1",
+			UserID:    user.ID,
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}, nil)
+
+		resp, err := svc.Refresh(context.Background(), &dto.RefreshTokenRequest{RefreshToken: token})
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.Nil(t, resp)
+		mockRefreshRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an access token", func(t *testing.T) {
+		mockRefreshRepo := new(mocks.MockRefreshTokenRepository)
+		svc := NewUserService(new(mocks.MockUserRepository), mockRefreshRepo, new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), Config{})
+
+		token, err := signToken(jwt.MapClaims{
+			"user_id":    user.ID,
+			"token_type": accessTokenType,
+			"exp":        time.Now().Add(time.Hour).Unix(),
+		})
+		assert.NoError(t, err)
+
+		resp, err := svc.Refresh(context.Background(), &dto.RefreshTokenRequest{RefreshToken: token})
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.Nil(t, resp)
+		mockRefreshRepo.AssertNotCalled(t, "GetByHash", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// ThrottlePolicy describes how failed login attempts for one key (an account
+// or an IP address) are slowed down and eventually locked out.
+type ThrottlePolicy struct {
+	// FreeAttempts failures are allowed before delays start.
+	FreeAttempts int
+	// BaseDelay is the wait after the first delayed failure. It doubles with
+	// every further failure, up to LockoutDuration.
+	BaseDelay time.Duration
+	// MaxAttempts failures lock the key for LockoutDuration.
+	MaxAttempts     int
+	LockoutDuration time.Duration
+	// Window is how long failures are remembered after the last one.
+	Window time.Duration
+}
+
+func (p ThrottlePolicy) delay(failures int) time.Duration {
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures && delay < p.LockoutDuration; i++ {
+		delay *= 2
+	}
+	if delay > p.LockoutDuration {
+		delay = p.LockoutDuration
+	}
+	return delay
+}
+
+type throttleEntry struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// loginThrottle keeps failed attempt counters in memory. It complements the
+// lockout persisted on the user, and also covers IP addresses and emails
+// that do not belong to any account.
+type loginThrottle struct {
+	policy    ThrottlePolicy
+	mu        sync.Mutex
+	entries   map[string]*throttleEntry
+	lastSweep time.Time
+}
+
+func newLoginThrottle(policy ThrottlePolicy) *loginThrottle {
+	return &loginThrottle{
+		policy:  policy,
+		entries: make(map[string]*throttleEntry),
+	}
+}
+
+// wait returns how long key must wait before its next attempt.
+func (t *loginThrottle) wait(key string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[key]
+	if !ok || !now.Before(entry.blockedUntil) {
+		return 0
+	}
+	return entry.blockedUntil.Sub(now)
+}
+
+// fail records a failed attempt and reports whether it locked key out.
+func (t *loginThrottle) fail(key string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sweep(now)
+
+	entry, ok := t.entries[key]
+	if !ok || now.Sub(entry.lastFailure) > t.policy.Window {
+		entry = &throttleEntry{}
+		t.entries[key] = entry
+	}
+
+	entry.failures++
+	entry.lastFailure = now
+
+	if entry.failures >= t.policy.MaxAttempts {
+		entry.blockedUntil = now.Add(t.policy.LockoutDuration)
+		// Keep delaying after the lockout ends instead of granting another
+		// round of free attempts.
+		entry.failures = t.policy.FreeAttempts
+		return true
+	}
+
+	entry.blockedUntil = now.Add(t.policy.delay(entry.failures))
+	return false
+}
+
+func (t *loginThrottle) reset(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, key)
+}
+
+// sweep drops forgotten entries at most once per window. Callers hold t.mu.
+func (t *loginThrottle) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < t.policy.Window {
+		return
+	}
+	t.lastSweep = now
+
+	for key, entry := range t.entries {
+		if now.Sub(entry.lastFailure) > t.policy.Window && !now.Before(entry.blockedUntil) {
+			delete(t.entries, key)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	policy := ThrottlePolicy{
+		FreeAttempts:    2,
+		BaseDelay:       time.Second,
+		MaxAttempts:     5,
+		LockoutDuration: time.Minute,
+		Window:          time.Hour,
+	}
+	throttle := newLoginThrottle(policy)
+	now := time.Now()
+
+	// Free attempts
+	assert.False(t, throttle.fail("key", now))
+	assert.False(t, throttle.fail("key", now))
+	assert.Zero(t, throttle.wait("key", now))
+
+	// Progressive delays
+	assert.False(t, throttle.fail("key", now))
+	assert.Equal(t, time.Second, throttle.wait("key", now))
+	now = now.Add(time.Second)
+	assert.False(t, throttle.fail("key", now))
+	assert.Equal(t, 2*time.Second, throttle.wait("key", now))
+
+	// Lockout
+	now = now.Add(2 * time.Second)
+	assert.True(t, throttle.fail("key", now))
+	assert.Equal(t, time.Minute, throttle.wait("key", now))
+	assert.Zero(t, throttle.wait("other", now))
+
+	throttle.reset("key")
+	assert.Zero(t, throttle.wait("key", now))
+}
+
+func TestThrottlePolicy_DelayIsCapped(t *testing.T) {
+	policy := ThrottlePolicy{FreeAttempts: 0, BaseDelay: time.Second, MaxAttempts: 100, LockoutDuration: 10 * time.Second}
+
+	assert.Equal(t, time.Second, policy.delay(1))
+	assert.Equal(t, 8*time.Second, policy.delay(4))
+	assert.Equal(t, 10*time.Second, policy.delay(50))
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"go-backend/internal/infrastructure/jwtkeys"
+	"go-backend/internal/pkg/apperror"
+)
+
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+	mfaTokenType     = "mfa"
+
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+	mfaTokenTTL     = 5 * time.Minute
+)
+
+var (
+	ErrInvalidRefreshToken = apperror.Unauthorized("invalid or expired refresh token")
+	ErrRefreshTokenReused  = apperror.Unauthorized("refresh token has already been used")
+)
+
+// signToken signs the given claims with the active JWT key.
+func signToken(claims jwt.MapClaims) (string, error) {
+	keys, err := jwtkeys.Default()
+	if err != nil {
+		return "", err
+	}
+	return keys.Sign(claims)
+}
+
+// parseToken validates the signature and expiry of tokenString and checks
+// that its token_type claim matches tokenType.
+func parseToken(tokenString, tokenType string) (jwt.MapClaims, error) {
+	keys, err := jwtkeys.Default()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	token, err := keys.Parse(tokenString, claims, jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if claimType, ok := claims["token_type"].(string); !ok || claimType != tokenType {
+		return nil, errors.New("invalid token type")
+	}
+
+	return claims, nil
+}
+
+// hashToken returns the hex encoded SHA-256 digest used to store tokens at rest.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomID returns a random 128-bit identifier encoded as hex.
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
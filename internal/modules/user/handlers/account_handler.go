@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go-backend/internal/infrastructure/logging"
+	"go-backend/internal/modules/user/domain/service"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/response"
+)
+
+type AccountHandler struct {
+	service service.AccountService
+}
+
+func NewAccountHandler(service service.AccountService) *AccountHandler {
+	return &AccountHandler{service: service}
+}
+
+func (h *AccountHandler) Register(c *gin.Context) {
+	var req dto.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	resp, err := h.service.Register(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "Registration successful, please check your email to verify your account", resp))
+}
+
+func (h *AccountHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.Error(apperror.BadRequest("token query parameter is required"))
+		return
+	}
+
+	if err := h.service.VerifyEmail(c.Request.Context(), token); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Email verified successfully", nil))
+}
+
+func (h *AccountHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	// As with password resets, the response must not reveal whether the
+	// email belongs to an unverified account.
+	if err := h.service.ResendVerification(c.Request.Context(), &req); err != nil {
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).ErrorContext(ctx, "verification resend failed", "error", err)
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "If your email belongs to an unverified account, you will receive a new verification link", nil))
+}
+
+func (h *AccountHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	// The response must not depend on whether the email exists, so failures
+	// are only logged.
+	if err := h.service.RequestPasswordReset(c.Request.Context(), &req); err != nil {
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).ErrorContext(ctx, "password reset request failed", "error", err)
+	}
+
+	resp := &dto.ResetPasswordResponse{
+		Status:  "success",
+		Message: "If your email exists in our system, you will receive a password reset link",
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Password reset link sent successfully", resp))
+}
+
+func (h *AccountHandler) ConfirmResetPassword(c *gin.Context) {
+	var req dto.ConfirmResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	if err := h.service.ConfirmPasswordReset(c.Request.Context(), &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Password reset successfully", nil))
+}
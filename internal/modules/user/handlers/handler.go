@@ -1,30 +1,20 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"go-backend/internal/modules/user/domain/service"
 	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/response"
 )
 
-type Response struct {
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
-
-func formatResponse(status int, message string, data interface{}, err string) Response {
-	return Response{
-		Status:  status,
-		Message: message,
-		Data:    data,
-		Error:   err,
-	}
-}
-
 type UserHandler struct {
 	service service.UserService
 }
@@ -36,127 +26,256 @@ func NewUserHandler(service service.UserService) *UserHandler {
 func (h *UserHandler) Create(c *gin.Context) {
 	var req dto.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	resp, err := h.service.Create(&req)
+	resp, err := h.service.Create(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to create user", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, formatResponse(http.StatusCreated, "User created successfully", resp, ""))
+	c.JSON(http.StatusCreated, response.New(http.StatusCreated, "User created successfully", resp))
 }
 
 func (h *UserHandler) GetByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	resp, err := h.service.GetByID(uint(id))
+	resp, err := h.service.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, formatResponse(http.StatusNotFound, "User not found", nil, err.Error()))
+		c.Error(err)
 		return
 	}
+	if !canManageUser(c, uint(id)) {
+		resp.LockedUntil = nil
+	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User retrieved successfully", resp, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User retrieved successfully", resp))
 }
 
 func (h *UserHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	params, err := pagination.Parse(c.Request.URL.Query(), dto.ListOptions)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	resp, err := h.service.List(page, limit)
+	resp, meta, err := h.service.List(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to retrieve users", nil, err.Error()))
+		c.Error(err)
 		return
 	}
+	// The list is public, so it never tells who is locked out.
+	for _, user := range resp {
+		user.LockedUntil = nil
+	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Users retrieved successfully", resp, ""))
+	c.JSON(http.StatusOK, response.NewPage(http.StatusOK, "Users retrieved successfully", resp, meta))
 }
 
 func (h *UserHandler) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
+		return
+	}
+
+	if !canManageUser(c, uint(id)) {
+		c.Error(apperror.Forbidden("You can only update your own account"))
 		return
 	}
 
 	var req dto.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
-	resp, err := h.service.Update(uint(id), &req)
+	resp, err := h.service.Update(c.Request.Context(), uint(id), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to update user", nil, err.Error()))
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User updated successfully", resp, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User updated successfully", resp))
+}
+
+func (h *UserHandler) UpdateRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid ID format"))
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	resp, err := h.service.UpdateRole(c.Request.Context(), uint(id), req.Role)
+	if err != nil {
+		c.Error(apperror.NotFoundOr(err, "user not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User role updated successfully", resp))
+}
+
+// Unlock lifts a login lockout on behalf of an admin.
+func (h *UserHandler) Unlock(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid ID format"))
+		return
+	}
+
+	if err := h.service.Unlock(c.Request.Context(), uint(id), c.GetUint("user_id")); err != nil {
+		c.Error(apperror.NotFoundOr(err, "user not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User unlocked successfully", nil))
 }
 
 func (h *UserHandler) Delete(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid ID", nil, "Invalid ID format"))
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	if err := h.service.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to delete user", nil, err.Error()))
+	if err := h.service.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User deleted successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User deleted successfully", nil))
 }
 
 func (h *UserHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+		c.Error(apperror.FromBinding(err))
 		return
 	}
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
 
-	resp, err := h.service.Login(&req)
+	resp, err := h.service.Login(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "Invalid credentials", nil, err.Error()))
+		var tooMany *service.TooManyAttemptsError
+		if errors.As(err, &tooMany) {
+			writeTooManyAttempts(c, tooMany)
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	if resp.MFAToken != "" {
+		c.JSON(http.StatusOK, response.New(http.StatusOK, "MFA verification required", resp))
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User logged in successfully", resp, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User logged in successfully", resp))
+}
+
+func (h *UserHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := h.service.Refresh(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Token refreshed successfully", resp))
 }
 
 func (h *UserHandler) Logout(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, formatResponse(http.StatusUnauthorized, "User not authenticated", nil, "User not authenticated"))
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	if err := h.service.Logout(userID.(uint)); err != nil {
-		c.JSON(http.StatusInternalServerError, formatResponse(http.StatusInternalServerError, "Failed to logout", nil, err.Error()))
+	if err := h.service.Logout(c.Request.Context(), userID.(uint), c.GetString("session_id")); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "User logged out successfully", nil, ""))
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User logged out successfully", nil))
 }
 
-func (h *UserHandler) ResetPassword(c *gin.Context) {
-	var req dto.ResetPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, formatResponse(http.StatusBadRequest, "Invalid request", nil, err.Error()))
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	resp, err := h.service.ListSessions(c.Request.Context(), userID.(uint), c.GetString("session_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Sessions retrieved successfully", resp))
+}
+
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	if err := h.service.RevokeSession(c.Request.Context(), userID.(uint), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Session revoked successfully", nil))
+}
+
+func (h *UserHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
 		return
 	}
 
-	resp := &dto.ResetPasswordResponse{
-		Status:  "success",
-		Message: "If your email exists in our system, you will receive a password reset link",
+	if err := h.service.RevokeOtherSessions(c.Request.Context(), userID.(uint), c.GetString("session_id")); err != nil {
+		c.Error(err)
+		return
 	}
 
-	c.JSON(http.StatusOK, formatResponse(http.StatusOK, "Password reset link sent successfully", resp, ""))
-}
\ No newline at end of file
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "Other sessions revoked successfully", nil))
+}
+
+// writeTooManyAttempts sets the Retry-After header in whole seconds and
+// records err, which is written as a 429.
+func writeTooManyAttempts(c *gin.Context, err *service.TooManyAttemptsError) {
+	retryAfter := int(math.Ceil(err.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.Error(err)
+}
+
+// canManageUser reports whether the authenticated user may modify the account
+// with the given ID: either it is their own account or they are an admin.
+func canManageUser(c *gin.Context, id uint) bool {
+	userID, ok := c.Get("user_id")
+	return ok && authz.CanModify(c.Request.Context(), userID.(uint), id)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go-backend/internal/modules/user/domain/service"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/response"
+)
+
+func (h *UserHandler) VerifyMFA(c *gin.Context) {
+	var req dto.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := h.service.VerifyMFA(c.Request.Context(), &req)
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User logged in successfully", resp))
+}
+
+func (h *UserHandler) BeginLoginMFAEnrollment(c *gin.Context) {
+	var req dto.MFATokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	resp, err := h.service.BeginLoginMFAEnrollment(c.Request.Context(), &req)
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "MFA enrollment started", resp))
+}
+
+func (h *UserHandler) ConfirmLoginMFAEnrollment(c *gin.Context) {
+	var req dto.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := h.service.ConfirmLoginMFAEnrollment(c.Request.Context(), &req)
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "MFA enabled and user logged in successfully", resp))
+}
+
+func (h *UserHandler) BeginMFAEnrollment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	resp, err := h.service.BeginMFAEnrollment(c.Request.Context(), userID.(uint))
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "MFA enrollment started", resp))
+}
+
+func (h *UserHandler) ConfirmMFAEnrollment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	resp, err := h.service.ConfirmMFAEnrollment(c.Request.Context(), userID.(uint), req.Code)
+	if err != nil {
+		writeMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "MFA enabled successfully", resp))
+}
+
+func (h *UserHandler) DisableMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.Error(apperror.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	if err := h.service.DisableMFA(c.Request.Context(), userID.(uint), req.Code); err != nil {
+		writeMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "MFA disabled successfully", nil))
+}
+
+func writeMFAError(c *gin.Context, err error) {
+	var tooMany *service.TooManyAttemptsError
+	if errors.As(err, &tooMany) {
+		writeTooManyAttempts(c, tooMany)
+		return
+	}
+	c.Error(err)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/response"
+)
+
+// BeginOAuthLogin redirects the browser to the provider's consent page.
+func (h *UserHandler) BeginOAuthLogin(c *gin.Context) {
+	authURL, err := h.service.BeginOAuthLogin(c.Request.Context(), c.Param("provider"), c.Query("device"))
+	if err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OAuthCallback completes the login when the provider redirects back.
+func (h *UserHandler) OAuthCallback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.Error(apperror.Unauthorized("OAuth login failed: " + providerErr))
+		return
+	}
+
+	var req dto.OAuthCallbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	resp, err := h.service.CompleteOAuthLogin(c.Request.Context(), c.Param("provider"), &req)
+	if err != nil {
+		writeOAuthError(c, err)
+		return
+	}
+
+	if resp.MFAToken != "" {
+		c.JSON(http.StatusOK, response.New(http.StatusOK, "MFA verification required", resp))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.New(http.StatusOK, "User logged in successfully", resp))
+}
+
+// writeOAuthError records err. Failures that are not an apperror.Error, such
+// as a failed code exchange, are reported as 401s.
+func writeOAuthError(c *gin.Context, err error) {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		err = apperror.Unauthorized("OAuth login failed").Wrap(err)
+	}
+	c.Error(err)
+}
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS o_auth_states;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS one_time_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go-backend/internal/modules/user/domain/entity"
+)
+
+type MockOneTimeTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockOneTimeTokenRepository) Create(ctx context.Context, token *entity.OneTimeToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockOneTimeTokenRepository) GetByHash(ctx context.Context, purpose entity.TokenPurpose, tokenHash string) (*entity.OneTimeToken, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OneTimeToken), args.Error(1)
+}
+
+func (m *MockOneTimeTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOneTimeTokenRepository) InvalidateByUserID(ctx context.Context, userID uint, purpose entity.TokenPurpose) error {
+	args := m.Called(ctx, userID, purpose)
+	return args.Error(0)
+}
+
+func (m *MockOneTimeTokenRepository) IssuedSince(ctx context.Context, userID uint, purpose entity.TokenPurpose, since time.Time) (bool, error) {
+	args := m.Called(ctx, userID, purpose, since)
+	return args.Bool(0), args.Error(1)
+}
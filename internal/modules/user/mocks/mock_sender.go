@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"go-backend/internal/infrastructure/mailer"
+)
+
+type MockSender struct {
+	mock.Mock
+}
+
+func (m *MockSender) Send(msg mailer.Message) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
@@ -1,68 +1,167 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"go-backend/internal/modules/user/dto"
+	"go-backend/internal/pkg/pagination"
 )
 
 type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) Create(req *dto.CreateUserRequest) (*dto.UserResponse, error) {
-	args := m.Called(req)
+func (m *MockUserService) Create(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) GetByID(id uint) (*dto.UserResponse, error) {
-	args := m.Called(id)
+func (m *MockUserService) GetByID(ctx context.Context, id uint) (*dto.UserResponse, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) GetByEmail(email string) (*dto.UserResponse, error) {
-	args := m.Called(email)
+func (m *MockUserService) GetByEmail(ctx context.Context, email string) (*dto.UserResponse, error) {
+	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
-	args := m.Called(req)
+func (m *MockUserService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.LoginResponse), args.Error(1)
 }
 
-func (m *MockUserService) Update(id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
-	args := m.Called(id, req)
+func (m *MockUserService) Refresh(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserService) Update(ctx context.Context, id uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserResponse), args.Error(1)
+}
+
+func (m *MockUserService) UpdateRole(ctx context.Context, id uint, role string) (*dto.UserResponse, error) {
+	args := m.Called(ctx, id, role)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.UserResponse), args.Error(1)
 }
 
-func (m *MockUserService) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockUserService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserService) List(ctx context.Context, params pagination.Params) ([]*dto.UserResponse, pagination.Meta, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, pagination.Meta{}, args.Error(2)
+	}
+	return args.Get(0).([]*dto.UserResponse), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockUserService) Logout(ctx context.Context, userID uint, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ListSessions(ctx context.Context, userID uint, currentSessionID string) ([]*dto.SessionResponse, error) {
+	args := m.Called(ctx, userID, currentSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.SessionResponse), args.Error(1)
+}
+
+func (m *MockUserService) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RevokeOtherSessions(ctx context.Context, userID uint, currentSessionID string) error {
+	args := m.Called(ctx, userID, currentSessionID)
 	return args.Error(0)
 }
 
-func (m *MockUserService) List(page, limit int) ([]*dto.UserResponse, error) {
-	args := m.Called(page, limit)
+func (m *MockUserService) Unlock(ctx context.Context, id, actorID uint) error {
+	args := m.Called(ctx, id, actorID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) BeginOAuthLogin(ctx context.Context, provider, device string) (string, error) {
+	args := m.Called(ctx, provider, device)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserService) CompleteOAuthLogin(ctx context.Context, provider string, req *dto.OAuthCallbackRequest) (*dto.LoginResponse, error) {
+	args := m.Called(ctx, provider, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserService) VerifyMFA(ctx context.Context, req *dto.MFALoginRequest) (*dto.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserService) BeginLoginMFAEnrollment(ctx context.Context, req *dto.MFATokenRequest) (*dto.MFAEnrollmentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockUserService) ConfirmLoginMFAEnrollment(ctx context.Context, req *dto.MFALoginRequest) (*dto.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserService) BeginMFAEnrollment(ctx context.Context, userID uint) (*dto.MFAEnrollmentResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MFAEnrollmentResponse), args.Error(1)
+}
+
+func (m *MockUserService) ConfirmMFAEnrollment(ctx context.Context, userID uint, code string) (*dto.RecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, code)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*dto.UserResponse), args.Error(1)
+	return args.Get(0).(*dto.RecoveryCodesResponse), args.Error(1)
 }
 
-func (m *MockUserService) Logout(userID uint) error {
-	args := m.Called(userID)
+func (m *MockUserService) DisableMFA(ctx context.Context, userID uint, code string) error {
+	args := m.Called(ctx, userID, code)
 	return args.Error(0)
 }
@@ -2,22 +2,28 @@ package user
 
 import (
 	"github.com/gin-gonic/gin"
+	"go-backend/internal/infrastructure/config"
 	"go-backend/internal/modules/user/seeder"
 	"gorm.io/gorm"
 )
 
 type Module struct {
-	db *gorm.DB
+	db  *gorm.DB
+	cfg *config.Config
 }
 
-func NewModule(db *gorm.DB) *Module {
+func NewModule(db *gorm.DB, cfg *config.Config) *Module {
 	// Seed admin users when module is initialized
 	if err := seeder.SeedAdminUsers(db); err != nil {
 		panic("Failed to seed admin users: " + err.Error())
 	}
-	return &Module{db: db}
+	return &Module{db: db, cfg: cfg}
 }
 
 func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
-	RegisterRoutes(router, m.db)
+	RegisterRoutes(router, m.db, m.cfg)
+}
+
+func (m *Module) RegisterWellKnownRoutes(router *gin.RouterGroup) {
+	RegisterWellKnownRoutes(router)
 }
\ No newline at end of file
@@ -1,7 +1,12 @@
 package user
 
 import (
+	"go-backend/internal/infrastructure/config"
+	"go-backend/internal/infrastructure/jwtkeys"
+	"go-backend/internal/infrastructure/mailer"
 	"go-backend/internal/infrastructure/middleware"
+	"go-backend/internal/infrastructure/oauth"
+	"go-backend/internal/modules/user/domain/entity"
 	"go-backend/internal/modules/user/domain/repository"
 	"go-backend/internal/modules/user/domain/service"
 	"go-backend/internal/modules/user/handlers"
@@ -10,20 +15,84 @@ import (
 	"gorm.io/gorm"
 )
 
-func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB) {
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, cfg *config.Config) {
 	repo := repository.NewUserRepository(db)
-	svc := service.NewUserService(repo)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+
+	oauthProviders, err := oauth.ProvidersFromConfig(cfg.OAuth)
+	if err != nil {
+		panic("Failed to configure OAuth providers: " + err.Error())
+	}
+
+	svc := service.NewUserService(
+		repo,
+		refreshTokenRepo,
+		sessionRepo,
+		repository.NewRecoveryCodeRepository(db),
+		repository.NewAuditRepository(db),
+		repository.NewUserIdentityRepository(db),
+		repository.NewOAuthStateRepository(db),
+		service.Config{
+			MFA: service.MFAConfig{
+				Issuer:            cfg.Auth.MFAIssuer,
+				RequiredForAdmins: cfg.Auth.MFARequiredForAdmins,
+			},
+			OAuth: service.OAuthConfig{
+				Providers:           oauthProviders,
+				RegistrationEnabled: cfg.Auth.RegistrationEnabled,
+			},
+		},
+	)
 	handler := handlers.NewUserHandler(svc)
+	apiKeyHandler := handlers.NewAPIKeyHandler(service.NewAPIKeyService(repository.NewAPIKeyRepository(db)))
 
-	// Auth routes
-	auth := router.Group("/auth")
+	sender, err := mailer.New(cfg.Mail)
+	if err != nil {
+		panic("Failed to configure mailer: " + err.Error())
+	}
+	accountSvc := service.NewAccountService(
+		repo,
+		repository.NewOneTimeTokenRepository(db),
+		sessionRepo,
+		refreshTokenRepo,
+		sender,
+		service.AccountConfig{
+			RegistrationEnabled:  cfg.Auth.RegistrationEnabled,
+			EmailVerificationURL: cfg.Auth.EmailVerificationURL,
+			PasswordResetURL:     cfg.Auth.PasswordResetURL,
+		},
+	)
+	accountHandler := handlers.NewAccountHandler(accountSvc)
+
+	// Auth routes, rate limited per IP on top of the login lockout
+	auth := router.Group("/auth", middleware.RateLimitMiddleware(middleware.NewRateLimiter()))
 	{
+		auth.POST("/register", accountHandler.Register)
+		auth.GET("/verify", accountHandler.VerifyEmail)
+		auth.POST("/verify-email/resend", accountHandler.ResendVerification)
 		auth.POST("/login", handler.Login)
-		auth.POST("/reset-password", handler.ResetPassword)
+		auth.POST("/login/mfa", handler.VerifyMFA)
+		auth.POST("/login/mfa/enroll", handler.BeginLoginMFAEnrollment)
+		auth.POST("/login/mfa/confirm", handler.ConfirmLoginMFAEnrollment)
+		auth.GET("/oauth/:provider", handler.BeginOAuthLogin)
+		auth.GET("/oauth/:provider/callback", handler.OAuthCallback)
+		auth.POST("/refresh", handler.Refresh)
+		auth.POST("/reset-password", accountHandler.ResetPassword)
+		auth.POST("/reset-password/confirm", accountHandler.ConfirmResetPassword)
 
 		// Protected auth routes - using AccessToken for logout
 		protected := auth.Use(middleware.JWTAuth(middleware.AccessToken))
 		protected.POST("/logout", handler.Logout)
+		protected.GET("/sessions", handler.ListSessions)
+		protected.DELETE("/sessions", handler.RevokeOtherSessions)
+		protected.DELETE("/sessions/:id", handler.RevokeSession)
+		protected.POST("/mfa/enroll", handler.BeginMFAEnrollment)
+		protected.POST("/mfa/confirm", handler.ConfirmMFAEnrollment)
+		protected.POST("/mfa/disable", handler.DisableMFA)
+		protected.GET("/api-keys", apiKeyHandler.List)
+		protected.POST("/api-keys", apiKeyHandler.Create)
+		protected.DELETE("/api-keys/:id", apiKeyHandler.Revoke)
 	}
 
 	// User routes
@@ -33,13 +102,31 @@ func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB) {
 
 		users.GET("", handler.List)
 
+		// Access token or any API key
+		users.GET("/:id", middleware.JWTOrAPIKeyAuth(entity.ScopeRead), handler.GetByID)
+
 		// Protected routes - using AccessToken for user management
-		protected := users.Use(middleware.JWTAuth(middleware.AccessToken))
+		protected := users.Group("", middleware.JWTAuth(middleware.AccessToken))
 		{
-			protected.POST("", handler.Create)
-			protected.GET("/:id", handler.GetByID)
+			// Users may update their own account; admins may update any
 			protected.PUT("/:id", handler.Update)
-			protected.DELETE("/:id", handler.Delete)
+
+			// Admin only
+			admin := protected.Group("", middleware.RequireRole(string(entity.RoleAdmin)))
+			admin.POST("", handler.Create)
+			admin.DELETE("/:id", handler.Delete)
+			admin.PUT("/:id/role", handler.UpdateRole)
+			admin.POST("/:id/unlock", handler.Unlock)
 		}
 	}
 }
+
+// RegisterWellKnownRoutes registers the routes served under /.well-known.
+func RegisterWellKnownRoutes(router *gin.RouterGroup) {
+	keys, err := jwtkeys.Default()
+	if err != nil {
+		panic("Failed to load JWT keys: " + err.Error())
+	}
+
+	router.GET("/jwks.json", handlers.NewJWKSHandler(keys).JWKS)
+}
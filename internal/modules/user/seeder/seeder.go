@@ -3,6 +3,7 @@ package seeder
 import (
 	"go-backend/internal/modules/user/domain/entity"
 	"log"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -12,11 +13,13 @@ var adminUsers = []entity.User{
 		Name:     "Admin User",
 		Email:    "admin@example.com",
 		Password: "admin123",
+		Role:     entity.RoleAdmin,
 	},
 	{
 		Name:     "Super Admin",
 		Email:    "superadmin@example.com",
 		Password: "superadmin123",
+		Role:     entity.RoleAdmin,
 	},
 }
 
@@ -27,6 +30,8 @@ func SeedAdminUsers(db *gorm.DB) error {
 		result := db.Where("email = ?", admin.Email).First(&existingUser)
 		
 		if result.Error == gorm.ErrRecordNotFound {
+			verifiedAt := time.Now()
+			admin.EmailVerifiedAt = &verifiedAt
 			if err := db.Create(&admin).Error; err != nil {
 				log.Printf("Error seeding admin user %s: %v", admin.Email, err)
 				return err
@@ -35,6 +40,13 @@ func SeedAdminUsers(db *gorm.DB) error {
 		} else if result.Error != nil {
 			log.Printf("Error checking existing admin user %s: %v", admin.Email, result.Error)
 			return result.Error
+		} else if existingUser.Role != entity.RoleAdmin {
+			// Accounts seeded before roles existed default to viewer
+			if err := db.Model(&existingUser).UpdateColumn("role", entity.RoleAdmin).Error; err != nil {
+				log.Printf("Error promoting admin user %s: %v", admin.Email, err)
+				return err
+			}
+			log.Printf("Admin user promoted to admin role: %s", admin.Email)
 		} else {
 			log.Printf("Admin user already exists: %s", admin.Email)
 		}
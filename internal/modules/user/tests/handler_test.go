@@ -6,13 +6,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go-backend/internal/infrastructure/middleware"
 	"go-backend/internal/modules/user/dto"
 	"go-backend/internal/modules/user/handlers"
 	"go-backend/internal/modules/user/mocks"
+	"go-backend/internal/pkg/authz"
+	"go-backend/internal/pkg/pagination"
 )
 
 func TestUserHandler_Create(t *testing.T) {
@@ -35,7 +39,7 @@ func TestUserHandler_Create(t *testing.T) {
 				Password: "password123",
 			},
 			mockFn: func() {
-				mockService.On("Create", mock.AnythingOfType("*dto.CreateUserRequest")).
+				mockService.On("Create", mock.Anything, mock.AnythingOfType("*dto.CreateUserRequest")).
 					Return(&dto.UserResponse{
 						ID:    1,
 						Name:  "Test User",
@@ -62,4 +66,125 @@ func TestUserHandler_Create(t *testing.T) {
 			mockService.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUserHandler_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		userID         uint
+		role           string
+		mockFn         func(*mocks.MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:   "own account",
+			userID: 1,
+			role:   "viewer",
+			mockFn: func(svc *mocks.MockUserService) {
+				svc.On("Update", mock.Anything, uint(1), mock.AnythingOfType("*dto.UpdateUserRequest")).
+					Return(&dto.UserResponse{ID: 1, Name: "Updated"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "another account",
+			userID:         2,
+			role:           "editor",
+			mockFn:         func(svc *mocks.MockUserService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "admin updating another account",
+			userID: 2,
+			role:   "admin",
+			mockFn: func(svc *mocks.MockUserService) {
+				svc.On("Update", mock.Anything, uint(1), mock.AnythingOfType("*dto.UpdateUserRequest")).
+					Return(&dto.UserResponse{ID: 1, Name: "Updated"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.MockUserService)
+			handler := handlers.NewUserHandler(mockService)
+			tt.mockFn(mockService)
+
+			r := gin.New()
+			r.Use(middleware.ErrorMiddleware())
+			r.PUT("/users/:id", func(c *gin.Context) {
+				// As stored by the authentication middleware
+				c.Set("user_id", tt.userID)
+				c.Set("user_role", tt.role)
+				actor := authz.Actor{UserID: tt.userID, Admin: tt.role == "admin"}
+				c.Request = c.Request.WithContext(authz.WithActor(c.Request.Context(), actor))
+				handler.Update(c)
+			})
+
+			w := httptest.NewRecorder()
+			jsonData, _ := json.Marshal(&dto.UpdateUserRequest{Name: "Updated"})
+			req := httptest.NewRequest(http.MethodPut, "/users/1", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_LockedUntil(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	locked := func() *dto.UserResponse {
+		until := time.Now().Add(10 * time.Minute)
+		return &dto.UserResponse{ID: 2, Name: "Locked", LockedUntil: &until}
+	}
+
+	tests := []struct {
+		name   string
+		userID uint
+		role   string
+		path   string
+		shown  bool
+	}{
+		{name: "own account", userID: 2, role: "viewer", path: "/users/2", shown: true},
+		{name: "admin", userID: 1, role: "admin", path: "/users/2", shown: true},
+		{name: "another user", userID: 3, role: "editor", path: "/users/2", shown: false},
+		{name: "public list", path: "/users", shown: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.MockUserService)
+			mockService.On("GetByID", mock.Anything, uint(2)).Return(locked(), nil)
+			mockService.On("List", mock.Anything, mock.AnythingOfType("pagination.Params")).
+				Return([]*dto.UserResponse{locked()}, pagination.Meta{}, nil)
+			handler := handlers.NewUserHandler(mockService)
+
+			authenticate := func(c *gin.Context) {
+				if tt.userID == 0 {
+					return
+				}
+				c.Set("user_id", tt.userID)
+				c.Set("user_role", tt.role)
+				actor := authz.Actor{UserID: tt.userID, Admin: tt.role == "admin"}
+				c.Request = c.Request.WithContext(authz.WithActor(c.Request.Context(), actor))
+			}
+
+			r := gin.New()
+			r.Use(middleware.ErrorMiddleware())
+			r.GET("/users", handler.List)
+			r.GET("/users/:id", authenticate, handler.GetByID)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.shown, bytes.Contains(w.Body.Bytes(), []byte(`"locked_until"`)))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,7 +13,7 @@ import (
 
 func TestUserService_Create(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	svc := service.NewUserService(mockRepo)
+	svc := service.NewUserService(mockRepo, new(mocks.MockRefreshTokenRepository), new(mocks.MockSessionRepository), new(mocks.MockRecoveryCodeRepository), new(mocks.MockAuditRepository), new(mocks.MockUserIdentityRepository), new(mocks.MockOAuthStateRepository), service.Config{})
 
 	tests := []struct {
 		name    string
@@ -28,7 +29,7 @@ func TestUserService_Create(t *testing.T) {
 				Password: "password123",
 			},
 			mockFn: func() {
-				mockRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(nil)
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -37,7 +38,7 @@ func TestUserService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn()
-			user, err := svc.Create(tt.input)
+			user, err := svc.Create(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -0,0 +1,172 @@
+// Package apperror defines the errors that services return to describe what
+// went wrong in terms a client can act on. Each error has a Kind, which
+// decides the HTTP status, and a message that is safe to show. The cause, if
+// any, is kept for logs but never sent to clients.
+//
+// Errors that are not an *Error, such as database failures, are reported to
+// clients as internal errors; see From.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// Kind classifies an error. Its value is the stable error code sent to
+// clients.
+type Kind string
+
+const (
+	KindBadRequest      Kind = "bad_request"
+	KindValidation      Kind = "validation_failed"
+	KindUnauthorized    Kind = "unauthorized"
+	KindForbidden       Kind = "forbidden"
+	KindNotFound        Kind = "not_found"
+	KindConflict        Kind = "conflict"
+	KindTooManyRequests Kind = "too_many_requests"
+	KindInternal        Kind = "internal"
+)
+
+// Status returns the HTTP status code for k.
+func (k Kind) Status() int {
+	switch k {
+	case KindBadRequest, KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// FieldError describes an invalid field of a request. Rule and Param name the
+// rule that the field failed, such as max_length and 255; see the validation
+// package.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+type Error struct {
+	Kind Kind
+	// Message is shown to clients.
+	Message string
+	// Fields lists the invalid fields of a validation error.
+	Fields []FieldError
+	// Err is the underlying cause. It is logged but not shown to clients.
+	Err error
+
+	// messageKey is the key of Message in the validation messages, for
+	// messages that can be translated.
+	messageKey string
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Status returns the HTTP status code for e.
+func (e *Error) Status() int {
+	return e.Kind.Status()
+}
+
+// Wrap returns a copy of e caused by err. Note that errors.Is does not match
+// the copy against e.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func BadRequest(message string) *Error {
+	return New(KindBadRequest, message)
+}
+
+// Validation reports an invalid request, listing the invalid fields. Build
+// the fields with Field.
+func Validation(fields ...FieldError) *Error {
+	err := translatable(KindValidation, "validation_failed")
+	err.Fields = fields
+	return err
+}
+
+func Unauthorized(message string) *Error {
+	return New(KindUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(KindForbidden, message)
+}
+
+func NotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+func TooManyRequests(message string) *Error {
+	return New(KindTooManyRequests, message)
+}
+
+// Internal reports an unexpected failure. Clients only see a generic message.
+func Internal(err error) *Error {
+	return &Error{Kind: KindInternal, Message: "internal server error", Err: err}
+}
+
+// From returns err as an *Error. Missing records become not found errors,
+// duplicate keys become conflicts and any other error is internal. It returns
+// nil for a nil err.
+func From(err error) *Error {
+	var appErr *Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFound("resource not found").Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return Conflict("resource already exists").Wrap(err)
+	default:
+		return Internal(err)
+	}
+}
+
+// NotFoundOr returns a not found error with message when err is a missing
+// record, and err otherwise. Services use it to name what was not found:
+//
+//	post, err := s.repo.GetByID(ctx, id)
+//	if err != nil {
+//		return nil, apperror.NotFoundOr(err, "post not found")
+//	}
+func NotFoundOr(err error, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound(message).Wrap(err)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go-backend/internal/pkg/validation"
+	"gorm.io/gorm"
+)
+
+func TestFrom(t *testing.T) {
+	forbidden := Forbidden("you can only update your own posts")
+
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{"app error", forbidden, http.StatusForbidden, forbidden.Message},
+		{"wrapped app error", fmt.Errorf("update: %w", forbidden), http.StatusForbidden, forbidden.Message},
+		{"missing record", gorm.ErrRecordNotFound, http.StatusNotFound, "resource not found"},
+		{"duplicate key", gorm.ErrDuplicatedKey, http.StatusConflict, "resource already exists"},
+		{"other error", errors.New("connection refused"), http.StatusInternalServerError, "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := From(tt.err)
+
+			assert.Equal(t, tt.status, appErr.Status())
+			assert.Equal(t, tt.message, appErr.Message)
+		})
+	}
+
+	assert.ErrorIs(t, From(gorm.ErrRecordNotFound), gorm.ErrRecordNotFound)
+	assert.Nil(t, From(nil))
+}
+
+func TestNotFoundOr(t *testing.T) {
+	err := NotFoundOr(fmt.Errorf("get post: %w", gorm.ErrRecordNotFound), "post not found")
+	assert.Equal(t, http.StatusNotFound, From(err).Status())
+	assert.Equal(t, "post not found", From(err).Message)
+
+	other := errors.New("connection refused")
+	assert.Same(t, other, NotFoundOr(other, "post not found"))
+}
+
+func TestWrap(t *testing.T) {
+	invalidToken := Unauthorized("invalid token")
+	cause := errors.New("token expired")
+	err := invalidToken.Wrap(cause)
+
+	assert.Equal(t, "invalid token: token expired", err.Error())
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, invalidToken.Err, "Wrap must not modify the original error")
+}
+
+func TestLocalize(t *testing.T) {
+	err := Validation(Field("title", "max_length", "255"))
+	assert.Equal(t, "request validation failed", err.Message)
+	assert.Equal(t, "must be at most 255 characters long", err.Fields[0].Message)
+
+	localized := err.Localize(validation.Indonesian)
+	assert.Equal(t, "validasi permintaan gagal", localized.Message)
+	assert.Equal(t, FieldError{Field: "title", Rule: "max_length", Param: "255", Message: "maksimal 255 karakter"}, localized.Fields[0])
+	assert.Equal(t, "must be at most 255 characters long", err.Fields[0].Message, "Localize must not modify the original error")
+
+	forbidden := Forbidden("you can only update your own posts")
+	assert.Equal(t, forbidden.Message, forbidden.Localize(validation.Indonesian).Message)
+}
@@ -0,0 +1,73 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/go-playground/validator/v10"
+	"go-backend/internal/pkg/validation"
+)
+
+// FromBinding converts an error from binding a request, as returned by
+// gin's ShouldBindJSON, into a validation error listing all invalid fields.
+func FromBinding(err error) *Error {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			rule, param := validation.Rule(fe)
+			fields = append(fields, Field(validation.FieldName(fe), rule, param))
+		}
+		return Validation(fields...).Wrap(err)
+	case errors.As(err, &typeErr):
+		return Validation(Field(typeErr.Field, "type", typeErr.Type.String())).Wrap(err)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return translatable(KindBadRequest, "invalid_json").Wrap(err)
+	case errors.Is(err, io.EOF):
+		return translatable(KindBadRequest, "empty_body").Wrap(err)
+	default:
+		return translatable(KindBadRequest, "invalid_request").Wrap(err)
+	}
+}
+
+// Field returns a field error for a failed rule, with the message in English.
+// Services use it for checks that tags cannot express:
+//
+//	apperror.Validation(apperror.Field("end_date", "notbefore", "start_date"))
+func Field(name, rule, param string) FieldError {
+	return FieldError{
+		Field:   name,
+		Rule:    rule,
+		Param:   param,
+		Message: validation.Message(validation.English, rule, param),
+	}
+}
+
+// Localize returns a copy of e with its message and field messages in lang,
+// where they can be translated.
+func (e *Error) Localize(lang validation.Language) *Error {
+	localized := *e
+	if e.messageKey != "" {
+		localized.Message = validation.Message(lang, e.messageKey, "")
+	}
+	if len(e.Fields) > 0 {
+		localized.Fields = make([]FieldError, len(e.Fields))
+		for i, field := range e.Fields {
+			field.Message = validation.Message(lang, field.Rule, field.Param)
+			localized.Fields[i] = field
+		}
+	}
+	return &localized
+}
+
+func translatable(kind Kind, key string) *Error {
+	return &Error{
+		Kind:       kind,
+		Message:    validation.Message(validation.English, key, ""),
+		messageKey: key,
+	}
+}
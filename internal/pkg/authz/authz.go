@@ -0,0 +1,61 @@
+// Package authz decides who may change a resource. Resources belong to the
+// user who created them: owners may change their own resources and admins may
+// change any resource.
+//
+// Services check ownership after loading the resource, so that a missing
+// resource is reported as not found and someone else's as forbidden:
+//
+//	post, err := s.repo.GetByID(ctx, id)
+//	if err != nil {
+//		return apperror.NotFoundOr(err, "post not found")
+//	}
+//	if err := authz.RequireOwner(ctx, userID, post.UserID, "delete", "posts"); err != nil {
+//		return err
+//	}
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	"go-backend/internal/pkg/apperror"
+)
+
+// Actor is the authenticated user a request is made by.
+type Actor struct {
+	UserID uint
+	Admin  bool
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying actor. The authentication
+// middleware stores the authenticated user in the request context this way.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx, if any.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// CanModify reports whether userID may change a resource owned by ownerID,
+// that is whether userID is the owner or the admin making the request in ctx.
+func CanModify(ctx context.Context, userID, ownerID uint) bool {
+	if userID == ownerID {
+		return true
+	}
+	actor, ok := ActorFromContext(ctx)
+	return ok && actor.Admin && actor.UserID == userID
+}
+
+// RequireOwner returns a forbidden error, such as "you can only update your
+// own posts", unless userID may change a resource owned by ownerID.
+func RequireOwner(ctx context.Context, userID, ownerID uint, action, resource string) error {
+	if CanModify(ctx, userID, ownerID) {
+		return nil
+	}
+	return apperror.Forbidden(fmt.Sprintf("you can only %s your own %s", action, resource))
+}
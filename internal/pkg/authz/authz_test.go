@@ -0,0 +1,41 @@
+package authz
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go-backend/internal/pkg/apperror"
+)
+
+func TestCanModify(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		userID  uint
+		ownerID uint
+		want    bool
+	}{
+		{"owner", context.Background(), 1, 1, true},
+		{"another user", WithActor(context.Background(), Actor{UserID: 2}), 2, 1, false},
+		{"admin", WithActor(context.Background(), Actor{UserID: 2, Admin: true}), 2, 1, true},
+		{"admin acting for someone else", WithActor(context.Background(), Actor{UserID: 3, Admin: true}), 2, 1, false},
+		{"no actor", context.Background(), 2, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CanModify(tt.ctx, tt.userID, tt.ownerID))
+		})
+	}
+}
+
+func TestRequireOwner(t *testing.T) {
+	assert.NoError(t, RequireOwner(context.Background(), 1, 1, "update", "posts"))
+
+	err := RequireOwner(context.Background(), 2, 1, "update", "posts")
+	appErr := apperror.From(err)
+	assert.Equal(t, http.StatusForbidden, appErr.Status())
+	assert.Equal(t, "you can only update your own posts", appErr.Message)
+}
@@ -0,0 +1,244 @@
+// Package filter filters list endpoints by their query parameters:
+//
+//	GET /api/tools?filter[category]=backend
+//	GET /api/posts?filter[user_id]=7&filter[created_at][gte]=2024-01-01
+//	GET /api/experiences?filter[end_date][null]=true
+//
+// A parameter names a field and, optionally, an operator; without one the
+// field must equal the value. Each list whitelists the fields it can be
+// filtered by, so clients can never reach other columns, and values are
+// always bound as query parameters. Handlers parse the query with Parse and
+// repositories apply the result with Scope.
+package filter
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-backend/internal/pkg/apperror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxValues is the largest number of values of an in filter.
+const MaxValues = 100
+
+// Type is the type of a filterable field, which decides how its values are
+// parsed and which operators it supports.
+type Type int
+
+const (
+	String Type = iota
+	Integer
+	Time
+	Bool
+)
+
+// String returns the name of the type as it is reported in validation
+// errors.
+func (t Type) String() string {
+	switch t {
+	case Integer:
+		return "integer"
+	case Time:
+		return "date"
+	case Bool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// Operators of filters. Eq is the operator of a parameter without one.
+const (
+	Eq       = "eq"
+	Ne       = "ne"
+	Gt       = "gt"
+	Gte      = "gte"
+	Lt       = "lt"
+	Lte      = "lte"
+	In       = "in"
+	Contains = "contains"
+	Null     = "null"
+)
+
+var operators = map[Type][]string{
+	String:  {Eq, Ne, In, Contains, Null},
+	Integer: {Eq, Ne, Gt, Gte, Lt, Lte, In, Null},
+	Time:    {Eq, Ne, Gt, Gte, Lt, Lte, Null},
+	Bool:    {Eq, Ne, Null},
+}
+
+// Field is a field that a list can be filtered by.
+type Field struct {
+	Column string
+	Type   Type
+}
+
+// Fields maps the names that clients filter by, which are the JSON names of
+// the fields, to their columns.
+type Fields map[string]Field
+
+func (f Fields) names() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+// Filter is a parsed set of filters. The zero value filters nothing.
+type Filter struct {
+	conditions []clause.Expression
+}
+
+var paramPattern = regexp.MustCompile(`^filter\[([^\[\]]*)\](?:\[([^\[\]]*)\])?$`)
+
+// Parse reads the filter parameters of a list request. Parameters for
+// fields that are not in fields, unknown operators and values of the wrong
+// type are reported as a validation error.
+func Parse(query url.Values, fields Fields) (Filter, error) {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if key == "filter" || strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var f Filter
+	var errs []apperror.FieldError
+	for _, key := range keys {
+		match := paramPattern.FindStringSubmatch(key)
+		if match == nil {
+			errs = append(errs, apperror.Field(key, "invalid", ""))
+			continue
+		}
+		field, ok := fields[match[1]]
+		if !ok {
+			errs = append(errs, apperror.Field(key, "oneof", fields.names()))
+			continue
+		}
+		op := match[2]
+		if op == "" {
+			op = Eq
+		}
+		if !supports(field.Type, op) {
+			errs = append(errs, apperror.Field(key, "oneof", strings.Join(operators[field.Type], " ")))
+			continue
+		}
+
+		condition, fieldErr := parseCondition(key, field, op, query.Get(key))
+		if fieldErr != nil {
+			errs = append(errs, *fieldErr)
+			continue
+		}
+		f.conditions = append(f.conditions, condition)
+	}
+
+	if len(errs) > 0 {
+		return Filter{}, apperror.Validation(errs...)
+	}
+	return f, nil
+}
+
+// Scope adds the filters to a query, as in db.Scopes(f.Scope).
+func (f Filter) Scope(db *gorm.DB) *gorm.DB {
+	if len(f.conditions) == 0 {
+		return db
+	}
+	return db.Where(clause.And(f.conditions...))
+}
+
+func supports(t Type, op string) bool {
+	for _, supported := range operators[t] {
+		if op == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCondition parses the value of the parameter key, which filters field
+// with op.
+func parseCondition(key string, field Field, op, value string) (clause.Expression, *apperror.FieldError) {
+	column := clause.Column{Table: clause.CurrentTable, Name: field.Column}
+	invalid := func(rule, param string) (clause.Expression, *apperror.FieldError) {
+		fieldErr := apperror.Field(key, rule, param)
+		return nil, &fieldErr
+	}
+
+	switch op {
+	case Null:
+		isNull, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid("type", Bool.String())
+		}
+		if isNull {
+			return clause.Eq{Column: column, Value: nil}, nil
+		}
+		return clause.Neq{Column: column, Value: nil}, nil
+	case In:
+		values := strings.Split(value, ",")
+		if len(values) > MaxValues {
+			return invalid("max_items", strconv.Itoa(MaxValues))
+		}
+		parsed := make([]interface{}, len(values))
+		for i, v := range values {
+			var err error
+			if parsed[i], err = parseValue(field.Type, strings.TrimSpace(v)); err != nil {
+				return invalid("type", field.Type.String())
+			}
+		}
+		return clause.IN{Column: column, Values: parsed}, nil
+	case Contains:
+		// ILIKE matches case-insensitively on Postgres; the value's own
+		// wildcards are escaped so that they match themselves
+		pattern := "%" + likeEscaper.Replace(value) + "%"
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{column, pattern}}, nil
+	}
+
+	parsed, err := parseValue(field.Type, value)
+	if err != nil {
+		return invalid("type", field.Type.String())
+	}
+	switch op {
+	case Ne:
+		return clause.Neq{Column: column, Value: parsed}, nil
+	case Gt:
+		return clause.Gt{Column: column, Value: parsed}, nil
+	case Gte:
+		return clause.Gte{Column: column, Value: parsed}, nil
+	case Lt:
+		return clause.Lt{Column: column, Value: parsed}, nil
+	case Lte:
+		return clause.Lte{Column: column, Value: parsed}, nil
+	default:
+		return clause.Eq{Column: column, Value: parsed}, nil
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// parseValue parses a value of type t. Times are RFC 3339 timestamps or
+// dates, which stand for midnight UTC.
+func parseValue(t Type, value string) (interface{}, error) {
+	switch t {
+	case Integer:
+		return strconv.ParseInt(value, 10, 64)
+	case Time:
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed, nil
+		}
+		return time.Parse(time.DateOnly, value)
+	case Bool:
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,110 @@
+package filter
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/pkg/apperror"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   uint
+	Name string
+}
+
+var testFields = Fields{
+	"name":       {Column: "name", Type: String},
+	"user_id":    {Column: "user_id", Type: Integer},
+	"created_at": {Column: "created_at", Type: Time},
+	"end_date":   {Column: "end_date", Type: Time},
+	"public":     {Column: "is_public", Type: Bool},
+}
+
+// statement returns the statement of a query filtered by f.
+func statement(t *testing.T, f Filter) *gorm.Statement {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	return db.Scopes(f.Scope).Find(&[]widget{}).Statement
+}
+
+// sql returns the query that f builds, with its values inlined.
+func sql(t *testing.T, f Filter) string {
+	stmt := statement(t, f)
+	return stmt.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"no filters", "page=2&sort=name", `SELECT * FROM "widgets"`},
+		{"equals", "filter[name]=backend", `SELECT * FROM "widgets" WHERE "widgets"."name" = 'backend'`},
+		{"explicit equals", "filter[user_id][eq]=7", `SELECT * FROM "widgets" WHERE "widgets"."user_id" = 7`},
+		{"not equals", "filter[public][ne]=true", `SELECT * FROM "widgets" WHERE "widgets"."is_public" <> true`},
+		{"date range", "filter[created_at][gte]=2024-01-01&filter[created_at][lt]=2024-02-01T00:00:00Z",
+			`SELECT * FROM "widgets" WHERE ("widgets"."created_at" >= '2024-01-01 00:00:00' AND "widgets"."created_at" < '2024-02-01 00:00:00')`},
+		{"combined", "filter[created_at][gt]=2024-01-01&filter[user_id]=7",
+			`SELECT * FROM "widgets" WHERE ("widgets"."created_at" > '2024-01-01 00:00:00' AND "widgets"."user_id" = 7)`},
+		{"in", "filter[user_id][in]=1,2, 3", `SELECT * FROM "widgets" WHERE "widgets"."user_id" IN (1,2,3)`},
+		{"is null", "filter[end_date][null]=true", `SELECT * FROM "widgets" WHERE "widgets"."end_date" IS NULL`},
+		{"is not null", "filter[end_date][null]=false", `SELECT * FROM "widgets" WHERE "widgets"."end_date" IS NOT NULL`},
+		{"contains", "filter[name][contains]=50%25_off", `SELECT * FROM "widgets" WHERE "widgets"."name" ILIKE '%50\%\_off%'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			f, err := Parse(query, testFields)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sql(t, f))
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+		field apperror.FieldError
+	}{
+		{"unknown field", url.Values{"filter[password]": {"x"}}, apperror.Field("filter[password]", "oneof", "created_at end_date name public user_id")},
+		{"unknown operator", url.Values{"filter[name][gte]": {"x"}}, apperror.Field("filter[name][gte]", "oneof", "eq ne in contains null")},
+		{"malformed", url.Values{"filter[name][eq][x]": {"x"}}, apperror.Field("filter[name][eq][x]", "invalid", "")},
+		{"not an integer", url.Values{"filter[user_id]": {"seven"}}, apperror.Field("filter[user_id]", "type", "integer")},
+		{"not a date", url.Values{"filter[created_at][gte]": {"yesterday"}}, apperror.Field("filter[created_at][gte]", "type", "date")},
+		{"not a boolean", url.Values{"filter[end_date][null]": {"maybe"}}, apperror.Field("filter[end_date][null]", "type", "boolean")},
+		{"bad list item", url.Values{"filter[user_id][in]": {"1,x"}}, apperror.Field("filter[user_id][in]", "type", "integer")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query, testFields)
+
+			appErr := apperror.From(err)
+			assert.Equal(t, apperror.KindValidation, appErr.Kind)
+			assert.Equal(t, []apperror.FieldError{tt.field}, appErr.Fields)
+		})
+	}
+}
+
+func TestParse_BindsValues(t *testing.T) {
+	f, err := Parse(url.Values{"filter[name]": {"x' OR '1'='1"}}, testFields)
+	require.NoError(t, err)
+
+	stmt := statement(t, f)
+	assert.Equal(t, `SELECT * FROM "widgets" WHERE "widgets"."name" = $1`, stmt.SQL.String())
+	assert.Equal(t, []interface{}{"x' OR '1'='1"}, stmt.Vars)
+}
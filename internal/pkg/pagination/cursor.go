@@ -0,0 +1,87 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var errInvalidCursor = errors.New("pagination: invalid cursor")
+
+// cursor is the position after, or before when Backward is set, the item
+// whose sort column holds Value and whose ID is ID. Value is nil when the
+// column is NULL. Clients see it encoded as base64 JSON and must not rely on
+// its contents.
+type cursor struct {
+	Sort     Sort
+	Value    interface{}
+	ID       uint
+	Backward bool
+}
+
+// cursorJSON is the encoded form of a cursor. Times are encoded as strings
+// with Time set, so that they decode as times again.
+type cursorJSON struct {
+	Sort     string      `json:"s"`
+	Value    interface{} `json:"v"`
+	Time     bool        `json:"t,omitempty"`
+	ID       uint        `json:"id"`
+	Backward bool        `json:"b,omitempty"`
+}
+
+func (c *cursor) encode() string {
+	encoded := cursorJSON{Sort: c.Sort.String(), Value: c.Value, ID: c.ID, Backward: c.Backward}
+	if t, ok := c.Value.(time.Time); ok {
+		encoded.Value = t.UTC().Format(time.RFC3339Nano)
+		encoded.Time = true
+	}
+	data, _ := json.Marshal(encoded)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var decoded cursorJSON
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil || decoded.Sort == "" || decoded.Sort == "-" {
+		return nil, errInvalidCursor
+	}
+
+	c := &cursor{Sort: parseSort(decoded.Sort), ID: decoded.ID, Backward: decoded.Backward}
+
+	switch v := decoded.Value.(type) {
+	case string:
+		if !decoded.Time {
+			c.Value = v
+			break
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, errInvalidCursor
+		}
+		c.Value = t
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			c.Value = n
+		} else if f, err := v.Float64(); err == nil {
+			c.Value = f
+		} else {
+			return nil, errInvalidCursor
+		}
+	case bool:
+		c.Value = v
+	case nil:
+		c.Value = nil
+	default:
+		// Objects and arrays are not column values
+		return nil, errInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,152 @@
+package pagination
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var schemas sync.Map
+
+// Find loads the page of T that params asks for from query, which holds
+// the conditions of the list, and describes it. The associations in
+// preloads are loaded for the items of the page; they must not be preloaded
+// on query, which is also used to count the items:
+//
+//	posts, meta, err := pagination.Find[entity.Post](
+//		r.db.WithContext(ctx).Where("user_id = ?", userID), params, "User", "Images")
+func Find[T any](query *gorm.DB, params Params, preloads ...string) ([]T, Meta, error) {
+	meta := Meta{Limit: params.Limit, Sort: params.Sort.String()}
+	if err := query.Session(&gorm.Session{}).Model(new(T)).Count(&meta.Total).Error; err != nil {
+		return nil, Meta{}, err
+	}
+
+	tx := query.Session(&gorm.Session{})
+	for _, preload := range preloads {
+		tx = tx.Preload(preload)
+	}
+
+	// A backward cursor reads the previous page in reverse order, nearest
+	// items first, and the page is turned around afterwards
+	backward := params.cursor != nil && params.cursor.Backward
+	desc := params.Sort.Desc != backward
+	sortColumn := clause.Column{Table: clause.CurrentTable, Name: params.Sort.Field}
+	idColumn := clause.Column{Table: clause.CurrentTable, Name: "id"}
+	tx = tx.Clauses(clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: sortColumn, Desc: desc},
+		{Column: idColumn, Desc: desc},
+	}})
+
+	sch, err := schema.Parse(new(T), &schemas, query.NamingStrategy)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	if params.cursor != nil {
+		sortField := sch.LookUpField(params.Sort.Field)
+		nullable := sortField != nil && sortField.FieldType.Kind() == reflect.Ptr
+		tx = tx.Where(after(params.cursor, sortColumn, idColumn, desc, nullable))
+	} else {
+		tx = tx.Offset((params.Page - 1) * params.Limit)
+	}
+
+	// One more item than asked for tells whether there is another page
+	var items []T
+	if err := tx.Limit(params.Limit + 1).Find(&items).Error; err != nil {
+		return nil, Meta{}, err
+	}
+	more := len(items) > params.Limit
+	if more {
+		items = items[:params.Limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	hasNext, hasPrev := more, params.Page > 1
+	if params.cursor != nil {
+		hasNext, hasPrev = more || backward, more || !backward
+	}
+	if params.Page > 0 {
+		meta.Page = params.Page
+		meta.TotalPages = int((meta.Total + int64(params.Limit) - 1) / int64(params.Limit))
+	}
+	if len(items) == 0 {
+		return items, meta, nil
+	}
+
+	if hasNext {
+		next, err := cursorAt(query, sch, params.Sort, &items[len(items)-1], false)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		meta.NextCursor = next
+	}
+	if hasPrev {
+		prev, err := cursorAt(query, sch, params.Sort, &items[0], true)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+		meta.PrevCursor = prev
+	}
+	return items, meta, nil
+}
+
+// after returns the condition of the items after c in the order by
+// column and ID, descending when desc is set. Items without a value of a
+// nullable column sort after all others, as NULLs do in Postgres, so they
+// come last in ascending order and first in descending order.
+func after(c *cursor, column, idColumn clause.Column, desc, nullable bool) clause.Expression {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	row := clause.Expr{
+		SQL:  fmt.Sprintf("(?, ?) %s (?, ?)", op),
+		Vars: []interface{}{column, idColumn, c.Value, c.ID},
+	}
+
+	switch {
+	case !nullable || (c.Value != nil && desc):
+		return row
+	case c.Value != nil:
+		return clause.Expr{SQL: "(? OR ? IS NULL)", Vars: []interface{}{row, column}}
+	case desc:
+		return clause.Expr{SQL: "(? IS NOT NULL OR ? < ?)", Vars: []interface{}{column, idColumn, c.ID}}
+	default:
+		return clause.Expr{SQL: "(? IS NULL AND ? > ?)", Vars: []interface{}{column, idColumn, c.ID}}
+	}
+}
+
+// cursorAt returns the cursor after, or before when backward is set, item.
+func cursorAt(query *gorm.DB, sch *schema.Schema, sort Sort, item interface{}, backward bool) (string, error) {
+	sortField, idField := sch.LookUpField(sort.Field), sch.LookUpField("id")
+	if sortField == nil || idField == nil {
+		return "", fmt.Errorf("pagination: %s cannot be sorted by %s", sch.Name, sort.Field)
+	}
+
+	ctx := query.Statement.Context
+	value := reflect.ValueOf(item).Elem()
+	sortValue, _ := sortField.ValueOf(ctx, value)
+	// Nullable columns are cursored by their value, or nil for NULL
+	if v := reflect.ValueOf(sortValue); v.Kind() == reflect.Ptr {
+		sortValue = nil
+		if !v.IsNil() {
+			sortValue = v.Elem().Interface()
+		}
+	}
+	id, _ := idField.ValueOf(ctx, value)
+	idValue, ok := id.(uint)
+	if !ok {
+		return "", fmt.Errorf("pagination: %s has no uint ID", sch.Name)
+	}
+
+	c := &cursor{Sort: sort, Value: sortValue, ID: idValue, Backward: backward}
+	return c.encode(), nil
+}
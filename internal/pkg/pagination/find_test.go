@@ -0,0 +1,204 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/schema"
+)
+
+type widget struct {
+	ID         uint
+	Name       string
+	CreatedAt  time.Time
+	ArchivedAt *time.Time
+}
+
+// fakeDB returns a database that records the SQL of queries instead of
+// running them. Counts return total and other queries return widgets with
+// the IDs in rows.
+func fakeDB(t *testing.T, total int64, rows ...uint) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	var queries []string
+	require.NoError(t, db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		callbacks.BuildQuerySQL(tx)
+		queries = append(queries, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+		switch dest := tx.Statement.Dest.(type) {
+		case *int64:
+			*dest = total
+			tx.RowsAffected = 1
+		case *[]widget:
+			for _, id := range rows {
+				*dest = append(*dest, widget{ID: id, Name: fmt.Sprintf("widget %d", id)})
+			}
+		}
+	}))
+	return db, &queries
+}
+
+func TestFind_Page(t *testing.T) {
+	db, queries := fakeDB(t, 5, 3, 4, 5)
+	params := Params{Page: 2, Limit: 2, Sort: Sort{Field: "name"}}
+
+	widgets, meta, err := Find[widget](db.WithContext(context.Background()).Where("name <> ?", ""), params)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`SELECT count(*) FROM "widgets" WHERE name <> ''`,
+		`SELECT * FROM "widgets" WHERE name <> '' ORDER BY "widgets"."name","widgets"."id" LIMIT 3 OFFSET 2`,
+	}, *queries)
+	assert.Len(t, widgets, 2, "the extra item only tells that there is a next page")
+	assert.Equal(t, int64(5), meta.Total)
+	assert.Equal(t, 2, meta.Page)
+	assert.Equal(t, 3, meta.TotalPages)
+	assert.Equal(t, "name", meta.Sort)
+
+	next, err := decodeCursor(meta.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, &cursor{Sort: params.Sort, Value: "widget 4", ID: 4}, next)
+	prev, err := decodeCursor(meta.PrevCursor)
+	require.NoError(t, err)
+	assert.Equal(t, &cursor{Sort: params.Sort, Value: "widget 3", ID: 3, Backward: true}, prev)
+}
+
+func TestFind_LastPage(t *testing.T) {
+	db, _ := fakeDB(t, 1, 1)
+
+	_, meta, err := Find[widget](db, Params{Page: 1, Limit: 2, Sort: Sort{Field: "id"}})
+	require.NoError(t, err)
+	assert.Empty(t, meta.NextCursor)
+	assert.Empty(t, meta.PrevCursor)
+}
+
+func TestFind_Cursor(t *testing.T) {
+	sort := Sort{Field: "name", Desc: true}
+
+	t.Run("forward", func(t *testing.T) {
+		db, queries := fakeDB(t, 10, 7, 6)
+		params := Params{Limit: 2, Sort: sort, cursor: &cursor{Sort: sort, Value: "widget 8", ID: 8}}
+
+		widgets, meta, err := Find[widget](db, params)
+		require.NoError(t, err)
+
+		assert.Equal(t, `SELECT * FROM "widgets" WHERE ("widgets"."name", "widgets"."id") < ('widget 8', 8) ORDER BY "widgets"."name" DESC,"widgets"."id" DESC LIMIT 3`, (*queries)[1])
+		assert.Equal(t, []uint{7, 6}, ids(widgets))
+		assert.Empty(t, meta.NextCursor, "there is no item after the page")
+		assert.NotEmpty(t, meta.PrevCursor)
+		assert.Zero(t, meta.Page)
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		// The previous page is read nearest first
+		db, queries := fakeDB(t, 10, 5, 4, 3)
+		params := Params{Limit: 2, Sort: sort, cursor: &cursor{Sort: sort, Value: "widget 6", ID: 6, Backward: true}}
+
+		widgets, meta, err := Find[widget](db, params)
+		require.NoError(t, err)
+
+		assert.Equal(t, `SELECT * FROM "widgets" WHERE ("widgets"."name", "widgets"."id") > ('widget 6', 6) ORDER BY "widgets"."name","widgets"."id" LIMIT 3`, (*queries)[1])
+		assert.Equal(t, []uint{4, 5}, ids(widgets))
+		assert.NotEmpty(t, meta.NextCursor)
+		prev, err := decodeCursor(meta.PrevCursor)
+		require.NoError(t, err)
+		assert.Equal(t, &cursor{Sort: sort, Value: "widget 4", ID: 4, Backward: true}, prev)
+	})
+}
+
+func TestFind_NullableSort(t *testing.T) {
+	archivedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	t.Run("cursor at a NULL", func(t *testing.T) {
+		db, _ := fakeDB(t, 5, 3, 4, 5)
+		sort := Sort{Field: "archived_at", Desc: true}
+
+		_, meta, err := Find[widget](db, Params{Page: 1, Limit: 2, Sort: sort})
+		require.NoError(t, err)
+
+		next, err := decodeCursor(meta.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, &cursor{Sort: sort, Value: nil, ID: 4}, next)
+	})
+
+	t.Run("cursor at a value", func(t *testing.T) {
+		db, _ := fakeDB(t, 1, 1)
+		item := &widget{ID: 1, ArchivedAt: &archivedAt}
+		sch, err := schema.Parse(item, &schemas, db.NamingStrategy)
+		require.NoError(t, err)
+
+		encoded, err := cursorAt(db, sch, Sort{Field: "archived_at"}, item, false)
+		require.NoError(t, err)
+		c, err := decodeCursor(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, archivedAt, c.Value)
+	})
+
+	tests := []struct {
+		name   string
+		cursor *cursor
+		where  string
+	}{
+		{
+			"ascending after a value includes the NULLs",
+			&cursor{Sort: Sort{Field: "archived_at"}, Value: archivedAt, ID: 4},
+			`WHERE (("widgets"."archived_at", "widgets"."id") > ('2024-05-01 12:30:00', 4) OR "widgets"."archived_at" IS NULL)`,
+		},
+		{
+			"ascending after a NULL",
+			&cursor{Sort: Sort{Field: "archived_at"}, Value: nil, ID: 4},
+			`WHERE ("widgets"."archived_at" IS NULL AND "widgets"."id" > 4)`,
+		},
+		{
+			"descending after a value excludes the NULLs",
+			&cursor{Sort: Sort{Field: "archived_at", Desc: true}, Value: archivedAt, ID: 4},
+			`WHERE ("widgets"."archived_at", "widgets"."id") < ('2024-05-01 12:30:00', 4)`,
+		},
+		{
+			"descending after a NULL",
+			&cursor{Sort: Sort{Field: "archived_at", Desc: true}, Value: nil, ID: 4},
+			`WHERE ("widgets"."archived_at" IS NOT NULL OR "widgets"."id" < 4)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, queries := fakeDB(t, 10)
+
+			_, _, err := Find[widget](db, Params{Limit: 2, Sort: tt.cursor.Sort, cursor: tt.cursor})
+			require.NoError(t, err)
+			assert.Contains(t, (*queries)[1], tt.where)
+		})
+	}
+}
+
+func ids(widgets []widget) []uint {
+	result := make([]uint, len(widgets))
+	for i, w := range widgets {
+		result[i] = w.ID
+	}
+	return result
+}
+
+func TestFind_Scopes(t *testing.T) {
+	db, queries := fakeDB(t, 1, 1)
+	named := func(db *gorm.DB) *gorm.DB { return db.Where("name = ?", "widget 1") }
+
+	_, _, err := Find[widget](db.Scopes(named), Params{Page: 1, Limit: 2, Sort: Sort{Field: "id"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`SELECT count(*) FROM "widgets" WHERE name = 'widget 1'`,
+		`SELECT * FROM "widgets" WHERE name = 'widget 1' ORDER BY "widgets"."id","widgets"."id" LIMIT 3`,
+	}, *queries)
+}
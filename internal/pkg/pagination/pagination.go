@@ -0,0 +1,169 @@
+// Package pagination pages list endpoints. Clients either ask for numbered
+// pages,
+//
+//	GET /api/posts?page=2&limit=20&sort=-created_at
+//
+// or follow the opaque cursors of the meta block, which page by the sort
+// key instead of an offset and so neither skip nor repeat items when items
+// are added in between:
+//
+//	GET /api/posts?cursor=eyJzIjoiLWNyZWF0ZWRfYXQiLC...
+//
+// Handlers parse the query with Parse and repositories run it with Find.
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go-backend/internal/pkg/apperror"
+)
+
+const (
+	// DefaultLimit is the page size when the request does not ask for one.
+	DefaultLimit = 20
+	// MaxLimit is the largest page size. Larger limits are lowered to it.
+	MaxLimit = 100
+	// MaxPage is the largest page number, which keeps offsets from
+	// overflowing. Deeper pages are reached by following cursors.
+	MaxPage = 10000
+)
+
+// Sort orders a list by a column. Ties are broken by ID, so that the order
+// is total and cursors are stable.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// String returns the sort as it is written in the sort parameter, such as
+// "-created_at" for newest first.
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Options describes how a list can be sorted.
+type Options struct {
+	// Sortable lists the columns that clients may sort by. They are also
+	// the JSON names of the fields.
+	Sortable []string
+	// DefaultSort is the sort when the request does not ask for one, such
+	// as "-created_at".
+	DefaultSort string
+}
+
+// Params is a parsed page request.
+type Params struct {
+	// Page is the requested page, starting at 1. It is 0 when the request
+	// follows a cursor.
+	Page  int
+	Limit int
+	Sort  Sort
+
+	cursor *cursor
+}
+
+// Meta describes a page. Page and TotalPages are only set for numbered
+// pages; the cursors are set when there is a next or previous page.
+type Meta struct {
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	Sort       string `json:"sort"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Parse reads the page, limit, cursor and sort parameters of a list
+// request. page_size is accepted as an alias of limit. Invalid parameters
+// are reported as a validation error.
+func Parse(query url.Values, opts Options) (Params, error) {
+	params := Params{Page: 1, Limit: DefaultLimit}
+	var fields []apperror.FieldError
+
+	limit := query.Get("limit")
+	if limit == "" {
+		limit = query.Get("page_size")
+	}
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		switch {
+		case err != nil:
+			fields = append(fields, apperror.Field("limit", "type", "integer"))
+		case n < 1:
+			fields = append(fields, apperror.Field("limit", "min", "1"))
+		default:
+			params.Limit = min(n, MaxLimit)
+		}
+	}
+
+	if page := query.Get("page"); page != "" {
+		n, err := strconv.Atoi(page)
+		switch {
+		case err != nil:
+			fields = append(fields, apperror.Field("page", "type", "integer"))
+		case n < 1:
+			fields = append(fields, apperror.Field("page", "min", "1"))
+		case n > MaxPage:
+			fields = append(fields, apperror.Field("page", "max", strconv.Itoa(MaxPage)))
+		default:
+			params.Page = n
+		}
+	}
+
+	params.Sort = parseSort(opts.DefaultSort)
+	if value := query.Get("sort"); value != "" {
+		if sort := parseSort(value); opts.sortable(sort.Field) {
+			params.Sort = sort
+		} else {
+			fields = append(fields, apperror.Field("sort", "oneof", strings.Join(opts.Sortable, " ")))
+		}
+	}
+
+	if value := query.Get("cursor"); value != "" {
+		c, err := decodeCursor(value)
+		switch {
+		case err != nil || !opts.sortable(c.Sort.Field):
+			fields = append(fields, apperror.Field("cursor", "invalid", ""))
+		case query.Get("page") != "":
+			fields = append(fields, apperror.Field("page", "excluded_with", "cursor"))
+		case query.Get("sort") != "" && params.Sort != c.Sort:
+			// The cursor's position only makes sense in its own order
+			fields = append(fields, apperror.Field("sort", "excluded_with", "cursor"))
+		default:
+			params.Page = 0
+			params.Sort = c.Sort
+			params.cursor = c
+		}
+	}
+
+	if len(fields) > 0 {
+		return Params{}, apperror.Validation(fields...)
+	}
+	return params, nil
+}
+
+// FirstPage returns the largest first page in the default sort of opts,
+// for responses that embed a list rather than page it.
+func FirstPage(opts Options) Params {
+	return Params{Page: 1, Limit: MaxLimit, Sort: parseSort(opts.DefaultSort)}
+}
+
+// parseSort parses a sort parameter, such as "-created_at".
+func parseSort(value string) Sort {
+	return Sort{Field: strings.TrimPrefix(value, "-"), Desc: strings.HasPrefix(value, "-")}
+}
+
+func (o Options) sortable(field string) bool {
+	for _, sortable := range o.Sortable {
+		if field == sortable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package pagination
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/pkg/apperror"
+)
+
+var testOptions = Options{
+	Sortable:    []string{"id", "name", "created_at"},
+	DefaultSort: "-created_at",
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  Params
+	}{
+		{"defaults", "", Params{Page: 1, Limit: DefaultLimit, Sort: Sort{Field: "created_at", Desc: true}}},
+		{"page and limit", "page=3&limit=5", Params{Page: 3, Limit: 5, Sort: Sort{Field: "created_at", Desc: true}}},
+		{"page_size alias", "page_size=7", Params{Page: 1, Limit: 7, Sort: Sort{Field: "created_at", Desc: true}}},
+		{"limit is capped", "limit=1000", Params{Page: 1, Limit: MaxLimit, Sort: Sort{Field: "created_at", Desc: true}}},
+		{"ascending sort", "sort=name", Params{Page: 1, Limit: DefaultLimit, Sort: Sort{Field: "name"}}},
+		{"descending sort", "sort=-id", Params{Page: 1, Limit: DefaultLimit, Sort: Sort{Field: "id", Desc: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			params, err := Parse(query, testOptions)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, params)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	query := url.Values{"page": {"0"}, "limit": {"ten"}, "sort": {"password"}}
+
+	_, err := Parse(query, testOptions)
+
+	appErr := apperror.From(err)
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+	assert.Equal(t, []apperror.FieldError{
+		apperror.Field("limit", "type", "integer"),
+		apperror.Field("page", "min", "1"),
+		apperror.Field("sort", "oneof", "id name created_at"),
+	}, appErr.Fields)
+}
+
+func TestParse_PageOutOfRange(t *testing.T) {
+	for _, page := range []string{"10001", "9223372036854775807"} {
+		_, err := Parse(url.Values{"page": {page}}, testOptions)
+
+		appErr := apperror.From(err)
+		assert.Equal(t, apperror.KindValidation, appErr.Kind, page)
+		assert.Equal(t, []apperror.FieldError{apperror.Field("page", "max", "10000")}, appErr.Fields, page)
+	}
+}
+
+func TestParse_Cursor(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	c := &cursor{Sort: Sort{Field: "created_at", Desc: true}, Value: createdAt, ID: 42}
+	encoded := c.encode()
+
+	params, err := Parse(url.Values{"cursor": {encoded}, "limit": {"10"}}, testOptions)
+	require.NoError(t, err)
+	assert.Equal(t, 0, params.Page)
+	assert.Equal(t, 10, params.Limit)
+	assert.Equal(t, c.Sort, params.Sort)
+	assert.Equal(t, c, params.cursor)
+
+	_, err = Parse(url.Values{"cursor": {encoded}, "sort": {"-created_at"}}, testOptions)
+	assert.NoError(t, err, "the cursor's own sort may be repeated")
+
+	tests := []struct {
+		name  string
+		query url.Values
+		field apperror.FieldError
+	}{
+		{"garbage", url.Values{"cursor": {"not-a-cursor"}}, apperror.Field("cursor", "invalid", "")},
+		{"unsortable field", url.Values{"cursor": {(&cursor{Sort: Sort{Field: "password"}, Value: "x", ID: 1}).encode()}}, apperror.Field("cursor", "invalid", "")},
+		{"with page", url.Values{"cursor": {encoded}, "page": {"2"}}, apperror.Field("page", "excluded_with", "cursor")},
+		{"with another sort", url.Values{"cursor": {encoded}, "sort": {"name"}}, apperror.Field("sort", "excluded_with", "cursor")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query, testOptions)
+			assert.Equal(t, []apperror.FieldError{tt.field}, apperror.From(err).Fields)
+		})
+	}
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	for _, value := range []interface{}{"Ada", int64(7), 2.5, time.Date(2024, 5, 1, 12, 30, 0, 123, time.UTC)} {
+		c := &cursor{Sort: Sort{Field: "name"}, Value: value, ID: 3, Backward: true}
+
+		decoded, err := decodeCursor(c.encode())
+		require.NoError(t, err)
+		assert.Equal(t, c, decoded)
+	}
+}
@@ -0,0 +1,67 @@
+package publishing
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go-backend/internal/infrastructure/tracing"
+	"gorm.io/gorm"
+)
+
+// Publisher publishes scheduled content once its publication time has come.
+type Publisher struct {
+	db     *gorm.DB
+	models []interface{}
+	now    func() time.Time
+}
+
+// NewPublisher returns a publisher of the scheduled rows of the tables of
+// models, such as &entity.Post{}, which embed State.
+func NewPublisher(db *gorm.DB, models ...interface{}) *Publisher {
+	return &Publisher{db: db, models: models, now: time.Now}
+}
+
+// PublishDue publishes the content that is due and returns how many rows
+// were published.
+func (p *Publisher) PublishDue(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "Publisher.PublishDue")
+	defer span.End()
+
+	now := p.now()
+	var published int64
+	for _, model := range p.models {
+		result := p.db.WithContext(ctx).Model(model).
+			Where("status = ? AND published_at <= ?", Scheduled, now).
+			Update("status", Published)
+		if result.Error != nil {
+			return published, result.Error
+		}
+		published += result.RowsAffected
+	}
+	return published, nil
+}
+
+// Run publishes the content that is due every interval until ctx is done.
+func (p *Publisher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := p.PublishDue(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("Failed to publish scheduled content", "error", err)
+				}
+				continue
+			}
+			if published > 0 {
+				slog.Info("Published scheduled content", "count", published)
+			}
+		}
+	}
+}
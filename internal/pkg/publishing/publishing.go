@@ -0,0 +1,87 @@
+// Package publishing is the draft and publish workflow of content, such as
+// posts and projects. Content starts as a draft, which only its owner sees,
+// and is published either at once or at a scheduled time:
+//
+//	draft ──▶ scheduled ──▶ published ──▶ archived
+//	  ▲___________|______________|____________|
+//
+// Any status can be changed to any other, and only published content is
+// shown publicly. Entities embed State, and the Publisher publishes
+// scheduled content when it is due.
+package publishing
+
+import (
+	"time"
+
+	"go-backend/internal/pkg/apperror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Statuses of content.
+const (
+	Draft     = "draft"
+	Scheduled = "scheduled"
+	Published = "published"
+	Archived  = "archived"
+)
+
+// Statuses lists every status.
+var Statuses = []string{Draft, Scheduled, Published, Archived}
+
+// State is the publishing state of content. Entities embed it, which adds
+// the status and published_at columns.
+type State struct {
+	Status string `json:"status" gorm:"not null;default:draft"`
+	// PublishedAt is when the content was published, or is to be published
+	// when it is scheduled.
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// IsPublished reports whether the content is shown publicly.
+func (s State) IsPublished() bool {
+	return s.Status == Published
+}
+
+// Change moves the state to status at now. Scheduling needs publishAt,
+// which must be in the future. Publishing uses publishAt, which must not
+// be in the future, or keeps the date of an earlier publication, or else
+// uses now. Going back to draft forgets the date.
+func (s *State) Change(status string, publishAt *time.Time, now time.Time) error {
+	switch status {
+	case Draft:
+		s.PublishedAt = nil
+	case Scheduled:
+		if publishAt == nil {
+			return apperror.Validation(apperror.Field("published_at", "required", ""))
+		}
+		if !publishAt.After(now) {
+			return apperror.Validation(apperror.Field("published_at", "future", ""))
+		}
+		at := publishAt.UTC()
+		s.PublishedAt = &at
+	case Published:
+		switch {
+		case publishAt != nil:
+			if publishAt.After(now) {
+				return apperror.Validation(apperror.Field("published_at", "past", ""))
+			}
+			at := publishAt.UTC()
+			s.PublishedAt = &at
+		case s.PublishedAt == nil || s.PublishedAt.After(now):
+			at := now.UTC()
+			s.PublishedAt = &at
+		}
+	case Archived:
+	default:
+		return apperror.Validation(apperror.Field("status", "oneof", "draft scheduled published archived"))
+	}
+	s.Status = status
+	return nil
+}
+
+// Public scopes a query to published content, as in
+// db.Scopes(publishing.Public).
+func Public(db *gorm.DB) *gorm.DB {
+	return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "status"}, Value: Published})
+}
@@ -0,0 +1,173 @@
+package publishing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-backend/internal/pkg/apperror"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+)
+
+var (
+	now       = time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	yesterday = now.Add(-24 * time.Hour)
+	tomorrow  = now.Add(24 * time.Hour)
+)
+
+func TestState_Change(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     State
+		status    string
+		publishAt *time.Time
+		expected  State
+	}{
+		{
+			name:     "draft forgets the date",
+			state:    State{Status: Scheduled, PublishedAt: &tomorrow},
+			status:   Draft,
+			expected: State{Status: Draft},
+		},
+		{
+			name:      "schedule",
+			state:     State{Status: Draft},
+			status:    Scheduled,
+			publishAt: &tomorrow,
+			expected:  State{Status: Scheduled, PublishedAt: &tomorrow},
+		},
+		{
+			name:     "publish now",
+			state:    State{Status: Draft},
+			status:   Published,
+			expected: State{Status: Published, PublishedAt: &now},
+		},
+		{
+			name:      "publish backdated",
+			state:     State{Status: Draft},
+			status:    Published,
+			publishAt: &yesterday,
+			expected:  State{Status: Published, PublishedAt: &yesterday},
+		},
+		{
+			name:     "publish a scheduled post early",
+			state:    State{Status: Scheduled, PublishedAt: &tomorrow},
+			status:   Published,
+			expected: State{Status: Published, PublishedAt: &now},
+		},
+		{
+			name:     "republish keeps the first date",
+			state:    State{Status: Archived, PublishedAt: &yesterday},
+			status:   Published,
+			expected: State{Status: Published, PublishedAt: &yesterday},
+		},
+		{
+			name:     "archive keeps the date",
+			state:    State{Status: Published, PublishedAt: &yesterday},
+			status:   Archived,
+			expected: State{Status: Archived, PublishedAt: &yesterday},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.state
+			require.NoError(t, state.Change(tt.status, tt.publishAt, now))
+			assert.Equal(t, tt.expected, state)
+			assert.Equal(t, tt.status == Published, state.IsPublished())
+		})
+	}
+}
+
+func TestState_Change_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		publishAt *time.Time
+		field     string
+		rule      string
+	}{
+		{name: "schedule without a date", status: Scheduled, field: "published_at", rule: "required"},
+		{name: "schedule in the past", status: Scheduled, publishAt: &yesterday, field: "published_at", rule: "future"},
+		{name: "schedule now", status: Scheduled, publishAt: &now, field: "published_at", rule: "future"},
+		{name: "publish in the future", status: Published, publishAt: &tomorrow, field: "published_at", rule: "past"},
+		{name: "unknown status", status: "deleted", field: "status", rule: "oneof"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := State{Status: Draft}
+			err := state.Change(tt.status, tt.publishAt, now)
+
+			appErr := apperror.From(err)
+			assert.Equal(t, apperror.KindValidation, appErr.Kind)
+			require.Len(t, appErr.Fields, 1)
+			assert.Equal(t, tt.field, appErr.Fields[0].Field)
+			assert.Equal(t, tt.rule, appErr.Fields[0].Rule)
+			assert.Equal(t, State{Status: Draft}, state, "a failed change leaves the state alone")
+		})
+	}
+}
+
+type article struct {
+	ID        uint
+	DeletedAt gorm.DeletedAt
+	State
+}
+
+// fakeDB returns a database that records the SQL of queries and updates
+// instead of running them. Updates affect one row.
+func fakeDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	var statements []string
+	record := func(tx *gorm.DB) {
+		statements = append(statements, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	}
+	require.NoError(t, db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		callbacks.BuildQuerySQL(tx)
+		record(tx)
+	}))
+	require.NoError(t, db.Callback().Update().Replace("gorm:update", func(tx *gorm.DB) {
+		callbacks.Update(&callbacks.Config{})(tx)
+		record(tx)
+		tx.RowsAffected = 1
+	}))
+	return db, &statements
+}
+
+func TestPublic(t *testing.T) {
+	db, statements := fakeDB(t)
+
+	var articles []article
+	require.NoError(t, db.Scopes(Public).Where("id > ?", 1).Find(&articles).Error)
+
+	assert.Equal(t, []string{
+		`SELECT * FROM "articles" WHERE id > 1 AND "articles"."status" = 'published' AND "articles"."deleted_at" IS NULL`,
+	}, *statements)
+}
+
+func TestPublisher_PublishDue(t *testing.T) {
+	db, statements := fakeDB(t)
+	publisher := NewPublisher(db, &article{}, &article{})
+	publisher.now = func() time.Time { return now }
+
+	published, err := publisher.PublishDue(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), published)
+	require.Len(t, *statements, 2)
+	assert.Equal(t,
+		`UPDATE "articles" SET "status"='published' WHERE (status = 'scheduled' AND published_at <= '2026-10-18 09:00:00') AND "articles"."deleted_at" IS NULL`,
+		(*statements)[0])
+}
@@ -1,35 +1,103 @@
+// Package response writes the JSON envelope shared by every endpoint:
+//
+//	{"status": 404, "message": "post not found", "error": "not_found"}
+//
+// Successful responses carry their payload in "data", and lists the page
+// they are on in "meta". Error responses carry the error code of the
+// apperror.Kind in "error" and, for validation errors, the invalid fields in
+// "details". Clients that accept application/problem+json get errors as
+// RFC 7807 problem details instead.
 package response
 
-// Response is the standard API response structure
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+	"go-backend/internal/pkg/apperror"
+	"go-backend/internal/pkg/pagination"
+	"go-backend/internal/pkg/validation"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
 type Response struct {
-	Status  string      `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+	Status  int                   `json:"status"`
+	Message string                `json:"message"`
+	Data    interface{}           `json:"data,omitempty"`
+	Meta    *pagination.Meta      `json:"meta,omitempty"`
+	Error   string                `json:"error,omitempty"`
+	Details []apperror.FieldError `json:"details,omitempty"`
 }
 
-// SuccessResponse creates a success response with data
-func SuccessResponse(message string, data interface{}) Response {
-	return Response{
-		Status:  "success",
-		Message: message,
-		Data:    data,
-	}
+// Problem is an RFC 7807 problem details object. Code and Errors are
+// extension members with the error code and the invalid fields.
+type Problem struct {
+	Type     string                `json:"type"`
+	Title    string                `json:"title"`
+	Status   int                   `json:"status"`
+	Detail   string                `json:"detail,omitempty"`
+	Instance string                `json:"instance,omitempty"`
+	Code     string                `json:"code"`
+	Errors   []apperror.FieldError `json:"errors,omitempty"`
 }
 
-// ErrorResponse creates an error response
-func ErrorResponse(message string) Response {
-	return Response{
-		Status:  "error",
-		Message: message,
-		Data:    nil,
-	}
+// New returns a successful response.
+func New(status int, message string, data interface{}) Response {
+	return Response{Status: status, Message: message, Data: data}
+}
+
+// NewPage returns a successful response with a page of a list, described
+// by meta.
+func NewPage(status int, message string, data interface{}, meta pagination.Meta) Response {
+	return Response{Status: status, Message: message, Data: data, Meta: &meta}
 }
 
-// EmptySuccessResponse creates a success response without data
-func EmptySuccessResponse(message string) Response {
-	return Response{
-		Status:  "success",
-		Message: message,
-		Data:    nil,
+// Error writes err as an error response. Errors that are not an
+// *apperror.Error are reported as internal errors without their message.
+// Messages are in the language the Accept-Language header prefers, where
+// they can be translated; see the validation package.
+//
+// Handlers do not call Error themselves: they record the error with
+// c.Error and middleware.ErrorMiddleware writes it.
+func Error(c *gin.Context, err error) {
+	lang := validation.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	appErr := apperror.From(err).Localize(lang)
+	c.Header("Content-Language", string(lang))
+	status := appErr.Status()
+	if wantsProblem(c.Request) {
+		c.Header("Content-Type", ProblemContentType)
+		c.Render(status, render.JSON{Data: Problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   appErr.Message,
+			Instance: c.Request.URL.Path,
+			Code:     string(appErr.Kind),
+			Errors:   appErr.Fields,
+		}})
+		return
 	}
+
+	c.JSON(status, Response{
+		Status:  status,
+		Message: appErr.Message,
+		Error:   string(appErr.Kind),
+		Details: appErr.Fields,
+	})
+}
+
+// Abort records err on c, so that the request log includes it, writes it
+// like Error and stops the handler chain. Middleware that rejects requests
+// uses it.
+func Abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	Error(c, err)
+	c.Abort()
+}
+
+func wantsProblem(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), ProblemContentType)
 }
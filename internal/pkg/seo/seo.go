@@ -0,0 +1,98 @@
+// Package seo holds the metadata that pages of posts, projects and profiles
+// are described by to search engines and link previews. Owners may set each
+// field; those left empty are derived from the content, so that responses
+// always carry complete metadata.
+package seo
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DescriptionLength is the length, in characters, of derived descriptions.
+const DescriptionLength = 160
+
+// Fields is the metadata of a page. Entities embed it, which adds the
+// meta_title, meta_description, canonical_url and og_image columns, and
+// requests take it as their seo object.
+type Fields struct {
+	MetaTitle       string `json:"meta_title" binding:"max=255"`
+	MetaDescription string `json:"meta_description" binding:"max=500"`
+	// CanonicalURL is the preferred URL of the page, such as the original
+	// of a post that was published elsewhere first.
+	CanonicalURL string `json:"canonical_url" binding:"omitempty,httpurl,max=2048"`
+	// OGImage is the image of link previews, after the Open Graph og:image
+	// property.
+	OGImage string `json:"og_image" gorm:"column:og_image" binding:"omitempty,httpurl,max=2048"`
+}
+
+// WithDefaults returns f with its empty fields taken from defaults.
+func (f Fields) WithDefaults(defaults Fields) Fields {
+	if f.MetaTitle == "" {
+		f.MetaTitle = defaults.MetaTitle
+	}
+	if f.MetaDescription == "" {
+		f.MetaDescription = defaults.MetaDescription
+	}
+	if f.CanonicalURL == "" {
+		f.CanonicalURL = defaults.CanonicalURL
+	}
+	if f.OGImage == "" {
+		f.OGImage = defaults.OGImage
+	}
+	return f
+}
+
+// Description returns the start of text as a description: its whitespace
+// collapsed and, when it is longer than DescriptionLength, cut at a word
+// and ended with an ellipsis.
+func Description(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= DescriptionLength {
+		return text
+	}
+
+	runes := []rune(text)[:DescriptionLength]
+	cut := string(runes)
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:-") + "…"
+}
+
+var (
+	siteMu  sync.RWMutex
+	siteURL string
+)
+
+// SetSiteURL sets the URL of the site that pages are published on, such as
+// https://example.com, which canonical URLs are made from.
+func SetSiteURL(u string) {
+	siteMu.Lock()
+	defer siteMu.Unlock()
+	siteURL = strings.TrimRight(u, "/")
+}
+
+// CanonicalURL returns the URL of the page at the path of segments on the
+// site, such as CanonicalURL("posts", "hello-world"). It returns "" when no
+// site URL is set or a segment is empty.
+func CanonicalURL(segments ...string) string {
+	siteMu.RLock()
+	defer siteMu.RUnlock()
+	if siteURL == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(siteURL)
+	for _, segment := range segments {
+		if segment == "" {
+			return ""
+		}
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
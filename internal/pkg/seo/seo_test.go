@@ -0,0 +1,46 @@
+package seo
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields_WithDefaults(t *testing.T) {
+	set := Fields{MetaTitle: "Custom title", OGImage: "https://cdn.example.com/og.png"}
+	defaults := Fields{
+		MetaTitle:       "Post title",
+		MetaDescription: "Post content",
+		CanonicalURL:    "https://example.com/posts/post-title",
+		OGImage:         "https://cdn.example.com/first.png",
+	}
+
+	assert.Equal(t, Fields{
+		MetaTitle:       "Custom title",
+		MetaDescription: "Post content",
+		CanonicalURL:    "https://example.com/posts/post-title",
+		OGImage:         "https://cdn.example.com/og.png",
+	}, set.WithDefaults(defaults))
+}
+
+func TestDescription(t *testing.T) {
+	assert.Equal(t, "Short text on two lines", Description("  Short text\n on   two lines "))
+
+	long := strings.Repeat("lorem ipsum, ", 20)
+	d := Description(long)
+	assert.LessOrEqual(t, utf8.RuneCountInString(d), DescriptionLength+1)
+	assert.True(t, strings.HasSuffix(d, "ipsum…"), d)
+}
+
+func TestCanonicalURL(t *testing.T) {
+	t.Cleanup(func() { SetSiteURL("") })
+
+	assert.Empty(t, CanonicalURL("posts", "hello"), "no site URL")
+
+	SetSiteURL("https://example.com/")
+	assert.Equal(t, "https://example.com/posts/hello", CanonicalURL("posts", "hello"))
+	assert.Equal(t, "https://example.com/@jane", CanonicalURL("@jane"))
+	assert.Empty(t, CanonicalURL("posts", ""), "empty segment")
+}
@@ -0,0 +1,78 @@
+// Package slug makes the slugs that identify posts, projects and profiles in
+// URLs, such as "hello-world" for a post titled "Hello, World!". Slugs are
+// lowercase ASCII letters and digits separated by single hyphens.
+package slug
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength is the length of the longest slug that Make returns.
+const MaxLength = 80
+
+var pattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Valid reports whether s is a slug.
+func Valid(s string) bool {
+	return pattern.MatchString(s)
+}
+
+// Make returns the slug of text. Accents are dropped, other characters than
+// letters and digits become hyphens, and long slugs are cut at a hyphen. It
+// returns "" when text has no letters or digits that fit in a slug.
+func Make(text string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(norm.NFKD.String(text)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accents, separated from their letters by the normalization
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		default:
+			hyphen = true
+		}
+	}
+
+	return Cut(b.String(), MaxLength)
+}
+
+// Cut returns the slug s cut to at most n characters, at a hyphen when it has
+// one early enough.
+func Cut(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n+1]
+	if i := strings.LastIndexByte(s, '-'); i > 0 {
+		return s[:i]
+	}
+	return s[:n]
+}
+
+// Unique returns base, or else base with the lowest suffix -2, -3, ... that is
+// not taken. Base is cut to make room for the suffix, so that slugs are at
+// most n characters.
+func Unique(base string, n int, taken func(slug string) (bool, error)) (string, error) {
+	candidate := Cut(base, n)
+	for i := 2; ; i++ {
+		ok, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return candidate, nil
+		}
+		suffix := "-" + strconv.Itoa(i)
+		candidate = Cut(base, n-len(suffix)) + suffix
+	}
+}
@@ -0,0 +1,92 @@
+package slug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  Go 1.22: what's new?  ", "go-1-22-what-s-new"},
+		{"Café crème à Jakarta", "cafe-creme-a-jakarta"},
+		{"ﬁle №5", "file-no5"},
+		{"snake_case--and  spaces", "snake-case-and-spaces"},
+		{"日本語", ""},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			s := Make(tt.text)
+			assert.Equal(t, tt.expected, s)
+			if s != "" {
+				assert.True(t, Valid(s))
+			}
+		})
+	}
+}
+
+func TestMake_Long(t *testing.T) {
+	words := strings.Repeat("word ", 30)
+	s := Make(words)
+	assert.LessOrEqual(t, len(s), MaxLength)
+	assert.True(t, strings.HasSuffix(s, "word"), "cut at a hyphen")
+
+	s = Make(strings.Repeat("a", 100))
+	assert.Equal(t, strings.Repeat("a", MaxLength), s)
+}
+
+func TestCut(t *testing.T) {
+	assert.Equal(t, "short", Cut("short", 10))
+	assert.Equal(t, "jane-doe", Cut("jane-doe-smith", 10))
+	assert.Equal(t, "janedoesmi", Cut("janedoesmith", 10), "no hyphen to cut at")
+}
+
+func TestValid(t *testing.T) {
+	for _, s := range []string{"a", "hello-world", "go-1-22"} {
+		assert.True(t, Valid(s), s)
+	}
+	for _, s := range []string{"", "-a", "a-", "a--b", "Hello", "a_b", "a b", "café"} {
+		assert.False(t, Valid(s), s)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	taken := map[string]bool{"hello": true, "hello-2": true}
+	s, err := Unique("hello", MaxLength, func(slug string) (bool, error) { return taken[slug], nil })
+	require.NoError(t, err)
+	assert.Equal(t, "hello-3", s)
+
+	s, err = Unique("fresh", MaxLength, func(slug string) (bool, error) { return taken[slug], nil })
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", s)
+
+	_, err = Unique("hello", MaxLength, func(string) (bool, error) { return false, errors.New("db down") })
+	assert.EqualError(t, err, "db down")
+}
+
+func TestUnique_Long(t *testing.T) {
+	base := strings.Repeat("a", MaxLength)
+	s, err := Unique(base, MaxLength, func(slug string) (bool, error) { return slug == base, nil })
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", MaxLength-2)+"-2", s)
+
+	words := Make(strings.Repeat("word ", 30))
+	s, err = Unique(words, MaxLength, func(slug string) (bool, error) { return slug == words, nil })
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(s), MaxLength)
+	assert.True(t, strings.HasSuffix(s, "word-2"), "cut at a hyphen")
+	assert.True(t, Valid(s))
+
+	s, err = Unique("jane-doe-smith", 10, func(string) (bool, error) { return false, nil })
+	require.NoError(t, err)
+	assert.Equal(t, "jane-doe", s, "base is cut to n")
+}
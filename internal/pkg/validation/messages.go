@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language is a language that messages are available in.
+type Language string
+
+const (
+	English    Language = "en"
+	Indonesian Language = "id"
+)
+
+// messages holds the message for each rule, and for the summaries of request
+// errors, by language. {param} is replaced by the parameter of the rule.
+var messages = map[Language]map[string]string{
+	English: {
+		"validation_failed": "request validation failed",
+		"invalid_json":      "request body is not valid JSON",
+		"empty_body":        "request body is empty",
+		"invalid_request":   "invalid request",
+
+		"required":     "is required",
+		"email":        "must be a valid email address",
+		"url":          "must be a valid URL",
+		"httpurl":      "must be an http or https URL",
+		"slug":         "must be lowercase letters and digits separated by hyphens",
+		"oneof":        "must be one of: {param}",
+		"notbefore":    "must not be before {param}",
+		"type":         "must be a {param}",
+		"min_length":   "must be at least {param} characters long",
+		"max_length":   "must be at most {param} characters long",
+		"exact_length": "must be exactly {param} characters long",
+		"min_items":    "must contain at least {param} items",
+		"max_items":    "must contain at most {param} items",
+		"exact_items":  "must contain exactly {param} items",
+		"min":          "must be at least {param}",
+		"max":          "must be at most {param}",
+		"len":          "must be exactly {param}",
+		"invalid":      "is invalid",
+
+		"excluded_with": "cannot be used together with {param}",
+		"future":        "must be in the future",
+		"past":          "must not be in the future",
+	},
+	Indonesian: {
+		"validation_failed": "validasi permintaan gagal",
+		"invalid_json":      "isi permintaan bukan JSON yang valid",
+		"empty_body":        "isi permintaan kosong",
+		"invalid_request":   "permintaan tidak valid",
+
+		"required":     "wajib diisi",
+		"email":        "harus berupa alamat email yang valid",
+		"url":          "harus berupa URL yang valid",
+		"httpurl":      "harus berupa URL http atau https",
+		"slug":         "harus berupa huruf kecil dan angka yang dipisahkan tanda hubung",
+		"oneof":        "harus salah satu dari: {param}",
+		"notbefore":    "tidak boleh sebelum {param}",
+		"type":         "harus bertipe {param}",
+		"min_length":   "minimal {param} karakter",
+		"max_length":   "maksimal {param} karakter",
+		"exact_length": "harus tepat {param} karakter",
+		"min_items":    "harus berisi minimal {param} item",
+		"max_items":    "harus berisi maksimal {param} item",
+		"exact_items":  "harus berisi tepat {param} item",
+		"min":          "minimal {param}",
+		"max":          "maksimal {param}",
+		"len":          "harus tepat {param}",
+		"invalid":      "tidak valid",
+
+		"excluded_with": "tidak boleh digunakan bersama {param}",
+		"future":        "harus di masa depan",
+		"past":          "tidak boleh di masa depan",
+	},
+}
+
+// Message returns the message for key, a rule or a summary, in lang. Unknown
+// rules get a generic message.
+func Message(lang Language, key, param string) string {
+	catalog, ok := messages[lang]
+	if !ok {
+		catalog = messages[English]
+	}
+	message, ok := catalog[key]
+	if !ok {
+		message = catalog["invalid"]
+	}
+	if key == "oneof" {
+		param = strings.ReplaceAll(param, " ", ", ")
+	}
+	return strings.ReplaceAll(message, "{param}", param)
+}
+
+// ParseAcceptLanguage returns the language preferred by an Accept-Language
+// header, such as "id-ID,id;q=0.9,en;q=0.8", out of the languages that
+// messages are available in. It defaults to English.
+func ParseAcceptLanguage(header string) Language {
+	type candidate struct {
+		lang Language
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		// "in" is the deprecated code for Indonesian
+		if primary == "in" {
+			primary = string(Indonesian)
+		}
+		if _, ok := messages[Language(primary)]; ok && q > 0 {
+			candidates = append(candidates, candidate{Language(primary), q})
+		}
+	}
+
+	// Stable, so that equally preferred languages keep the client's order
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	if len(candidates) == 0 {
+		return English
+	}
+	return candidates[0].lang
+}
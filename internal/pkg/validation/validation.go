@@ -0,0 +1,164 @@
+// Package validation configures the validator that gin uses to bind requests
+// and describes its errors. Besides the built-in rules, requests can use
+//
+//	httpurl            an absolute http or https URL
+//	slug               lowercase letters and digits separated by single
+//	                   hyphens, as made by the slug package
+//	notbefore=<field>  a date that is not before the date in the named sibling
+//	                   field, given by its JSON name
+//
+// and the enums added with RegisterEnum. Failed rules are reported as the
+// rules and messages listed in messages.go, in English or Indonesian.
+package validation
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"go-backend/internal/pkg/slug"
+)
+
+var (
+	enumsMu sync.RWMutex
+	enums   = map[string][]string{}
+)
+
+// Register names the fields of v by their JSON names, as clients know them,
+// and adds the custom rules.
+func Register(v *validator.Validate) {
+	v.RegisterTagNameFunc(jsonName)
+	_ = v.RegisterValidation("httpurl", isHTTPURL)
+	_ = v.RegisterValidation("notbefore", isNotBefore)
+	_ = v.RegisterValidation("slug", isSlug)
+}
+
+// Setup registers the rules with gin's validator, which ShouldBind and its
+// variants use. It is called once while the router is built; tests that bind
+// requests without the router call it themselves.
+func Setup() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		Register(v)
+	}
+}
+
+// RegisterEnum adds a rule named tag to gin's validator that accepts one of
+// values. Modules register the enums of their requests when they are set up:
+//
+//	validation.RegisterEnum("platform", entity.Platforms...)
+func RegisterEnum(tag string, values ...string) {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	enumsMu.Lock()
+	enums[tag] = values
+	enumsMu.Unlock()
+
+	_ = v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		for _, allowed := range values {
+			if value == allowed {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FieldName returns the path of the field below the request struct, such as
+// "links[0].url".
+func FieldName(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+// Rule returns the rule that fe failed and its parameter, as reported to
+// clients. Length rules are told apart by what they measure: max on a string
+// is max_length, on a slice max_items and on a number max. Enums are reported
+// as oneof with the allowed values.
+func Rule(fe validator.FieldError) (rule, param string) {
+	switch tag := fe.Tag(); tag {
+	case "min", "max", "len":
+		switch fe.Kind() {
+		case reflect.String:
+			return lengthRules[tag] + "_length", fe.Param()
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return lengthRules[tag] + "_items", fe.Param()
+		default:
+			return tag, fe.Param()
+		}
+	default:
+		enumsMu.RLock()
+		values, ok := enums[tag]
+		enumsMu.RUnlock()
+		if ok {
+			return "oneof", strings.Join(values, " ")
+		}
+		return tag, fe.Param()
+	}
+}
+
+var lengthRules = map[string]string{"min": "min", "max": "max", "len": "exact"}
+
+func jsonName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func isHTTPURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func isSlug(fl validator.FieldLevel) bool {
+	return slug.Valid(fl.Field().String())
+}
+
+// isNotBefore compares time.Time and *time.Time fields. Missing dates are not
+// compared; required covers those.
+func isNotBefore(fl validator.FieldLevel) bool {
+	end, ok := timeOf(fl.Field())
+	if !ok {
+		return true
+	}
+
+	parent := fl.Parent()
+	if parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+	for i := 0; i < parent.NumField(); i++ {
+		if jsonName(parent.Type().Field(i)) != fl.Param() {
+			continue
+		}
+		start, ok := timeOf(parent.Field(i))
+		return !ok || !end.Before(start)
+	}
+	return true
+}
+
+func timeOf(v reflect.Value) (time.Time, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return time.Time{}, false
+		}
+		v = v.Elem()
+	}
+	t, ok := v.Interface().(time.Time)
+	return t, ok && !t.IsZero()
+}
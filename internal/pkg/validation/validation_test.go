@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Title     string     `json:"title" binding:"max=5"`
+	Tags      []string   `json:"tags" binding:"max=2,dive,max=3"`
+	Count     int        `json:"count" binding:"max=3"`
+	Website   string     `json:"website" binding:"omitempty,httpurl"`
+	Slug      string     `json:"slug" binding:"omitempty,slug"`
+	StartDate time.Time  `json:"start_date"`
+	EndDate   *time.Time `json:"end_date" binding:"omitempty,notbefore=start_date"`
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	Register(v)
+	return v
+}
+
+func failures(t *testing.T, v *validator.Validate, req testRequest) map[string][2]string {
+	t.Helper()
+	err := v.Struct(req)
+	if err == nil {
+		return nil
+	}
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+
+	result := map[string][2]string{}
+	for _, fe := range errs {
+		rule, param := Rule(fe)
+		result[FieldName(fe)] = [2]string{rule, param}
+	}
+	return result
+}
+
+func TestRule(t *testing.T) {
+	v := newValidator()
+
+	got := failures(t, v, testRequest{
+		Title: "too long",
+		Tags:  []string{"go", "rust", "c"},
+		Count: 4,
+	})
+
+	assert.Equal(t, map[string][2]string{
+		"title": {"max_length", "5"},
+		"tags":  {"max_items", "2"},
+		"count": {"max", "3"},
+	}, got)
+
+	assert.Equal(t, map[string][2]string{"tags[1]": {"max_length", "3"}},
+		failures(t, v, testRequest{Tags: []string{"go", "rust"}}))
+}
+
+func TestHTTPURL(t *testing.T) {
+	v := newValidator()
+
+	for _, website := range []string{"https://example.com", "http://example.com/path?q=1"} {
+		assert.Nil(t, failures(t, v, testRequest{Website: website}), website)
+	}
+	for _, website := range []string{"example.com", "ftp://example.com", "javascript:alert(1)", "https://"} {
+		assert.Equal(t, map[string][2]string{"website": {"httpurl", ""}}, failures(t, v, testRequest{Website: website}), website)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	v := newValidator()
+
+	assert.Nil(t, failures(t, v, testRequest{Slug: "hello-world"}))
+	for _, slug := range []string{"Hello", "hello--world", "-hello", "héllo"} {
+		assert.Equal(t, map[string][2]string{"slug": {"slug", ""}}, failures(t, v, testRequest{Slug: slug}), slug)
+	}
+}
+
+func TestNotBefore(t *testing.T) {
+	v := newValidator()
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, 0, -1)
+	after := start.AddDate(0, 1, 0)
+
+	assert.Nil(t, failures(t, v, testRequest{StartDate: start, EndDate: &after}))
+	assert.Nil(t, failures(t, v, testRequest{StartDate: start, EndDate: &start}))
+	assert.Nil(t, failures(t, v, testRequest{EndDate: &before}), "a missing start date is not compared")
+	assert.Equal(t, map[string][2]string{"end_date": {"notbefore", "start_date"}},
+		failures(t, v, testRequest{StartDate: start, EndDate: &before}))
+}
+
+func TestMessage(t *testing.T) {
+	assert.Equal(t, "must be at most 255 characters long", Message(English, "max_length", "255"))
+	assert.Equal(t, "maksimal 255 karakter", Message(Indonesian, "max_length", "255"))
+	assert.Equal(t, "must be one of: github, gitlab", Message(English, "oneof", "github gitlab"))
+	assert.Equal(t, "is invalid", Message(English, "unknown_rule", ""))
+	assert.Equal(t, "is required", Message(Language("fr"), "required", ""))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Language
+	}{
+		{"", English},
+		{"id", Indonesian},
+		{"id-ID,id;q=0.9,en;q=0.8", Indonesian},
+		{"en-US,en;q=0.9,id;q=0.8", English},
+		{"fr-FR,id;q=0.5", Indonesian},
+		{"in", Indonesian},
+		{"en;q=0.2, id;q=0.7", Indonesian},
+		{"id;q=0", English},
+		{"fr, de", English},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ParseAcceptLanguage(tt.header), tt.header)
+	}
+}